@@ -0,0 +1,204 @@
+// Package async 基于 Future 的异步结果工具
+package async
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+// Future 异步计算的结果，计算完成后可被任意多个调用方并发读取
+type Future[T any] struct {
+	ctx  context.Context
+	done chan struct{}
+	val  T
+	err  error
+}
+
+// Go 在新的 goroutine 中运行 fn 并返回其 Future；fn 的 panic 会转为 *reflecting.PanicError
+//
+//	@param ctx context.Context 传给 fn，也作为 Then、Catch 后续计算的 ctx
+//	@param fn func(ctx context.Context) (T, error)
+//	@return *Future[T]
+//	@update 2026-10-17 20:08:51
+//
+// for example:
+//
+//	user := async.Go(ctx, func(ctx context.Context) (*User, error) { return loadUser(ctx, id) })
+//	orders := async.Go(ctx, func(ctx context.Context) ([]Order, error) { return loadOrders(ctx, id) })
+//	u, err := user.Await(ctx)
+func Go[T any](ctx context.Context, fn func(ctx context.Context) (T, error)) *Future[T] {
+	f := &Future[T]{ctx: ctx, done: make(chan struct{})}
+	go func() {
+		defer close(f.done)
+		defer func() {
+			if r := recover(); r != nil {
+				f.err = reflecting.NewPanicError(r)
+			}
+		}()
+		f.val, f.err = fn(ctx)
+	}()
+	return f
+}
+
+// Resolved 返回已完成的 Future
+//
+//	@param v T
+//	@param err error
+//	@return *Future[T]
+//	@update 2026-10-17 20:08:51
+func Resolved[T any](v T, err error) *Future[T] {
+	f := &Future[T]{ctx: context.Background(), done: make(chan struct{}), val: v, err: err}
+	close(f.done)
+	return f
+}
+
+// Await 等待计算完成并返回结果；ctx 先结束时返回 ctx.Err()，计算本身不受影响
+//
+//	@receiver f *Future[T]
+//	@param ctx context.Context
+//	@return T
+//	@return error
+//	@update 2026-10-17 20:08:51
+func (f *Future[T]) Await(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.val, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// AwaitTimeout 最多等待 d，超时返回 context.DeadlineExceeded
+//
+//	@receiver f *Future[T]
+//	@param d time.Duration
+//	@return T
+//	@return error
+//	@update 2026-10-17 20:08:51
+func (f *Future[T]) AwaitTimeout(d time.Duration) (T, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	return f.Await(ctx)
+}
+
+// Done 返回计算完成时关闭的 channel，便于在 select 中使用
+//
+//	@receiver f *Future[T]
+//	@return <-chan struct{}
+//	@update 2026-10-17 20:08:51
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Catch 计算出错时调用 fn 尝试恢复，成功时原样传递结果
+//
+//	@receiver f *Future[T]
+//	@param fn func(err error) (T, error)
+//	@return *Future[T]
+//	@update 2026-10-17 20:08:51
+func (f *Future[T]) Catch(fn func(err error) (T, error)) *Future[T] {
+	return Go(f.ctx, func(ctx context.Context) (T, error) {
+		<-f.done
+		if f.err == nil {
+			return f.val, nil
+		}
+		return fn(f.err)
+	})
+}
+
+// Then 计算成功后以其结果调用 fn，出错时跳过 fn 并传递错误
+//
+//	@param f *Future[T]
+//	@param fn func(ctx context.Context, v T) (R, error)
+//	@return *Future[R]
+//	@update 2026-10-17 20:08:51
+//
+// for example:
+//
+//	name := async.Then(user, func(ctx context.Context, u *User) (string, error) { return u.Name, nil })
+func Then[T, R any](f *Future[T], fn func(ctx context.Context, v T) (R, error)) *Future[R] {
+	return Go(f.ctx, func(ctx context.Context) (R, error) {
+		<-f.done
+		if f.err != nil {
+			var zero R
+			return zero, f.err
+		}
+		return fn(ctx, f.val)
+	})
+}
+
+// All 等待全部 Future 成功并按顺序返回结果；任一出错时立即以该错误完成，ctx 结束时以 ctx.Err() 完成
+//
+//	@param ctx context.Context
+//	@param futures ...*Future[T]
+//	@return *Future[[]T]
+//	@update 2026-10-17 20:08:51
+func All[T any](ctx context.Context, futures ...*Future[T]) *Future[[]T] {
+	return Go(ctx, func(ctx context.Context) ([]T, error) {
+		results := make([]T, len(futures))
+		done := make(chan int, len(futures))
+		for i, f := range futures {
+			go func() {
+				select {
+				case <-f.done:
+					done <- i
+				case <-ctx.Done():
+				}
+			}()
+		}
+		for range futures {
+			select {
+			case i := <-done:
+				if err := futures[i].err; err != nil {
+					return nil, err
+				}
+				results[i] = futures[i].val
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		return results, nil
+	})
+}
+
+// Any 以首个成功的 Future 的结果完成；全部失败时返回合并后的错误，ctx 结束时以 ctx.Err() 完成
+//
+//	@param ctx context.Context
+//	@param futures ...*Future[T]
+//	@return *Future[T]
+//	@update 2026-10-17 20:08:51
+func Any[T any](ctx context.Context, futures ...*Future[T]) *Future[T] {
+	return Go(ctx, func(ctx context.Context) (T, error) {
+		var zero T
+		if len(futures) == 0 {
+			return zero, errors.New("async: Any called with no futures")
+		}
+		done := make(chan int, len(futures))
+		for i, f := range futures {
+			go func() {
+				select {
+				case <-f.done:
+					done <- i
+				case <-ctx.Done():
+				}
+			}()
+		}
+		errs := make([]error, len(futures))
+		for range futures {
+			select {
+			case i := <-done:
+				if futures[i].err == nil {
+					return futures[i].val, nil
+				}
+				errs[i] = futures[i].err
+			case <-ctx.Done():
+				return zero, ctx.Err()
+			}
+		}
+		return zero, errors.Join(errs...)
+	})
+}
@@ -0,0 +1,307 @@
+// Package batch 按条数或最大延迟将零散数据攒批处理，用于日志投递、批量写入等场景
+package batch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+// ErrClosed Processor 已关闭，不再接受数据
+var ErrClosed = errors.New("batch: closed")
+
+// job 交给 worker 的一个批次，done 不为 nil 时表示 Flush 的等待点
+type job[T any] struct {
+	items []T
+	done  chan struct{}
+}
+
+// Processor 攒批处理器，Add 可在任意 goroutine 调用，批次按交付顺序由单个 goroutine 串行交给 handler
+type Processor[T any] struct {
+	handler func(context.Context, []T) error
+	onError atomic.Pointer[func([]T, error)]
+	size    int
+	maxWait time.Duration
+
+	mu      sync.Mutex
+	buf     []T
+	timer   *time.Timer
+	gen     uint64
+	closed  bool
+	queue   []job[T]      // 已交付、尚未被 worker 取走的批次
+	pending int           // Add 交付批次时 queue 的长度上限
+	ready   *sync.Cond    // queue 非空或已关闭时唤醒 worker
+	space   chan struct{} // worker 取走批次或关闭时关闭并替换，唤醒等待 queue 空位的 Add
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// New 创建并启动攒批处理器，缓冲达到 WithSize 条或第一条等待超过 WithMaxWait 时将当前批次交给 handler
+//
+//	handler 收到的切片归其所有；handler 的 ctx 在 Close 超时后被取消，panic 会被恢复并交给 OnError
+//
+//	@param handler func(ctx context.Context, items []T) error
+//	@param opts ...Option
+//	@return *Processor[T]
+//	@update 2026-10-18 14:00:36
+//
+// for example:
+//
+//	p := batch.New(func(ctx context.Context, rows []Row) error {
+//		return db.BulkInsert(ctx, rows)
+//	}, batch.WithSize(500), batch.WithMaxWait(200*time.Millisecond)).OnError(func(rows []Row, err error) {
+//		slog.Error("bulk insert failed", "rows", len(rows), "err", err)
+//	})
+//	defer p.Close(context.Background())
+//	_ = p.Add(ctx, row)
+func New[T any](handler func(ctx context.Context, items []T) error, opts ...Option) *Processor[T] {
+	o := options{size: defaultSize, maxWait: defaultMaxWait, pending: 1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	p := &Processor[T]{
+		handler: handler,
+		size:    max(o.size, 1),
+		maxWait: o.maxWait,
+		pending: max(o.pending, 1),
+		space:   make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	p.ready = sync.NewCond(&p.mu)
+	p.ctx, p.cancel = context.WithCancel(context.Background())
+	go p.work()
+	return p
+}
+
+// OnError handler 返回错误或 panic 时调用 fn，fn 为 nil 时忽略错误（默认）；返回 p 以便链式调用
+//
+//	应在 Add 之前调用，调用之前已处理的批次不会回调
+//
+//	@receiver p *Processor[T]
+//	@param fn func(items []T, err error)
+//	@return *Processor[T]
+//	@update 2026-10-18 14:00:36
+func (p *Processor[T]) OnError(fn func(items []T, err error)) *Processor[T] {
+	if fn == nil {
+		p.onError.Store(nil)
+	} else {
+		p.onError.Store(&fn)
+	}
+	return p
+}
+
+// Add 加入数据，缓冲满时将批次交给 handler；已满的批次超过 WithPending 时阻塞，直到 handler 跟上或 ctx 结束
+//
+//	ctx 结束时返回 ctx.Err()，此时 items 已在缓冲中，会随后续批次处理；关闭后返回 ErrClosed
+//
+//	@receiver p *Processor[T]
+//	@param ctx context.Context
+//	@param items ...T
+//	@return error
+//	@update 2026-10-18 11:01:37
+func (p *Processor[T]) Add(ctx context.Context, items ...T) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return ErrClosed
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	if len(p.buf) == 0 {
+		p.startTimer()
+	}
+	p.buf = append(p.buf, items...)
+	for len(p.buf) >= p.size {
+		if len(p.queue) >= p.pending {
+			// 释放锁等待 worker 取走批次，期间其他 Add、计时器与 Close 可以继续推进；
+			// 醒来后缓冲可能已被交付，重新检查循环条件
+			space := p.space
+			p.mu.Unlock()
+			select {
+			case <-space:
+			case <-ctx.Done():
+				p.mu.Lock()
+				return ctx.Err()
+			}
+			p.mu.Lock()
+			continue
+		}
+		p.enqueue(job[T]{items: p.buf[:p.size:p.size]})
+		p.buf = append([]T(nil), p.buf[p.size:]...)
+		p.resetTimer()
+	}
+	return nil
+}
+
+// Flush 将当前缓冲交给 handler，并等待此前的所有批次处理完毕；ctx 结束时返回 ctx.Err()，批次仍会被处理
+//
+//	@receiver p *Processor[T]
+//	@param ctx context.Context
+//	@return error
+//	@update 2026-10-18 11:01:37
+func (p *Processor[T]) Flush(ctx context.Context) error {
+	done := make(chan struct{})
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return ErrClosed
+	}
+	// 等待点紧跟当前缓冲入队，不受 WithPending 限制，因此不会阻塞
+	p.handoff()
+	p.enqueue(job[T]{done: done})
+	p.mu.Unlock()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close 停止接受数据，将剩余缓冲交给 handler 并等待所有批次处理完毕
+//
+//	ctx 先结束时取消 handler 的 ctx 并返回 ctx.Err()，worker 仍会以已取消的 ctx 处理完剩余批次后退出；
+//	阻塞在 WithPending 上的 Add 返回 nil，其数据随剩余批次处理；可重复调用
+//
+//	@receiver p *Processor[T]
+//	@param ctx context.Context
+//	@return error
+//	@update 2026-10-18 11:01:37
+func (p *Processor[T]) Close(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		p.handoff()
+		p.ready.Signal()
+		p.wakeAdders()
+	}
+	p.mu.Unlock()
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+		return ctx.Err()
+	}
+}
+
+// Len 返回缓冲中尚未交给 handler 的条数
+//
+//	@receiver p *Processor[T]
+//	@return int
+//	@update 2026-10-18 05:52:06
+func (p *Processor[T]) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.buf)
+}
+
+// handoff 将整个缓冲作为一个批次交给 worker，不受 WithPending 限制，调用方持有 p.mu
+func (p *Processor[T]) handoff() {
+	if len(p.buf) == 0 {
+		return
+	}
+	p.enqueue(job[T]{items: p.buf})
+	p.buf = nil
+	p.resetTimer()
+}
+
+// enqueue 批次入队并唤醒 worker，调用方持有 p.mu
+func (p *Processor[T]) enqueue(j job[T]) {
+	p.queue = append(p.queue, j)
+	p.ready.Signal()
+}
+
+// wakeAdders 唤醒所有等待 queue 空位的 Add，调用方持有 p.mu
+func (p *Processor[T]) wakeAdders() {
+	close(p.space)
+	p.space = make(chan struct{})
+}
+
+// next 取出下一个批次，queue 为空时等待；已关闭且 queue 为空时返回 false
+func (p *Processor[T]) next() (job[T], bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for len(p.queue) == 0 {
+		if p.closed {
+			return job[T]{}, false
+		}
+		p.ready.Wait()
+	}
+	j := p.queue[0]
+	p.queue[0] = job[T]{}
+	p.queue = p.queue[1:]
+	p.wakeAdders()
+	return j, true
+}
+
+// startTimer 第一条数据进入缓冲时开始计时，调用方持有 p.mu
+func (p *Processor[T]) startTimer() {
+	if p.maxWait <= 0 {
+		return
+	}
+	gen := p.gen
+	p.timer = time.AfterFunc(p.maxWait, func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		// 计时期间缓冲已被交付（gen 变化）或已关闭时忽略
+		if p.gen != gen || p.closed {
+			return
+		}
+		p.handoff()
+	})
+}
+
+// resetTimer 缓冲交付后作废当前计时，剩余数据重新计时，调用方持有 p.mu
+func (p *Processor[T]) resetTimer() {
+	p.gen++
+	if p.timer != nil {
+		p.timer.Stop()
+		p.timer = nil
+	}
+	if len(p.buf) > 0 {
+		p.startTimer()
+	}
+}
+
+func (p *Processor[T]) work() {
+	defer func() {
+		p.cancel()
+		close(p.done)
+	}()
+	for {
+		j, ok := p.next()
+		if !ok {
+			return
+		}
+		if len(j.items) > 0 {
+			if err := p.run(j.items); err != nil {
+				if fn := p.onError.Load(); fn != nil {
+					(*fn)(j.items, err)
+				}
+			}
+		}
+		if j.done != nil {
+			close(j.done)
+		}
+	}
+}
+
+// run 处理单个批次，handler 的 panic 转换为 *reflecting.PanicError
+func (p *Processor[T]) run(items []T) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = reflecting.NewPanicError(r)
+		}
+	}()
+	return p.handler(p.ctx, items)
+}
@@ -0,0 +1,31 @@
+package batch_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/BetaGoRobot/go_utils/batch"
+	"github.com/BetaGoRobot/go_utils/testx"
+)
+
+func TestProcessorOnError(t *testing.T) {
+	errBoom := errors.New("boom")
+	var mu sync.Mutex
+	var failed [][]int
+	p := batch.New(func(_ context.Context, items []int) error {
+		if items[0] == 0 {
+			return errBoom
+		}
+		return nil
+	}, batch.WithSize(2), batch.WithMaxWait(0)).OnError(func(items []int, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		testx.ErrorIs(t, err, errBoom)
+		failed = append(failed, items)
+	})
+	testx.ErrorIs(t, p.Add(context.Background(), 0, 1, 2, 3, 4), nil)
+	testx.ErrorIs(t, p.Close(context.Background()), nil)
+	testx.DeepEqual(t, [][]int{{0, 1}}, failed)
+}
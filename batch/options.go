@@ -0,0 +1,44 @@
+package batch
+
+import "time"
+
+const (
+	defaultSize    = 100
+	defaultMaxWait = time.Second
+)
+
+type options struct {
+	size    int
+	maxWait time.Duration
+	pending int
+}
+
+// Option New 的选项
+type Option func(*options)
+
+// WithSize 缓冲达到 n 条时立即交给 handler，默认 100
+//
+//	@param n int
+//	@return Option
+//	@update 2026-10-18 05:52:06
+func WithSize(n int) Option {
+	return func(o *options) { o.size = n }
+}
+
+// WithMaxWait 第一条进入缓冲后最多等待 d 即交给 handler，即一条数据的最大延迟（不含排队），默认 1s；<= 0 表示只按条数触发
+//
+//	@param d time.Duration
+//	@return Option
+//	@update 2026-10-18 05:52:06
+func WithMaxWait(d time.Duration) Option {
+	return func(o *options) { o.maxWait = d }
+}
+
+// WithPending 已满但 handler 尚未开始处理的批次数上限，超过时 Add 阻塞（背压），默认 1，<= 0 时按 1 处理
+//
+//	@param n int
+//	@return Option
+//	@update 2026-10-18 11:01:37
+func WithPending(n int) Option {
+	return func(o *options) { o.pending = n }
+}
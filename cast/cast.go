@@ -0,0 +1,257 @@
+// Package cast 将 any 转换为具体类型，适用于 JSON / YAML 解析出的弱类型数据；转换失败时返回错误而不是 panic
+package cast
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCast 无法转换，返回的错误满足 errors.Is(err, ErrInvalidCast)
+var ErrInvalidCast = errors.New("cast: invalid cast")
+
+func castErr(v any, to string) error {
+	return fmt.Errorf("%w: %T(%v) to %s", ErrInvalidCast, v, v, to)
+}
+
+// indirect 解开指针，nil 指针返回 nil
+func indirect(v any) any {
+	if v == nil {
+		return nil
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer {
+		return v
+	}
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	return rv.Interface()
+}
+
+// ToString 转换为字符串：数字按十进制且不带多余的零，bool 为 true/false，time.Time 为 RFC 3339，
+// []byte 直接转换，实现了 fmt.Stringer、error 或 encoding.TextMarshaler 的类型调用对应方法；nil 为空字符串
+//
+//	@param v any
+//	@return string
+//	@return error
+//	@update 2026-10-18 08:02:14
+func ToString(v any) (string, error) {
+	switch x := indirect(v).(type) {
+	case nil:
+		return "", nil
+	case string:
+		return x, nil
+	case []byte:
+		return string(x), nil
+	case bool:
+		return strconv.FormatBool(x), nil
+	case json.Number:
+		return x.String(), nil
+	case time.Time:
+		return x.Format(time.RFC3339Nano), nil
+	case error:
+		return x.Error(), nil
+	case fmt.Stringer:
+		return x.String(), nil
+	case encoding.TextMarshaler:
+		b, err := x.MarshalText()
+		if err != nil {
+			return "", fmt.Errorf("%w: %w", ErrInvalidCast, err)
+		}
+		return string(b), nil
+	}
+	rv := reflect.ValueOf(indirect(v))
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'f', -1, rv.Type().Bits()), nil
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), nil
+	}
+	return "", castErr(v, "string")
+}
+
+// ToInt64 转换为 int64：整数溢出时报错，浮点数须为整数值（JSON 解析出的 3.0 可以转换，3.5 不行），
+// 字符串按十进制解析（允许首尾空白与整数值的小数写法），bool 为 0/1，time.Duration 为纳秒；nil 为 0
+//
+//	@param v any
+//	@return int64
+//	@return error
+//	@update 2026-10-18 08:02:14
+func ToInt64(v any) (int64, error) {
+	switch x := indirect(v).(type) {
+	case nil:
+		return 0, nil
+	case bool:
+		if x {
+			return 1, nil
+		}
+		return 0, nil
+	case json.Number:
+		return parseInt(x.String(), v)
+	case string:
+		return parseInt(x, v)
+	case []byte:
+		return parseInt(string(x), v)
+	}
+	rv := reflect.ValueOf(indirect(v))
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if rv.Uint() > math.MaxInt64 {
+			return 0, castErr(v, "int64")
+		}
+		return int64(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return floatToInt(rv.Float(), v)
+	case reflect.String:
+		return parseInt(rv.String(), v)
+	}
+	return 0, castErr(v, "int64")
+}
+
+func parseInt(s string, orig any) (int64, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return n, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, castErr(orig, "int64")
+	}
+	return floatToInt(f, orig)
+}
+
+func floatToInt(f float64, orig any) (int64, error) {
+	// float64(math.MaxInt64) 会舍入为 2^63，因此上界使用 >=
+	if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, castErr(orig, "int64")
+	}
+	return int64(f), nil
+}
+
+// ToInt 转换为 int，规则同 ToInt64，超出 int 范围时报错
+//
+//	@param v any
+//	@return int
+//	@return error
+//	@update 2026-10-18 08:02:14
+func ToInt(v any) (int, error) {
+	n, err := ToInt64(v)
+	if err != nil {
+		return 0, err
+	}
+	if n < math.MinInt || n > math.MaxInt {
+		return 0, castErr(v, "int")
+	}
+	return int(n), nil
+}
+
+// ToFloat64 转换为 float64：整数直接转换，字符串按 strconv.ParseFloat 解析，bool 为 0/1；nil 为 0
+//
+//	@param v any
+//	@return float64
+//	@return error
+//	@update 2026-10-18 08:02:14
+func ToFloat64(v any) (float64, error) {
+	switch x := indirect(v).(type) {
+	case nil:
+		return 0, nil
+	case bool:
+		if x {
+			return 1, nil
+		}
+		return 0, nil
+	case json.Number:
+		return parseFloat(x.String(), v)
+	case string:
+		return parseFloat(x, v)
+	case []byte:
+		return parseFloat(string(x), v)
+	}
+	rv := reflect.ValueOf(indirect(v))
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	case reflect.String:
+		return parseFloat(rv.String(), v)
+	}
+	return 0, castErr(v, "float64")
+}
+
+func parseFloat(s string, orig any) (float64, error) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0, castErr(orig, "float64")
+	}
+	return f, nil
+}
+
+// ToBool 转换为 bool：数字非 0 为 true；字符串不区分大小写地接受 true/false、1/0、yes/no、y/n、on/off、t/f，空字符串为 false；nil 为 false
+//
+//	@param v any
+//	@return bool
+//	@return error
+//	@update 2026-10-18 08:02:14
+func ToBool(v any) (bool, error) {
+	switch x := indirect(v).(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return x, nil
+	case string:
+		return parseBool(x, v)
+	case []byte:
+		return parseBool(string(x), v)
+	case json.Number:
+		f, err := x.Float64()
+		if err != nil {
+			return false, castErr(v, "bool")
+		}
+		return f != 0, nil
+	}
+	rv := reflect.ValueOf(indirect(v))
+	switch rv.Kind() {
+	case reflect.Bool:
+		return rv.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int() != 0, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint() != 0, nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float() != 0, nil
+	case reflect.String:
+		return parseBool(rv.String(), v)
+	}
+	return false, castErr(v, "bool")
+}
+
+func parseBool(s string, orig any) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "1", "t", "true", "y", "yes", "on":
+		return true, nil
+	case "", "0", "f", "false", "n", "no", "off":
+		return false, nil
+	}
+	return false, castErr(orig, "bool")
+}
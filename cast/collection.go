@@ -0,0 +1,93 @@
+package cast
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+// ToStringSlice 转换为 []string：切片与数组的每个元素按 ToString 转换，字符串按逗号拆分并去除首尾空白（空字符串为空切片）；nil 为 nil
+//
+//	@param v any
+//	@return []string
+//	@return error
+//	@update 2026-10-18 08:02:14
+func ToStringSlice(v any) ([]string, error) {
+	switch x := indirect(v).(type) {
+	case nil:
+		return nil, nil
+	case []string:
+		return x, nil
+	case string:
+		if strings.TrimSpace(x) == "" {
+			return []string{}, nil
+		}
+		parts := strings.Split(x, ",")
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
+		return parts, nil
+	case []byte:
+		return ToStringSlice(string(x))
+	}
+	rv := reflect.ValueOf(indirect(v))
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, castErr(v, "[]string")
+	}
+	res := make([]string, rv.Len())
+	for i := range rv.Len() {
+		s, err := ToString(rv.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		res[i] = s
+	}
+	return res, nil
+}
+
+// ToMapStringAny 转换为 map[string]any：任意 key 的 map 将 key 按 ToString 转换（兼容 YAML 解析出的 map[any]any），
+// 结构体按 reflecting.StructToMap 转换，字符串与 []byte 按 JSON 对象解析；nil 为 nil
+//
+//	@param v any
+//	@return map[string]any
+//	@return error
+//	@update 2026-10-18 08:02:14
+func ToMapStringAny(v any) (map[string]any, error) {
+	switch x := indirect(v).(type) {
+	case nil:
+		return nil, nil
+	case map[string]any:
+		return x, nil
+	case string:
+		return parseJSONMap([]byte(x), v)
+	case []byte:
+		return parseJSONMap(x, v)
+	}
+	rv := reflect.ValueOf(indirect(v))
+	switch rv.Kind() {
+	case reflect.Map:
+		res := make(map[string]any, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			k, err := ToString(iter.Key().Interface())
+			if err != nil {
+				return nil, err
+			}
+			res[k] = iter.Value().Interface()
+		}
+		return res, nil
+	case reflect.Struct:
+		return reflecting.StructToMap(indirect(v)), nil
+	}
+	return nil, castErr(v, "map[string]any")
+}
+
+func parseJSONMap(data []byte, orig any) (map[string]any, error) {
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, castErr(orig, "map[string]any")
+	}
+	return m, nil
+}
@@ -0,0 +1,155 @@
+package cast
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeLayouts ToTime 依次尝试的字符串格式，不带时区的格式按 UTC 解析
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	time.DateTime,
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	time.DateOnly,
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.RFC822Z,
+	time.RFC822,
+	time.ANSIC,
+	time.UnixDate,
+	"2006/01/02 15:04:05",
+	"2006/01/02",
+}
+
+// ToTime 转换为 time.Time：字符串依次尝试 RFC 3339、2006-01-02 15:04:05、2006-01-02 等常见格式（不带时区的按 UTC），
+// 数字与纯数字字符串视为 Unix 时间戳，并按数量级识别单位：小于 1e11 为秒，小于 1e14 为毫秒，小于 1e17 为微秒，否则为纳秒；
+// 浮点数的秒保留小数部分；nil 为零值
+//
+//	@param v any
+//	@return time.Time
+//	@return error
+//	@update 2026-10-18 08:02:14
+//
+// for example:
+//
+//	cast.ToTime("2024-05-01")          // 2024-05-01 00:00:00 UTC
+//	cast.ToTime(1714521600)            // 秒
+//	cast.ToTime("1714521600123")       // 毫秒
+func ToTime(v any) (time.Time, error) {
+	switch x := indirect(v).(type) {
+	case nil:
+		return time.Time{}, nil
+	case time.Time:
+		return x, nil
+	case string:
+		return parseTime(x, v)
+	case []byte:
+		return parseTime(string(x), v)
+	case json.Number:
+		return parseTime(x.String(), v)
+	}
+	rv := reflect.ValueOf(indirect(v))
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return fromUnix(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if rv.Uint() > math.MaxInt64 {
+			return time.Time{}, castErr(v, "time.Time")
+		}
+		return fromUnix(int64(rv.Uint())), nil
+	case reflect.Float32, reflect.Float64:
+		return fromUnixFloat(rv.Float(), v)
+	case reflect.String:
+		return parseTime(rv.String(), v)
+	}
+	return time.Time{}, castErr(v, "time.Time")
+}
+
+func parseTime(s string, orig any) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return fromUnix(n), nil
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return fromUnixFloat(f, orig)
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, castErr(orig, "time.Time")
+}
+
+// fromUnix 按数量级识别时间戳的单位
+func fromUnix(n int64) time.Time {
+	abs := n
+	if abs < 0 {
+		abs = -abs
+	}
+	switch {
+	case abs < 1e11:
+		return time.Unix(n, 0)
+	case abs < 1e14:
+		return time.UnixMilli(n)
+	case abs < 1e17:
+		return time.UnixMicro(n)
+	default:
+		return time.Unix(0, n)
+	}
+}
+
+func fromUnixFloat(f float64, orig any) (time.Time, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) || math.Abs(f) >= math.MaxInt64 {
+		return time.Time{}, castErr(orig, "time.Time")
+	}
+	if math.Abs(f) >= 1e11 {
+		return fromUnix(int64(f)), nil
+	}
+	sec, frac := math.Modf(f)
+	return time.Unix(int64(sec), int64(math.Round(frac*1e9))), nil
+}
+
+// ToDuration 转换为 time.Duration：字符串按 time.ParseDuration 解析，纯数字（含数字类型与数字字符串）视为纳秒，
+// 与 time.Duration 的底层表示一致；nil 为 0
+//
+//	@param v any
+//	@return time.Duration
+//	@return error
+//	@update 2026-10-18 08:02:14
+func ToDuration(v any) (time.Duration, error) {
+	switch x := indirect(v).(type) {
+	case nil:
+		return 0, nil
+	case time.Duration:
+		return x, nil
+	case string:
+		return parseDuration(x, v)
+	case []byte:
+		return parseDuration(string(x), v)
+	}
+	n, err := ToInt64(v)
+	if err != nil {
+		return 0, castErr(v, "time.Duration")
+	}
+	return time.Duration(n), nil
+}
+
+func parseDuration(s string, orig any) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Duration(n), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, castErr(orig, "time.Duration")
+	}
+	return d, nil
+}
@@ -0,0 +1,111 @@
+package chanutil
+
+import "sync"
+
+// Broadcast 将源 channel 中的每个值复制给所有订阅者；源关闭后所有订阅者的 channel 关闭
+//
+//	订阅者接收缓慢时会阻塞广播，可通过 Subscribe 的缓冲区吸收突发
+type Broadcast[T any] struct {
+	mu     sync.Mutex
+	subs   map[*subscriber[T]]struct{}
+	closed bool
+}
+
+type subscriber[T any] struct {
+	ch   chan T
+	quit chan struct{} // 取消订阅时关闭，避免广播阻塞在已离开的订阅者上
+	once sync.Once
+}
+
+// NewBroadcast 创建从 src 读取并广播的 Broadcast
+//
+//	@param src <-chan T
+//	@return *Broadcast[T]
+//	@update 2026-10-17 20:27:13
+//
+// for example:
+//
+//	b := chanutil.NewBroadcast(events)
+//	ch, unsubscribe := b.Subscribe(16)
+//	defer unsubscribe()
+func NewBroadcast[T any](src <-chan T) *Broadcast[T] {
+	b := &Broadcast[T]{subs: map[*subscriber[T]]struct{}{}}
+	go b.run(src)
+	return b
+}
+
+// Subscribe 新增缓冲区大小为 buffer 的订阅者，只会收到订阅之后的值；
+// 返回的函数用于取消订阅，取消后不再收到新值，channel 在下一次广播或源关闭时关闭；可重复调用
+//
+//	@receiver b *Broadcast[T]
+//	@param buffer int
+//	@return <-chan T
+//	@return func()
+//	@update 2026-10-17 20:27:13
+func (b *Broadcast[T]) Subscribe(buffer int) (<-chan T, func()) {
+	s := &subscriber[T]{ch: make(chan T, buffer), quit: make(chan struct{})}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		close(s.ch)
+		return s.ch, func() {}
+	}
+	b.subs[s] = struct{}{}
+	return s.ch, func() {
+		s.once.Do(func() { close(s.quit) })
+	}
+}
+
+// Len 返回当前订阅者数量
+//
+//	@receiver b *Broadcast[T]
+//	@return int
+//	@update 2026-10-17 20:27:13
+func (b *Broadcast[T]) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := 0
+	for s := range b.subs {
+		select {
+		case <-s.quit:
+		default:
+			n++
+		}
+	}
+	return n
+}
+
+func (b *Broadcast[T]) run(src <-chan T) {
+	for v := range src {
+		for _, s := range b.snapshot() {
+			select {
+			case s.ch <- v:
+			case <-s.quit:
+			}
+		}
+	}
+	b.mu.Lock()
+	b.closed = true
+	for s := range b.subs {
+		close(s.ch)
+	}
+	clear(b.subs)
+	b.mu.Unlock()
+}
+
+// snapshot 返回仍在订阅的订阅者，并清理已取消订阅的
+func (b *Broadcast[T]) snapshot() []*subscriber[T] {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	subs := make([]*subscriber[T], 0, len(b.subs))
+	for s := range b.subs {
+		select {
+		case <-s.quit:
+			delete(b.subs, s)
+			close(s.ch)
+		default:
+			subs = append(subs, s)
+		}
+	}
+	return subs
+}
@@ -0,0 +1,150 @@
+// Package chanutil channel 常用模式：合并、分发、广播、批量与 ctx 取消
+package chanutil
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Merge 将多个 channel 合并为一个，所有输入关闭后输出关闭；不保证不同输入之间的顺序
+//
+//	@param chs ...<-chan T
+//	@return <-chan T
+//	@update 2026-10-17 20:27:13
+func Merge[T any](chs ...<-chan T) <-chan T {
+	out := make(chan T)
+	var wg sync.WaitGroup
+	for _, ch := range chs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := range ch {
+				out <- v
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// FanOut 将 ch 中的值分发到 n 个输出，每个值只会被其中一个空闲的输出取走；ch 关闭后所有输出关闭
+//
+//	@param ch <-chan T
+//	@param n int
+//	@return []<-chan T
+//	@update 2026-10-17 20:27:13
+//
+// for example:
+//
+//	for _, out := range chanutil.FanOut(jobs, 4) {
+//		go worker(out)
+//	}
+func FanOut[T any](ch <-chan T, n int) []<-chan T {
+	outs := make([]<-chan T, n)
+	for i := range outs {
+		out := make(chan T)
+		outs[i] = out
+		go func() {
+			defer close(out)
+			for v := range ch {
+				out <- v
+			}
+		}()
+	}
+	return outs
+}
+
+// OrDone 转发 ch 中的值，ctx 结束或 ch 关闭时输出关闭，避免 range ch 时无法响应取消
+//
+//	@param ctx context.Context
+//	@param ch <-chan T
+//	@return <-chan T
+//	@update 2026-10-17 20:27:13
+//
+// for example:
+//
+//	for v := range chanutil.OrDone(ctx, events) {
+//		handle(v)
+//	}
+func OrDone[T any](ctx context.Context, ch <-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-ch:
+				if !ok {
+					return
+				}
+				select {
+				case out <- v:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// Batch 将 ch 中的值按批输出：凑满 size 个，或首个值到达后等待超过 maxWait 时输出一批；
+// ch 关闭时输出剩余的值后关闭
+//
+//	@param ch <-chan T
+//	@param size int
+//	@param maxWait time.Duration
+//	@return <-chan []T
+//	@update 2026-10-17 20:27:13
+func Batch[T any](ch <-chan T, size int, maxWait time.Duration) <-chan []T {
+	out := make(chan []T)
+	go func() {
+		defer close(out)
+		timer := time.NewTimer(maxWait)
+		timer.Stop()
+		var batch []T
+		flush := func() {
+			timer.Stop()
+			if len(batch) > 0 {
+				out <- batch
+				batch = nil
+			}
+		}
+		for {
+			select {
+			case v, ok := <-ch:
+				if !ok {
+					flush()
+					return
+				}
+				if len(batch) == 0 {
+					timer.Reset(maxWait)
+				}
+				if batch = append(batch, v); len(batch) >= size {
+					flush()
+				}
+			case <-timer.C:
+				flush()
+			}
+		}
+	}()
+	return out
+}
+
+// Drain 丢弃 ch 中剩余的值直到其关闭，返回丢弃的数量；用于让上游的发送方退出
+//
+//	@param ch <-chan T
+//	@return int
+//	@update 2026-10-17 20:27:13
+func Drain[T any](ch <-chan T) int {
+	n := 0
+	for range ch {
+		n++
+	}
+	return n
+}
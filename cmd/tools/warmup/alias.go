@@ -0,0 +1,105 @@
+package main
+
+import (
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// reservedAliases 生成代码中已占用的标识符，导入别名不能与之相同
+var reservedAliases = []string{"reflecting", "v", "err"}
+
+// assignAliases 为导入路径分配互不冲突的别名，pkgNames 为导入路径到包名的映射
+//
+//	按导入路径排序后依次分配，结果只取决于输入集合：优先使用包名，
+//	冲突时在前面拼接上一级目录名（如 a/utils → a_utils），仍冲突则追加数字后缀
+func assignAliases(pkgNames map[string]string) map[string]string {
+	paths := make([]string, 0, len(pkgNames))
+	for path := range pkgNames {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+
+	used := map[string]bool{}
+	for _, r := range reservedAliases {
+		used[r] = true
+	}
+	aliases := make(map[string]string, len(paths))
+	for _, path := range paths {
+		base := sanitizeIdent(pkgNames[path])
+		if base == "" {
+			base = sanitizeIdent(lastSegment(path))
+		}
+		candidates := []string{base}
+		if parent := parentSegment(path); parent != "" {
+			candidates = append(candidates, sanitizeIdent(parent+"_"+base))
+		}
+		alias := ""
+		for _, c := range candidates {
+			if !used[c] {
+				alias = c
+				break
+			}
+		}
+		for i := 2; alias == ""; i++ {
+			if c := base + strconv.Itoa(i); !used[c] {
+				alias = c
+			}
+		}
+		used[alias] = true
+		aliases[path] = alias
+	}
+	return aliases
+}
+
+// sanitizeIdent 将任意字符串转换为合法的小写 Go 标识符，如 go-redis → go_redis、v1.2 → v1_2
+func sanitizeIdent(s string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteByte('_')
+		}
+	}
+	id := strings.Trim(sb.String(), "_")
+	if id == "" {
+		return ""
+	}
+	if unicode.IsDigit(rune(id[0])) || token.IsKeyword(id) {
+		id = "_" + id
+	}
+	return id
+}
+
+func lastSegment(path string) string {
+	return path[strings.LastIndexByte(path, '/')+1:]
+}
+
+// parentSegment 返回导入路径的上一级目录名，跳过 v2 等主版本后缀与开头的域名段（如 github.com）
+func parentSegment(path string) string {
+	segs := strings.Split(path, "/")
+	segs = segs[:len(segs)-1]
+	for i := len(segs) - 1; i >= 0; i-- {
+		s := segs[i]
+		if s == "" || isMajorVersion(s) || (i == 0 && strings.Contains(s, ".")) {
+			continue
+		}
+		return s
+	}
+	return ""
+}
+
+func isMajorVersion(s string) bool {
+	if len(s) < 2 || s[0] != 'v' {
+		return false
+	}
+	for _, r := range s[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"testing"
+
+	"github.com/BetaGoRobot/go_utils/testx"
+)
+
+func TestAssignAliases(t *testing.T) {
+	tests := []struct {
+		name     string
+		pkgNames map[string]string
+		want     map[string]string
+	}{
+		{
+			name: "collision uses parent segment",
+			pkgNames: map[string]string{
+				"github.com/a/utils": "utils",
+				"github.com/b/utils": "utils",
+			},
+			want: map[string]string{
+				"github.com/a/utils": "utils",
+				"github.com/b/utils": "b_utils",
+			},
+		},
+		{
+			name: "collision falls back to numeric suffix",
+			pkgNames: map[string]string{
+				"x.com/a/utils": "utils",
+				"y.com/a/utils": "utils",
+				"z.com/a/utils": "utils",
+			},
+			want: map[string]string{
+				"x.com/a/utils": "utils",
+				"y.com/a/utils": "a_utils",
+				"z.com/a/utils": "utils2",
+			},
+		},
+		{
+			name: "dot segments",
+			pkgNames: map[string]string{
+				"gopkg.in/yaml.v3":          "yaml",
+				"example.com/other/yaml.v2": "yaml",
+				"example.com/foo.bar":       "",
+			},
+			want: map[string]string{
+				"example.com/foo.bar":       "foo_bar",
+				"example.com/other/yaml.v2": "yaml",
+				"gopkg.in/yaml.v3":          "yaml2",
+			},
+		},
+		{
+			name: "hyphenated module paths",
+			pkgNames: map[string]string{
+				"github.com/acme/redis":     "redis",
+				"github.com/go-redis/redis": "redis",
+				"github.com/acme/go-kit":    "",
+			},
+			want: map[string]string{
+				"github.com/acme/go-kit":    "go_kit",
+				"github.com/acme/redis":     "redis",
+				"github.com/go-redis/redis": "go_redis_redis",
+			},
+		},
+		{
+			name: "major version suffix is skipped",
+			pkgNames: map[string]string{
+				"github.com/a/log":         "log",
+				"github.com/b/zap/v2/log":  "log",
+				"github.com/c/metrics/v10": "metrics",
+			},
+			want: map[string]string{
+				"github.com/a/log":         "log",
+				"github.com/b/zap/v2/log":  "zap_log",
+				"github.com/c/metrics/v10": "metrics",
+			},
+		},
+		{
+			name: "reserved names, keywords and leading digits",
+			pkgNames: map[string]string{
+				"example.com/reflecting": "reflecting",
+				"example.com/a/v":        "v",
+				"example.com/x/2fa":      "",
+				"example.com/y/go":       "",
+			},
+			want: map[string]string{
+				"example.com/a/v":        "a_v",
+				"example.com/reflecting": "reflecting2",
+				"example.com/x/2fa":      "_2fa",
+				"example.com/y/go":       "_go",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testx.DeepEqual(t, tt.want, assignAliases(tt.pkgNames))
+		})
+	}
+}
+
+func TestAssignAliasesDeterministic(t *testing.T) {
+	pkgNames := map[string]string{}
+	for i := range 50 {
+		pkgNames[fmt.Sprintf("github.com/org%d/utils", i%7)+fmt.Sprintf("/sub%d/utils", i)] = "utils"
+		pkgNames[fmt.Sprintf("example.com/m-%d/go-utils", i)] = ""
+	}
+	want := assignAliases(pkgNames)
+	seen := map[string]bool{}
+	for _, alias := range want {
+		testx.Equal(t, false, seen[alias], "duplicate alias %s", alias)
+		seen[alias] = true
+	}
+	for range 20 {
+		// 每次复制得到的 map 迭代顺序不同，结果应保持一致
+		testx.DeepEqual(t, want, assignAliases(maps.Clone(pkgNames)))
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"go/ast"
+	"go/build/constraint"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// 受构建约束的源文件中找到的函数，会生成到带同样约束的 warmup_<约束>.gen.go 中，
+// 避免在其他平台上引用不存在的函数；约束来自 //go:build 行与 _GOOS、_GOARCH 文件名后缀。
+// 只有 -emit=init 的逐包模式会拆分文件（init 可以重复定义），其余模式跳过受约束的函数
+
+var knownOS = map[string]bool{
+	"aix": true, "android": true, "darwin": true, "dragonfly": true, "freebsd": true, "hurd": true,
+	"illumos": true, "ios": true, "js": true, "linux": true, "nacl": true, "netbsd": true, "openbsd": true,
+	"plan9": true, "solaris": true, "wasip1": true, "windows": true, "zos": true,
+}
+
+var knownArch = map[string]bool{
+	"386": true, "amd64": true, "amd64p32": true, "arm": true, "armbe": true, "arm64": true, "arm64be": true,
+	"loong64": true, "mips": true, "mipsle": true, "mips64": true, "mips64le": true, "mips64p32": true,
+	"mips64p32le": true, "ppc": true, "ppc64": true, "ppc64le": true, "riscv": true, "riscv64": true,
+	"s390": true, "s390x": true, "sparc": true, "sparc64": true, "wasm": true,
+}
+
+// fileConstraint 返回源文件的构建约束表达式，无约束时返回空串
+func fileConstraint(file *ast.File, filename string) string {
+	var exprs []constraint.Expr
+	for _, group := range file.Comments {
+		if group.Pos() >= file.Package {
+			break
+		}
+		for _, c := range group.List {
+			if !constraint.IsGoBuild(c.Text) {
+				continue
+			}
+			if expr, err := constraint.Parse(c.Text); err == nil {
+				exprs = append(exprs, expr)
+			}
+		}
+	}
+	exprs = append(exprs, filenameConstraint(filepath.Base(filename))...)
+	if len(exprs) == 0 {
+		return ""
+	}
+	expr := exprs[0]
+	for _, e := range exprs[1:] {
+		expr = &constraint.AndExpr{X: expr, Y: e}
+	}
+	return expr.String()
+}
+
+// filenameConstraint 按 go/build 的规则解析 name_GOOS_GOARCH.go 形式的隐式约束
+func filenameConstraint(name string) []constraint.Expr {
+	name = strings.TrimSuffix(strings.TrimSuffix(name, ".go"), "_test")
+	i := strings.IndexByte(name, '_')
+	if i < 0 {
+		return nil
+	}
+	l := strings.Split(name[i:], "_")
+	n := len(l)
+	tag := func(s string) constraint.Expr { return &constraint.TagExpr{Tag: s} }
+	switch {
+	case n >= 2 && knownOS[l[n-2]] && knownArch[l[n-1]]:
+		return []constraint.Expr{tag(l[n-2]), tag(l[n-1])}
+	case n >= 1 && (knownOS[l[n-1]] || knownArch[l[n-1]]):
+		return []constraint.Expr{tag(l[n-1])}
+	}
+	return nil
+}
+
+// buildFileName 返回约束 build 对应的生成文件名，如 linux && amd64 → warmup_linux_and_amd64.gen.go
+func (c *config) buildFileName(build string) string {
+	output := c.outputFile()
+	if build == "" {
+		return output
+	}
+	var sb strings.Builder
+	for _, field := range strings.Fields(build) {
+		switch field = strings.Trim(field, "()"); {
+		case field == "&&":
+			field = "and"
+		case field == "||":
+			field = "or"
+		case strings.HasPrefix(field, "!"):
+			field = "not_" + strings.TrimLeft(field, "!")
+		}
+		if field = strings.Trim(sanitizeIdent(field), "_"); field != "" {
+			sb.WriteString("_" + field)
+		}
+	}
+	base, ext, _ := strings.Cut(output, ".")
+	name := base + sb.String() + "." + ext
+	// 自定义文件名以 .go 结尾时，避免 _GOOS、_GOARCH 后缀再附加一层隐式约束
+	if filenameConstraint(name) != nil {
+		name = base + sb.String() + "_build." + ext
+	}
+	return name
+}
+
+// isOutputFile 判断文件名是否为生成文件（含按构建约束拆分的文件）
+func (c *config) isOutputFile(name string) bool {
+	output := c.outputFile()
+	if name == output {
+		return true
+	}
+	base, ext, _ := strings.Cut(output, ".")
+	return strings.HasPrefix(name, base+"_") && strings.HasSuffix(name, "."+ext)
+}
+
+// existingOutputs 返回目录 dir 下已存在的生成文件
+func (c *config) existingOutputs(dir string) []string {
+	entries, _ := os.ReadDir(dir)
+	var paths []string
+	for _, e := range entries {
+		if !e.IsDir() && c.isOutputFile(e.Name()) {
+			paths = append(paths, filepath.Join(dir, e.Name()))
+		}
+	}
+	return paths
+}
+
+// splitByBuild 按构建约束拆分调用，返回的约束按字典序排列，无约束（空串）在最前
+func splitByBuild(calls []warmupCall) ([]string, map[string][]warmupCall) {
+	groups := map[string][]warmupCall{}
+	var builds []string
+	for _, c := range calls {
+		if _, ok := groups[c.Build]; !ok {
+			builds = append(builds, c.Build)
+		}
+		groups[c.Build] = append(groups[c.Build], c)
+	}
+	slices.Sort(builds)
+	return builds, groups
+}
+
+// addConstraint 为生成代码加上 //go:build 约束；模板或 -header 已有约束时与之合并
+func addConstraint(src []byte, build string) []byte {
+	if build == "" {
+		return src
+	}
+	var out bytes.Buffer
+	sc := bufio.NewScanner(bytes.NewReader(src))
+	merged := false
+	inHeader := true
+	for sc.Scan() {
+		line := sc.Text()
+		if inHeader && strings.HasPrefix(line, "package ") {
+			inHeader = false
+		}
+		if inHeader && !merged && constraint.IsGoBuild(line) {
+			if expr, err := constraint.Parse(line); err == nil {
+				extra, _ := constraint.Parse("//go:build " + build)
+				line = "//go:build " + (&constraint.AndExpr{X: expr, Y: extra}).String()
+				merged = true
+			}
+		}
+		out.WriteString(line + "\n")
+	}
+	if merged {
+		return out.Bytes()
+	}
+	return append([]byte("//go:build "+build+"\n\n"), src...)
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BetaGoRobot/go_utils/fsutil"
+)
+
+const (
+	defaultCacheFile = ".warmup-cache.json"
+	cacheVersion     = "5"
+)
+
+// fileStamp 单个源文件的缓存戳，大小与修改时间不变时直接复用 Hash
+type fileStamp struct {
+	Size    int64  `json:"size"`
+	ModTime int64  `json:"mod_time"`
+	Hash    string `json:"hash"`
+}
+
+// cachedPackage 包级扫描结果，Hash 为包内全部源文件内容的摘要
+type cachedPackage struct {
+	Hash  string    `json:"hash"`
+	Calls []rawCall `json:"calls"`
+}
+
+// scanCache 按文件内容摘要缓存逐包扫描结果，只有内容变化的包才需要重新类型检查
+type scanCache struct {
+	Signature string                   `json:"signature"`
+	Files     map[string]fileStamp     `json:"files"`
+	Packages  map[string]cachedPackage `json:"packages"`
+
+	path     string
+	next     scanCacheData
+	disabled bool // 禁用时只在内存中保留（供 -watch 复用），不读写缓存文件
+}
+
+type scanCacheData struct {
+	Files    map[string]fileStamp
+	Packages map[string]cachedPackage
+}
+
+func newScanCacheData() scanCacheData {
+	return scanCacheData{Files: map[string]fileStamp{}, Packages: map[string]cachedPackage{}}
+}
+
+// loadScanCache 读取缓存文件；文件不存在、损坏或配置签名不一致时返回空缓存。path 为空表示禁用缓存
+func loadScanCache(path, signature string) *scanCache {
+	c := &scanCache{path: path, disabled: path == "", next: newScanCacheData()}
+	if !c.disabled {
+		if data, err := os.ReadFile(path); err == nil {
+			_ = json.Unmarshal(data, c)
+		}
+	}
+	if c.Signature != signature {
+		c.Files, c.Packages = nil, nil
+	}
+	c.Signature = signature
+	return c
+}
+
+// lookup 返回包 pkgPath 在摘要为 hash 时缓存的扫描结果
+func (c *scanCache) lookup(pkgPath, hash string) ([]rawCall, bool) {
+	entry, ok := c.Packages[pkgPath]
+	if !ok || entry.Hash != hash {
+		return nil, false
+	}
+	c.store(pkgPath, hash, entry.Calls)
+	return entry.Calls, true
+}
+
+func (c *scanCache) store(pkgPath, hash string, calls []rawCall) {
+	c.next.Packages[pkgPath] = cachedPackage{Hash: hash, Calls: calls}
+}
+
+// packageHash 计算包内源文件（相对 absDir 的路径与内容）的摘要
+func (c *scanCache) packageHash(absDir string, files []string) (string, error) {
+	files = slices.Clone(files)
+	slices.Sort(files)
+	h := sha256.New()
+	for _, file := range files {
+		rel, _ := filepath.Rel(absDir, file)
+		rel = filepath.ToSlash(rel)
+		sum, err := c.fileHash(rel, file)
+		if err != nil {
+			return "", err
+		}
+		h.Write([]byte(rel + "\x00" + sum + "\n"))
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (c *scanCache) fileHash(rel, file string) (string, error) {
+	info, err := os.Stat(file)
+	if err != nil {
+		return "", err
+	}
+	stamp, ok := c.Files[rel]
+	if !ok || stamp.Size != info.Size() || stamp.ModTime != info.ModTime().UnixNano() {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		sum := sha256.Sum256(data)
+		stamp = fileStamp{Size: info.Size(), ModTime: info.ModTime().UnixNano(), Hash: hex.EncodeToString(sum[:])}
+	}
+	c.next.Files[rel] = stamp
+	return stamp.Hash, nil
+}
+
+// save 写回本次运行涉及的条目，已删除的文件与包随之淘汰
+func (c *scanCache) save() error {
+	c.Files, c.Packages = c.next.Files, c.next.Packages
+	c.next = newScanCacheData()
+	if c.disabled {
+		return nil
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fsutil.AtomicWriteFile(c.path, data, 0644)
+}
+
+// signature 影响扫描结果的配置项，任一变化都会使缓存整体失效
+func (c *config) signature() string {
+	markers := slices.Clone(c.Markers)
+	slices.Sort(markers)
+	insts := make([]string, 0, len(c.Instantiate))
+	for fn, hints := range c.Instantiate {
+		insts = append(insts, fn+"="+strings.Join(hints, "|"))
+	}
+	slices.Sort(insts)
+	return strings.Join([]string{
+		cacheVersion,
+		strings.Join(markers, ","),
+		strings.Join(c.Include, ","),
+		strings.Join(c.SkipFiles, ","),
+		strings.Join(insts, ","),
+		strings.Join(c.Tags, ","),
+	}, ";")
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"go/token"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	modePackage = "package"
+	modeCentral = "central"
+	centralDir  = "warmup"
+)
+
+// generateCentral 将所有包的预热调用汇总到 <dir>/warmup/warmup.gen.go 的 All() 中
+//
+//	跨包只能引用导出的函数与方法，未导出的会被跳过；
+//	构造表达式与实例化提示中只有开头的标识符会加上包名限定；受构建约束的调用会被跳过
+func generateCentral(dir string, pkgs []packageData, cfg *config) []generatedFile {
+	imports, calls := externalCalls(pkgs, modeCentral)
+	central := packageData{PackageName: centralDir, Dir: filepath.Join(dir, centralDir)}
+	return generateWarmupCode(central, imports, calls, cfg.template(centralTemplateText), false, cfg)
+}
+
+// generateSuffixed 将包 pkg 的预热代码生成到子目录 <包名><suffix> 下的独立包中，如 foo/foo_warmup，
+// 由使用方以空白导入引入，生成代码不进入原包的命名空间与覆盖率统计；同样只能预热导出的函数与方法
+func generateSuffixed(pkg packageData, cfg *config) []generatedFile {
+	name := pkg.PackageName + cfg.PackageSuffix
+	imports, calls := externalCalls([]packageData{pkg}, "package_suffix")
+	external := packageData{PackageName: name, Dir: filepath.Join(pkg.Dir, name)}
+	return generateWarmupCode(external, imports, calls, cfg.template(warmupTemplateText), cfg.Emit == emitInit, cfg)
+}
+
+// externalCalls 返回从其他包引用 pkgs 中函数所需的 import 与加上包名限定的调用，未导出的函数被跳过
+func externalCalls(pkgs []packageData, mode string) ([]importLine, []warmupCall) {
+	pkgs = slices.Clone(pkgs)
+	slices.SortFunc(pkgs, func(a, b packageData) int {
+		return strings.Compare(a.ImportPaths[a.PackageName], b.ImportPaths[b.PackageName])
+	})
+
+	exported := map[string][]warmupCall{}
+	pkgNames := map[string]string{}
+	skipped := 0
+	for _, pkg := range pkgs {
+		path := pkg.ImportPaths[pkg.PackageName]
+		for _, c := range deduplicateCalls(pkg.RawCalls) {
+			if !exportedCall(c) {
+				skipped++
+				continue
+			}
+			exported[path] = append(exported[path], c)
+			pkgNames[path] = pkg.PackageName
+		}
+	}
+
+	var imports []importLine
+	var calls []warmupCall
+	aliases := assignAliases(pkgNames)
+	for _, pkg := range pkgs {
+		path := pkg.ImportPaths[pkg.PackageName]
+		if len(exported[path]) == 0 {
+			continue
+		}
+		alias := aliases[path]
+		for _, c := range exported[path] {
+			c.Expr = qualifyExpr(alias, c.Expr)
+			calls = append(calls, c)
+		}
+		imports = append(imports, importLine{Alias: alias, Path: path})
+	}
+	if skipped > 0 {
+		logger.Info("skipped unexported functions", "count", skipped, "mode", mode)
+	}
+	return imports, calls
+}
+
+// qualifyExpr 为表达式开头的标识符加上包名限定，如 (*T).M → (*pkg.T).M、&T{} → &pkg.T{}
+func qualifyExpr(alias, expr string) string {
+	for _, prefix := range []string{"(*", "&"} {
+		if rest, ok := strings.CutPrefix(expr, prefix); ok {
+			return prefix + alias + "." + rest
+		}
+	}
+	return alias + "." + expr
+}
+
+// exportedCall 判断调用能否从其他包引用：开头的标识符与方法名均需导出
+func exportedCall(c warmupCall) bool {
+	head := strings.TrimLeft(c.Expr, "(*&")
+	if i := strings.IndexAny(head, "[]().{} "); i >= 0 {
+		head = head[:i]
+	}
+	if !token.IsExported(head) {
+		return false
+	}
+	// 方法名为顶层（不在类型实参内）最后一个 '.' 之后的部分
+	depth := 0
+	for i := len(c.Expr) - 1; i >= 0; i-- {
+		switch c.Expr[i] {
+		case ']', ')':
+			depth++
+		case '[', '(':
+			depth--
+		case '.':
+			if depth == 0 {
+				return token.IsExported(c.Expr[i+1:])
+			}
+		}
+	}
+	return true
+}
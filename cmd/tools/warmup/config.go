@@ -0,0 +1,267 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+const defaultConfigFile = "warmup.yaml"
+
+type config struct {
+	// Include 需要扫描的目录 glob（相对 -dir，支持 **），为空表示全部
+	Include []string `yaml:"include"`
+	// Exclude 不扫描的目录 glob，优先于 Include
+	Exclude []string `yaml:"exclude"`
+	// SkipFiles 不扫描的文件 glob，匹配文件名或相对路径，如 *.pb.go、mocks/**
+	SkipFiles []string `yaml:"skip_files"`
+	// Markers 额外的标记函数，格式为 <import path>.<func>，
+	// 如 github.com/acme/trace.Start；reflecting.GetCurrentFunc 与 trace.Span / trace.SpanErr 始终生效
+	Markers []string `yaml:"markers"`
+	// Mode 输出模式：package（默认，每个包生成 init 文件）或 central（集中生成 warmup 包）
+	Mode string `yaml:"mode"`
+	// Emit 生成代码的形式：init（默认，在 init 中引用函数）或 func（生成 WarmupFuncNames()）
+	Emit string `yaml:"emit"`
+	// OnDemand Emit 为 func 时不在 init 中自动调用 WarmupFuncNames()
+	OnDemand bool `yaml:"on_demand"`
+	// NestedModules 同时扫描嵌套模块（含独立 go.mod 的子目录）
+	NestedModules bool `yaml:"nested_modules"`
+	// Testdata 同时扫描 testdata 目录下的包
+	Testdata bool `yaml:"testdata"`
+	// NoGitignore 不跳过 .gitignore 忽略的目录
+	NoGitignore bool `yaml:"no_gitignore"`
+	// ExecGoimports 调用外部 goimports 而不是在进程内整理 import
+	ExecGoimports bool `yaml:"exec_goimports"`
+	// Jobs 并发解析与生成的 worker 数，<=0 时使用 GOMAXPROCS
+	Jobs int `yaml:"jobs"`
+	// Instantiate 泛型函数的实例化提示，键为 <import path>.<func> 或 <import path>.<type>.<method>，
+	// 每个值对应一组逗号分隔的类型实参，与 //warmup:instantiate 指令等价
+	Instantiate map[string][]string `yaml:"instantiate"`
+	// Tags 加载包时使用的构建标签，如 integration；受其他标签约束的文件仍会带约束生成
+	Tags []string `yaml:"tags"`
+	// Report 函数清单（JSON）的输出路径，为空表示不输出
+	Report string `yaml:"report"`
+	// Output 生成文件名，默认 warmup.gen.go；受构建约束的文件在扩展名前加上约束后缀
+	Output string `yaml:"output"`
+	// PackageSuffix 非空时生成到子目录 <包名><suffix> 下的独立包（如 foo/foo_warmup），只在 package 模式下生效
+	PackageSuffix string `yaml:"package_suffix"`
+	// Template 自定义生成文件模板的路径（相对配置文件所在目录），可使用与内置模板相同的数据与 "calls" 子模板
+	Template string `yaml:"template"`
+	// Header 自定义头部文件的路径（相对配置文件所在目录），内容原样置于每个生成文件顶部
+	Header string `yaml:"header"`
+
+	markers       map[marker]bool
+	templateText  string
+	headerText    string
+	singlePackage bool   // 由 //go:generate 调用，只扫描 -dir 对应的单个包
+	baseDir       string // 配置文件所在目录，配置中的相对路径以此为准
+}
+
+// defaultMarkers 始终生效的标记函数：它们在运行时按调用方解析函数名
+var defaultMarkers = []string{
+	"github.com/BetaGoRobot/go_utils/reflecting.GetCurrentFunc",
+	"github.com/BetaGoRobot/go_utils/trace.Span",
+	"github.com/BetaGoRobot/go_utils/trace.SpanErr",
+}
+
+// marker 标记函数：包内出现对它的调用时，调用所在函数会被预热
+type marker struct {
+	ImportPath string
+	Func       string
+}
+
+func parseMarker(s string) (marker, error) {
+	i := strings.LastIndexByte(s, '.')
+	if i <= strings.LastIndexByte(s, '/') || i == len(s)-1 {
+		return marker{}, fmt.Errorf("invalid marker %q, want <import path>.<func>", s)
+	}
+	return marker{ImportPath: s[:i], Func: s[i+1:]}, nil
+}
+
+type stringsFlag []string
+
+func (s *stringsFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringsFlag) Set(v string) error {
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*s = append(*s, item)
+		}
+	}
+	return nil
+}
+
+// loadConfig 读取配置文件；未指定 path 时使用 dir 下的 warmup.yaml，
+// searchUp 时（//go:generate 调用）继续向上查找，直到模块根目录
+func loadConfig(path string, dir string, searchUp bool) (*config, error) {
+	cfg := &config{baseDir: dir}
+	explicit := path != ""
+	if !explicit {
+		path = filepath.Join(dir, defaultConfigFile)
+		if searchUp {
+			path = findConfig(dir)
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !explicit && errors.Is(err, fs.ErrNotExist) {
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("read config %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("parse config %s: %w", path, err)
+	}
+	cfg.baseDir = filepath.Dir(path)
+	return cfg, nil
+}
+
+// findConfig 从 dir 开始逐级向上查找 warmup.yaml，到达含 go.mod 的目录为止；找不到时返回 dir 下的默认路径
+func findConfig(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return filepath.Join(dir, defaultConfigFile)
+	}
+	for d := abs; ; d = filepath.Dir(d) {
+		if path := filepath.Join(d, defaultConfigFile); fileExists(path) {
+			return path
+		}
+		if fileExists(filepath.Join(d, "go.mod")) || filepath.Dir(d) == d {
+			return filepath.Join(dir, defaultConfigFile)
+		}
+	}
+}
+
+func (c *config) merge(include, exclude, skipFiles, markers, tags []string) error {
+	c.Include = append(c.Include, include...)
+	c.Tags = append(c.Tags, tags...)
+	c.Exclude = append(c.Exclude, exclude...)
+	c.SkipFiles = append(c.SkipFiles, skipFiles...)
+	c.Markers = append(c.Markers, markers...)
+
+	c.markers = map[marker]bool{}
+	for _, s := range append(slices.Clone(defaultMarkers), c.Markers...) {
+		m, err := parseMarker(s)
+		if err != nil {
+			return err
+		}
+		c.markers[m] = true
+	}
+	return nil
+}
+
+// outputFile 返回生成文件名
+func (c *config) outputFile() string {
+	if c.Output != "" {
+		return c.Output
+	}
+	return defaultOutputFile
+}
+
+// validateOutput 检查 Output 与 PackageSuffix：文件名须为非测试的 .go 文件，后缀拼接包名后须为合法标识符
+func (c *config) validateOutput() error {
+	output := c.outputFile()
+	if filepath.Base(output) != output || !strings.HasSuffix(output, ".go") || strings.HasSuffix(output, "_test.go") {
+		return fmt.Errorf("invalid output %q, want a non-test .go file name without directories", output)
+	}
+	if c.PackageSuffix != "" && !token.IsIdentifier("p"+c.PackageSuffix) {
+		return fmt.Errorf("invalid package suffix %q", c.PackageSuffix)
+	}
+	return nil
+}
+
+// buildFlags 传给 go/packages 的构建参数
+func (c *config) buildFlags() []string {
+	if len(c.Tags) == 0 {
+		return nil
+	}
+	return []string{"-tags=" + strings.Join(c.Tags, ",")}
+}
+
+// isMarker 判断 importPath 包中的函数 name 是否为标记函数
+func (c *config) isMarker(importPath, name string) bool {
+	return c.markers[marker{ImportPath: importPath, Func: name}]
+}
+
+// skipDir 判断相对路径 rel 的目录是否应跳过
+func (c *config) skipDir(rel string) bool {
+	rel = filepath.ToSlash(rel)
+	if rel == "." {
+		return false
+	}
+	return matchAny(c.Exclude, rel)
+}
+
+// skipFile 判断相对路径 rel 的文件是否应跳过
+func (c *config) skipFile(rel string) bool {
+	rel = filepath.ToSlash(rel)
+	dir := filepath.ToSlash(filepath.Dir(rel))
+	if len(c.Include) > 0 && !matchAny(c.Include, dir) {
+		return true
+	}
+	if matchAny(c.SkipFiles, rel) || matchAny(c.SkipFiles, filepath.Base(rel)) {
+		return true
+	}
+	return false
+}
+
+func matchAny(patterns []string, rel string) bool {
+	for _, p := range patterns {
+		if globMatch(p, rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// globCache 编译后的 glob，scanPackage 在 parallel 中并发调用 globMatch
+var globCache = &sync.Map{}
+
+// globMatch 支持 *（不跨目录）、?、**（跨任意层目录）的 glob 匹配；
+// 目录模式同时匹配其所有子路径，如 gen/proto 匹配 gen/proto/v1
+func globMatch(pattern, rel string) bool {
+	pattern = strings.TrimSuffix(filepath.ToSlash(pattern), "/")
+	re, ok := globCache.Load(pattern)
+	if !ok {
+		re, _ = globCache.LoadOrStore(pattern, compileGlob(pattern))
+	}
+	return re.(*regexp.Regexp).MatchString(rel)
+}
+
+func compileGlob(pattern string) *regexp.Regexp {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch ch := pattern[i]; ch {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					i++
+					sb.WriteString("(.*/)?")
+				} else {
+					sb.WriteString(".*")
+				}
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+	sb.WriteString("(/.*)?$")
+	return regexp.MustCompile(sb.String())
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/BetaGoRobot/go_utils/testx"
+)
+
+func TestGlobMatch(t *testing.T) {
+	tests := []struct {
+		pattern, rel string
+		want         bool
+	}{
+		{"gen/proto", "gen/proto", true},
+		{"gen/proto/", "gen/proto/v1", true},
+		{"gen/*", "gen/proto/v1", true},
+		{"*_test.go", "a/x_test.go", false},
+		{"**/*_test.go", "a/b/x_test.go", true},
+		{"**/*_test.go", "x_test.go", true},
+		{"internal/?", "internal/a", true},
+		{"internal/?", "internal/ab", false},
+		{"vendor/**", "vendor/a/b", true},
+		{"a.b", "axb", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.rel, func(t *testing.T) {
+			testx.Equal(t, tt.want, globMatch(tt.pattern, tt.rel))
+		})
+	}
+}
+
+// TestGlobMatchConcurrent scanPackage 在 parallel 中调用 globMatch，配合 -race 检查缓存的并发访问
+func TestGlobMatchConcurrent(t *testing.T) {
+	got := make([]bool, 64)
+	parallel(len(got), 8, func(i int) {
+		got[i] = globMatch(fmt.Sprintf("pkg%d/**", i%8), fmt.Sprintf("pkg%d/a/b.go", i%8))
+	})
+	for i, ok := range got {
+		testx.Equal(t, true, ok, "case %d", i)
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const diffContext = 3
+
+type diffOp struct {
+	kind byte // ' ', '-', '+'
+	line string
+}
+
+// unifiedDiff 生成 old -> new 的 unified diff，old 为空表示新建文件
+func unifiedDiff(path string, oldContent, newContent []byte) string {
+	oldLines, newLines := splitLines(oldContent), splitLines(newContent)
+	ops := diffLines(oldLines, newLines)
+
+	var sb strings.Builder
+	fromName := "a/" + path
+	if len(oldContent) == 0 {
+		fromName = "/dev/null"
+	}
+	toName := "b/" + path
+	if len(newContent) == 0 {
+		toName = "/dev/null"
+	}
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+
+	oldNo, newNo := 1, 1
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			oldNo++
+			newNo++
+			i++
+			continue
+		}
+		// 找到当前 hunk 的范围：前后各保留 diffContext 行上下文，间隔不超过 2*diffContext 的改动合并
+		start := max(i-diffContext, 0)
+		end := i
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+			run := end
+			for run < len(ops) && ops[run].kind == ' ' {
+				run++
+			}
+			if run == len(ops) || run-end > 2*diffContext {
+				end = min(end+diffContext, len(ops))
+				break
+			}
+			end = run
+		}
+
+		hunkOld, hunkNew := oldNo-(i-start), newNo-(i-start)
+		var body strings.Builder
+		oldCnt, newCnt := 0, 0
+		for _, op := range ops[start:end] {
+			body.WriteByte(op.kind)
+			body.WriteString(op.line)
+			body.WriteByte('\n')
+			switch op.kind {
+			case ' ':
+				oldCnt++
+				newCnt++
+			case '-':
+				oldCnt++
+			case '+':
+				newCnt++
+			}
+		}
+		if oldCnt == 0 {
+			hunkOld--
+		}
+		if newCnt == 0 {
+			hunkNew--
+		}
+		fmt.Fprintf(&sb, "@@ -%d,%d +%d,%d @@\n", hunkOld, oldCnt, hunkNew, newCnt)
+		sb.WriteString(body.String())
+
+		for _, op := range ops[i:end] {
+			if op.kind != '+' {
+				oldNo++
+			}
+			if op.kind != '-' {
+				newNo++
+			}
+		}
+		i = end
+	}
+	return sb.String()
+}
+
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	return strings.Split(string(bytes.TrimSuffix(content, []byte("\n"))), "\n")
+}
+
+// diffLines 基于最长公共子序列计算逐行差异
+func diffLines(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, n+m)
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, diffOp{'-', a[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, diffOp{'+', b[j]})
+	}
+	return ops
+}
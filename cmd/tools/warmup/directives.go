@@ -0,0 +1,39 @@
+package main
+
+import (
+	"go/ast"
+	"strings"
+)
+
+const (
+	// ignoreDirective 写在 package 子句之前，整个文件不参与扫描
+	ignoreDirective = "//warmup:ignore"
+	// skipDirective 写在函数的 doc comment 中，该函数不会被预热
+	skipDirective = "//warmup:skip"
+)
+
+// hasDirective 判断注释组中是否有独占一行的指令 directive
+func hasDirective(group *ast.CommentGroup, directive string) bool {
+	if group == nil {
+		return false
+	}
+	for _, c := range group.List {
+		if rest, ok := strings.CutPrefix(c.Text, directive); ok && (rest == "" || rest[0] == ' ' || rest[0] == '\t') {
+			return true
+		}
+	}
+	return false
+}
+
+// fileIgnored 判断文件是否在 package 子句之前声明了 //warmup:ignore
+func fileIgnored(file *ast.File) bool {
+	for _, group := range file.Comments {
+		if group.Pos() >= file.Package {
+			break
+		}
+		if hasDirective(group, ignoreDirective) {
+			return true
+		}
+	}
+	return false
+}
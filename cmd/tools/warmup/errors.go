@@ -0,0 +1,98 @@
+package main
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+)
+
+// 进程退出码；2 保留给 flag 包的参数错误
+const (
+	exitOK      = 0
+	exitStale   = 1 // -check 发现生成文件已过期
+	exitPartial = 3 // 部分包处理失败，其余包的结果已正常输出
+	exitFailure = 4 // 全部包处理失败，或启动阶段（配置、参数）出错
+)
+
+// 失败发生的阶段
+const (
+	stageList     = "list"
+	stageHash     = "hash"
+	stageLoad     = "load"
+	stageParse    = "parse"
+	stageGenerate = "generate"
+	stageRead     = "read"
+	stageWrite    = "write"
+	stageReport   = "report"
+)
+
+// pkgError 单个包在某一阶段的失败原因
+type pkgError struct {
+	Stage string
+	Pkg   string // 包目录；无法定位到包时为模块目录或文件路径
+	Err   error
+}
+
+// errorReport 收集一次运行中各包的失败，单个包失败不中断其他包的处理，结束时统一输出
+type errorReport struct {
+	mu     sync.Mutex
+	errs   []pkgError
+	failed map[string]bool
+}
+
+var report = errorReport{failed: map[string]bool{}}
+
+// add 记录包 pkg 在 stage 阶段的失败，pkg 同时计为失败的包
+func (r *errorReport) add(stage, pkg string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errs = append(r.errs, pkgError{Stage: stage, Pkg: pkg, Err: err})
+	r.failed[pkg] = true
+}
+
+// markFailed 将 pkgs 计为失败的包而不额外记录错误，如集中模式下生成失败时模块内的所有包
+func (r *errorReport) markFailed(pkgs ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, pkg := range pkgs {
+		r.failed[pkg] = true
+	}
+}
+
+func (r *errorReport) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errs = nil
+	r.failed = map[string]bool{}
+}
+
+// log 按包汇总输出全部失败
+func (r *errorReport) log(total int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.errs) == 0 {
+		return
+	}
+	errs := slices.Clone(r.errs)
+	slices.SortStableFunc(errs, func(a, b pkgError) int {
+		return cmp.Compare(a.Pkg, b.Pkg)
+	})
+	for _, e := range errs {
+		logger.Error("package failed", "pkg", e.Pkg, "stage", e.Stage, "err", e.Err)
+	}
+	logger.Error("finished with errors", "errors", len(errs), "failed_packages", len(r.failed), "total_packages", total)
+}
+
+// exitCode 根据失败包数与本次处理的包总数 total 给出退出码
+func (r *errorReport) exitCode(total int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch {
+	case len(r.errs) == 0:
+		return exitOK
+	case len(r.failed) >= total:
+		return exitFailure
+	default:
+		return exitPartial
+	}
+}
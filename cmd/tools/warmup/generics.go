@@ -0,0 +1,121 @@
+package main
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// instantiateDirective 泛型函数的实例化提示，每行对应一组按顺序排列的类型实参，如
+//
+//	//warmup:instantiate int,string
+//	func Do[K comparable, V any](k K, v V) { ... }
+const instantiateDirective = "//warmup:instantiate"
+
+// genericReceiver 返回泛型类型方法的接收者类型名、是否为指针接收者以及类型参数个数
+func genericReceiver(funcDecl *ast.FuncDecl) (name string, isPointer bool, nTypeParams int) {
+	if funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+		return "", false, 0
+	}
+	t := funcDecl.Recv.List[0].Type
+	if star, ok := t.(*ast.StarExpr); ok {
+		t, isPointer = star.X, true
+	}
+	switch x := t.(type) {
+	case *ast.IndexExpr:
+		if ident, ok := x.X.(*ast.Ident); ok {
+			return ident.Name, isPointer, 1
+		}
+	case *ast.IndexListExpr:
+		if ident, ok := x.X.(*ast.Ident); ok {
+			return ident.Name, isPointer, len(x.Indices)
+		}
+	}
+	return "", false, 0
+}
+
+// isGeneric 判断函数本身或其接收者是否带类型参数
+func isGeneric(funcDecl *ast.FuncDecl) bool {
+	_, _, n := genericReceiver(funcDecl)
+	return funcDecl.Type.TypeParams != nil || n > 0
+}
+
+// funcKey 返回函数在配置 instantiate 中的键（不含包路径），如 Do、Cache.Get
+func funcKey(funcDecl *ast.FuncDecl) string {
+	if name, _, n := genericReceiver(funcDecl); n > 0 {
+		return name + "." + funcDecl.Name.Name
+	}
+	if name, _ := receiverName(funcDecl); name != "" {
+		return name + "." + funcDecl.Name.Name
+	}
+	return funcDecl.Name.Name
+}
+
+// instantiationHints 收集函数的实例化提示：doc comment 中的指令与配置 instantiate 中 <import path>.<func> 的条目
+func instantiationHints(funcDecl *ast.FuncDecl, pkgPath string, cfg *config) [][]string {
+	var hints [][]string
+	if funcDecl.Doc != nil {
+		for _, c := range funcDecl.Doc.List {
+			if rest, ok := strings.CutPrefix(c.Text, instantiateDirective); ok {
+				hints = append(hints, splitTypeArgs(rest))
+			}
+		}
+	}
+	for _, h := range cfg.Instantiate[pkgPath+"."+funcKey(funcDecl)] {
+		hints = append(hints, splitTypeArgs(h))
+	}
+	return hints
+}
+
+// buildGenericCalls 按实例化提示生成泛型函数的实例化表达式，类型实参个数不匹配的提示会被忽略
+func buildGenericCalls(funcDecl *ast.FuncDecl, hints [][]string) []string {
+	recv, isPointer, nRecv := genericReceiver(funcDecl)
+	want := nRecv
+	if funcDecl.Type.TypeParams != nil {
+		want = funcDecl.Type.TypeParams.NumFields()
+	}
+
+	var exprs []string
+	for _, args := range hints {
+		if len(args) != want {
+			logger.Warn("ignoring instantiation hint with wrong number of type arguments",
+				"func", funcKey(funcDecl), "want", want, "hint", strings.Join(args, ","))
+			continue
+		}
+		inst := "[" + strings.Join(args, ", ") + "]"
+		switch {
+		case nRecv == 0:
+			exprs = append(exprs, funcDecl.Name.Name+inst)
+		case isPointer:
+			exprs = append(exprs, "(*"+recv+inst+")."+funcDecl.Name.Name)
+		default:
+			exprs = append(exprs, recv+inst+"."+funcDecl.Name.Name)
+		}
+	}
+	return exprs
+}
+
+// splitTypeArgs 按顶层逗号切分类型实参列表，括号内的逗号（如 func(a, b int)、map[K]V）不切分
+func splitTypeArgs(s string) []string {
+	var args []string
+	depth, start := 0, 0
+	push := func(end int) {
+		if arg := strings.TrimSpace(s[start:end]); arg != "" {
+			args = append(args, arg)
+		}
+	}
+	for i, ch := range s {
+		switch ch {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				push(i)
+				start = i + 1
+			}
+		}
+	}
+	push(len(s))
+	return args
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/BetaGoRobot/go_utils/testx"
+)
+
+var _ = testx.RegisterUpdateFlag()
+
+// TestGenerateGolden 固定 testdata/basic 在各输出形式下的生成结果，更新：go test -run TestGenerateGolden -update
+func TestGenerateGolden(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     string
+		emit     string
+		onDemand bool
+	}{
+		{name: "init", mode: modePackage, emit: emitInit},
+		{name: "func", mode: modePackage, emit: emitFunc},
+		{name: "func_on_demand", mode: modePackage, emit: emitFunc, onDemand: true},
+		{name: "central", mode: modeCentral, emit: emitInit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join("testdata", "basic")
+			cfg, err := loadConfig("", dir, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := cfg.merge(nil, nil, nil, nil, nil); err != nil {
+				t.Fatal(err)
+			}
+			cfg.Mode, cfg.Emit, cfg.OnDemand, cfg.Jobs = tt.mode, tt.emit, tt.onDemand, 1
+
+			files := generate(&dir, cfg, loadScanCache("", cfg.signature()), true)
+			if len(files) == 0 {
+				t.Fatal("no files generated")
+			}
+			for _, f := range files {
+				rel, err := filepath.Rel(dir, f.Path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				testx.Golden(t, "generate/"+tt.name+"/"+filepath.ToSlash(rel), f.Content)
+			}
+		})
+	}
+}
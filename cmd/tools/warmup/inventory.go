@@ -0,0 +1,69 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/BetaGoRobot/go_utils/fsutil"
+)
+
+// inventoryEntry -report 输出的单个函数：调用了标记函数、会被预热的函数
+type inventoryEntry struct {
+	Package string `json:"package"`         // 包的导入路径
+	Name    string `json:"name"`            // 函数名，方法为 <类型>.<方法>
+	File    string `json:"file"`            // 标记调用所在文件，相对 -dir
+	Line    int    `json:"line"`            // 标记调用所在行
+	Build   string `json:"build,omitempty"` // 文件的构建约束
+}
+
+// buildInventory 汇总所有包中的函数，同一函数的多个泛型实例只记录一次
+func buildInventory(pkgs []packageData) []inventoryEntry {
+	seen := map[inventoryEntry]bool{}
+	entries := []inventoryEntry{}
+	for _, pkg := range pkgs {
+		for _, c := range pkg.RawCalls {
+			file, line := c.Comment, 0
+			if i := strings.LastIndexByte(c.Comment, ':'); i >= 0 {
+				file = c.Comment[:i]
+				line, _ = strconv.Atoi(c.Comment[i+1:])
+			}
+			e := inventoryEntry{
+				Package: pkg.ImportPaths[pkg.PackageName],
+				Name:    c.Func,
+				File:    file,
+				Line:    line,
+				Build:   c.Build,
+			}
+			if !seen[e] {
+				seen[e] = true
+				entries = append(entries, e)
+			}
+		}
+	}
+	slices.SortFunc(entries, func(a, b inventoryEntry) int {
+		return cmp.Or(
+			cmp.Compare(a.Package, b.Package),
+			cmp.Compare(a.File, b.File),
+			cmp.Compare(a.Line, b.Line),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+	return entries
+}
+
+// writeInventory 将函数清单以 JSON 数组写入 path
+func writeInventory(path string, pkgs []packageData) error {
+	data, err := json.MarshalIndent(buildInventory(pkgs), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return fsutil.AtomicWriteFile(path, append(data, '\n'), 0644)
+}
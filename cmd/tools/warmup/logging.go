@@ -0,0 +1,66 @@
+package main
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"time"
+)
+
+var logger = slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+// setupLogger 按 -v / -q / -log-json 配置日志：-v 输出每个找到的函数，-q 只输出警告与错误
+func setupLogger(w io.Writer, verbose, quiet, jsonOutput bool) {
+	opts := &slog.HandlerOptions{Level: slog.LevelInfo}
+	switch {
+	case verbose:
+		opts.Level = slog.LevelDebug
+	case quiet:
+		opts.Level = slog.LevelWarn
+	}
+	if jsonOutput {
+		logger = slog.New(slog.NewJSONHandler(w, opts))
+	} else {
+		logger = slog.New(slog.NewTextHandler(w, opts))
+	}
+}
+
+func fatal(msg string, args ...any) {
+	logger.Error(msg, args...)
+	os.Exit(exitFailure)
+}
+
+// runStats 一次运行的统计，只在串行阶段更新
+type runStats struct {
+	start           time.Time
+	PackagesScanned int
+	PackagesCached  int
+	PackagesFailed  int
+	CallsFound      int
+	FilesWritten    int
+	FilesRemoved    int
+	FilesUnchanged  int
+	FilesFailed     int
+}
+
+var stats = runStats{start: time.Now()}
+
+// packagesTotal 本次处理的包总数，含处理失败的包
+func (s *runStats) packagesTotal() int {
+	return s.PackagesScanned + s.PackagesFailed
+}
+
+// logSummary 输出本次运行的汇总
+func (s *runStats) logSummary() {
+	logger.Info("summary",
+		"packages_scanned", s.PackagesScanned,
+		"packages_cached", s.PackagesCached,
+		"packages_failed", s.PackagesFailed,
+		"calls_found", s.CallsFound,
+		"files_written", s.FilesWritten,
+		"files_removed", s.FilesRemoved,
+		"files_unchanged", s.FilesUnchanged,
+		"files_failed", s.FilesFailed,
+		"elapsed", time.Since(s.start).Round(time.Millisecond).String(),
+	)
+}
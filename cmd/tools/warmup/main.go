@@ -8,18 +8,25 @@ import (
 	"go/format"
 	"go/parser"
 	"go/token"
-	"log"
+	"go/types"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"slices"
 	"strings"
-	"text/template"
+	"time"
+
+	"github.com/BetaGoRobot/go_utils/fsutil"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/types/typeutil"
+	"golang.org/x/tools/imports"
 )
 
 type warmupCall struct {
 	Expr     string
 	Comments []string
+	Build    string
 }
 
 type importLine struct {
@@ -31,11 +38,21 @@ type warmupTemplateData struct {
 	PackageName string
 	Imports     []importLine
 	WarmupCalls []warmupCall
+	// FuncMode 生成 WarmupFuncNames()，逐个调用 reflecting.GetFunctionName
+	FuncMode bool
+	// OnDemand 不在 init() 中调用 WarmupFuncNames()，由使用方自行触发
+	OnDemand bool
+	// Header -header 指定的自定义头部（许可证、构建约束等），位于生成标记之前
+	Header string
 }
 
 type rawCall struct {
-	Expr    string
-	Comment string
+	Expr    string `json:"expr"`
+	Comment string `json:"comment"`
+	// Build 所在源文件的构建约束表达式，如 linux && amd64
+	Build string `json:"build,omitempty"`
+	// Func 调用所在的函数名，方法为 <类型>.<方法>，用于 -report
+	Func string `json:"func,omitempty"`
 }
 
 type packageData struct {
@@ -43,108 +60,537 @@ type packageData struct {
 	Dir         string
 	RawCalls    []rawCall
 	ImportPaths map[string]string
+	// ModuleDir 包所属模块的根目录（与 Dir 同样相对于 -dir 的基准）
+	ModuleDir string
 }
 
 func main() {
-	dir := flag.String("dir", ".", "target directory to scan")
+	var include, exclude, skipFiles, markers, tags stringsFlag
+	dir := flag.String("dir", ".", "target directory to scan; when run by go generate without -dir, only the package containing the directive is processed")
+	configPath := flag.String("config", "", "config file path (default: <dir>/"+defaultConfigFile+" if present)")
+	flag.Var(&include, "include", "directory glob to scan, relative to -dir (repeatable, comma-separated)")
+	flag.Var(&exclude, "exclude", "directory glob to skip, relative to -dir (repeatable, comma-separated)")
+	flag.Var(&skipFiles, "skip", "file glob to skip, e.g. *.pb.go (repeatable, comma-separated)")
+	flag.Var(&tags, "tags", "build tags to load packages with (repeatable, comma-separated)")
+	flag.Var(&markers, "marker", "additional marker function as <import path>.<func> (repeatable, comma-separated)")
+	check := flag.Bool("check", false, "regenerate in memory and exit non-zero with a diff if any generated file is stale, without writing")
+	dryRun := flag.Bool("dry-run", false, "print the files that would be created or changed, with a unified diff, without writing")
+	jobs := flag.Int("jobs", 0, "number of packages to parse and generate concurrently (default: GOMAXPROCS)")
+	cachePath := flag.String("cache", "", "scan cache file path (default: <dir>/"+defaultCacheFile+")")
+	noCache := flag.Bool("no-cache", false, "disable the scan cache and type-check every package")
+	watchMode := flag.Bool("watch", false, "after generating, watch -dir and regenerate affected packages on change")
+	debounce := flag.Duration("debounce", 300*time.Millisecond, "quiet period after the last change before regenerating in -watch mode")
+	mode := flag.String("mode", "", "output mode: "+modePackage+" (one init file per package, default) or "+modeCentral+" (a single "+centralDir+"/"+defaultOutputFile+" exposing All())")
+	emit := flag.String("emit", "", "generated code style: "+emitInit+" (reference functions in init, default) or "+emitFunc+" (define WarmupFuncNames() calling reflecting.GetFunctionName on each)")
+	onDemand := flag.Bool("on-demand", false, "with -emit="+emitFunc+", do not call WarmupFuncNames() from init")
+	nestedModules := flag.Bool("nested-modules", false, "also scan nested modules (directories with their own go.mod)")
+	testdata := flag.Bool("testdata", false, "also scan packages under testdata directories")
+	noGitignore := flag.Bool("no-gitignore", false, "do not skip directories ignored by .gitignore")
+	execGoimports := flag.Bool("exec-goimports", false, "run the external goimports binary instead of processing imports in-process")
+	verbose := flag.Bool("v", false, "verbose: also log every function found")
+	quiet := flag.Bool("q", false, "quiet: only log warnings and errors")
+	logJSON := flag.Bool("log-json", false, "write logs (including the final summary) as JSON lines to stderr")
+	templatePath := flag.String("template", "", "custom template file for generated files; keep the \""+generatedHeader+"\" line so stale files can be recognised")
+	headerPath := flag.String("header", "", "file whose content is placed at the top of every generated file (license, build tags, lint suppressions)")
+	output := flag.String("output", "", "generated file name (default: "+defaultOutputFile+")")
+	packageSuffix := flag.String("package-suffix", "", "generate into a separate <pkg><suffix> package in a subdirectory (e.g. _warmup), to be blank-imported; only exported functions can be warmed")
+	reportPath := flag.String("report", "", "also write a JSON inventory of every warmed function (package, name, file, line) to this path")
+	keepStale := flag.Bool("keep-stale", false, "keep generated files of packages that no longer contain any marker call")
 	flag.Parse()
+	setupLogger(os.Stderr, *verbose, *quiet, *logJSON)
+
+	// 通过 //go:generate warmup 调用（未显式指定 -dir）时只处理指令所在的包，默认不使用缓存文件
+	goGenerate := os.Getenv("GOPACKAGE") != "" && !flagSet("dir")
+	if goGenerate && !flagSet("cache") {
+		*noCache = true
+	}
+
+	cfg, err := loadConfig(*configPath, *dir, goGenerate)
+	if err != nil {
+		fatal("load config failed", "err", err)
+	}
+	if err := cfg.merge(include, exclude, skipFiles, markers, tags); err != nil {
+		fatal("invalid config", "err", err)
+	}
+	if err := cfg.loadTemplates(*templatePath, *headerPath); err != nil {
+		fatal("invalid template", "err", err)
+	}
+	if *jobs > 0 {
+		cfg.Jobs = *jobs
+	}
+	if *mode != "" {
+		cfg.Mode = *mode
+	}
+	if cfg.Mode == "" {
+		cfg.Mode = modePackage
+	}
+	if cfg.Mode != modePackage && cfg.Mode != modeCentral {
+		fatal("invalid mode", "mode", cfg.Mode, "want", modePackage+" or "+modeCentral)
+	}
+	if goGenerate && cfg.Mode == modeCentral {
+		fatal("mode " + modeCentral + " cannot be used from //go:generate, run warmup from the module root instead")
+	}
+	cfg.singlePackage = goGenerate
+	if *emit != "" {
+		cfg.Emit = *emit
+	}
+	if cfg.Emit == "" {
+		cfg.Emit = emitInit
+	}
+	if cfg.Emit != emitInit && cfg.Emit != emitFunc {
+		fatal("invalid emit", "emit", cfg.Emit, "want", emitInit+" or "+emitFunc)
+	}
+	if *reportPath != "" {
+		cfg.Report = *reportPath
+	}
+	if *output != "" {
+		cfg.Output = *output
+	}
+	if *packageSuffix != "" {
+		cfg.PackageSuffix = *packageSuffix
+	}
+	if err := cfg.validateOutput(); err != nil {
+		fatal("invalid output", "err", err)
+	}
+	cfg.OnDemand = cfg.OnDemand || *onDemand
+	cfg.NestedModules = cfg.NestedModules || *nestedModules
+	cfg.Testdata = cfg.Testdata || *testdata
+	cfg.NoGitignore = cfg.NoGitignore || *noGitignore
+	cfg.ExecGoimports = cfg.ExecGoimports || *execGoimports
+	if cfg.Jobs <= 0 {
+		cfg.Jobs = runtime.GOMAXPROCS(0)
+	}
 
-	generate(dir)
+	if *cachePath == "" {
+		*cachePath = filepath.Join(*dir, defaultCacheFile)
+	}
+	if *noCache {
+		*cachePath = ""
+	}
+	cache := loadScanCache(*cachePath, cfg.signature())
+
+	files := generate(dir, cfg, cache, *keepStale)
+	if err := cache.save(); err != nil {
+		logger.Warn("failed to save scan cache", "err", err)
+	}
+	code := exitOK
+	switch {
+	case *check:
+		stale := staleFiles(files)
+		for _, f := range stale {
+			fmt.Print(unifiedDiff(f.Path, f.Old, f.Content))
+		}
+		stats.FilesUnchanged = len(files) - len(stale)
+		if len(stale) > 0 {
+			logger.Warn("generated files are stale, run warmup to regenerate", "stale", len(stale))
+			code = exitStale
+		} else {
+			logger.Info("generated files are up to date", "files", len(files))
+		}
+	case *dryRun:
+		stale := staleFiles(files)
+		for _, f := range stale {
+			action := "update"
+			switch {
+			case f.Remove:
+				action = "remove"
+			case !f.Exists:
+				action = "create"
+			}
+			fmt.Printf("would %s %s\n", action, f.Path)
+			fmt.Print(unifiedDiff(f.Path, f.Old, f.Content))
+		}
+		stats.FilesUnchanged = len(files) - len(stale)
+		logger.Info("dry run", "would_write", len(stale), "unchanged", len(files)-len(stale))
+	default:
+		writeStale(files)
+		if *watchMode {
+			stats.logSummary()
+			report.log(stats.packagesTotal())
+			report.reset()
+			if err := watch(*dir, cfg, cache, *keepStale, *debounce); err != nil {
+				fatal("watch failed", "err", err)
+			}
+		}
+	}
+	stats.logSummary()
+	total := stats.packagesTotal()
+	report.log(total)
+	// 处理失败优先于 -check 的过期结果
+	if failed := report.exitCode(total); failed != exitOK {
+		code = failed
+	}
+	os.Exit(code)
+}
+
+// flagSet 判断命令行中是否显式指定了 flag name
+func flagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		set = set || f.Name == name
+	})
+	return set
+}
+
+type generatedFile struct {
+	Path    string
+	Content []byte
+	Remove  bool  // 包中已无标记调用，需要删除的旧文件
+	Err     error // 生成失败的原因，失败的文件不会写入
 }
 
-func generate(dir *string) {
-	pkgs := scanPackages(*dir)
-	modulePrefix := getGoModModuleName(*dir)
+// generate 扫描并生成所有文件；单个包或文件失败不影响其他包，失败原因记录到 report
+func generate(dir *string, cfg *config, cache *scanCache, keepStale bool) (files []generatedFile) {
+	pkgs, existing := scanPackages(*dir, cfg, cache)
+	if cfg.Report != "" {
+		if err := writeInventory(cfg.Report, pkgs); err != nil {
+			report.add(stageReport, cfg.Report, err)
+		}
+	}
 
-	for _, pkg := range pkgs {
+	results := make([][]generatedFile, len(pkgs))
+	parallel(len(pkgs), cfg.Jobs, func(i int) {
+		pkg := pkgs[i]
 		if len(pkg.RawCalls) == 0 {
-			continue
+			return
+		}
+		if cfg.PackageSuffix != "" {
+			results[i] = generateSuffixed(pkg, cfg)
+			return
 		}
-		imports := buildImportLines(pkg.ImportPaths, modulePrefix)
+		imports := buildImportLines(pkg.ImportPaths)
 		uniqueCalls := deduplicateCalls(pkg.RawCalls)
-		generateWarmupCode(pkg, imports, uniqueCalls)
+		results[i] = generateWarmupCode(pkg, imports, uniqueCalls, cfg.template(warmupTemplateText), cfg.Emit == emitInit, cfg)
+	})
+	if cfg.Mode == modeCentral {
+		results = nil
+		// 工作区中每个模块各自生成一个集中预热包
+		byModule := map[string][]packageData{}
+		for _, pkg := range pkgs {
+			byModule[pkg.ModuleDir] = append(byModule[pkg.ModuleDir], pkg)
+		}
+		for moduleDir, modulePkgs := range byModule {
+			moduleFiles := generateCentral(moduleDir, modulePkgs, cfg)
+			// 集中文件生成失败时，模块内的包均未能预热
+			if slices.ContainsFunc(moduleFiles, func(f generatedFile) bool { return f.Err != nil }) {
+				for _, pkg := range modulePkgs {
+					report.markFailed(pkg.Dir)
+				}
+			}
+			results = append(results, moduleFiles)
+		}
 	}
+
+	generated := map[string]bool{}
+	for _, file := range slices.Concat(results...) {
+		// 生成失败的文件同样视为已生成，避免被当作过期文件删除
+		generated[file.Path] = true
+		if file.Err != nil {
+			stats.FilesFailed++
+			report.add(stageGenerate, filepath.Dir(file.Path), file.Err)
+			continue
+		}
+		files = append(files, file)
+	}
+	if !keepStale {
+		for _, path := range existing {
+			if !generated[path] && isGeneratedByUs(path) {
+				files = append(files, generatedFile{Path: path, Remove: true})
+			}
+		}
+	}
+	slices.SortFunc(files, func(a, b generatedFile) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	return files
 }
 
-func scanPackages(dir string) []packageData {
-	pkgMap := map[string]*packageData{}
+// writeStale 只写入内容有变化的文件，未变化的文件不触碰
+func writeStale(files []generatedFile) {
+	stale := staleFiles(files)
+	stats.FilesUnchanged += len(files) - len(stale)
+	toWrite := make([]generatedFile, 0, len(stale))
+	for _, f := range stale {
+		toWrite = append(toWrite, f.generatedFile)
+	}
+	writeFiles(toWrite)
+}
 
-	_ = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
-		if err != nil || filepath.Ext(path) != ".go" || filepath.Base(path) == "warmup.gen.go" {
-			return nil
+// writeFiles 写入或删除文件，单个文件失败时记录到 report 并继续处理其余文件
+func writeFiles(files []generatedFile) {
+	for _, f := range files {
+		if f.Remove {
+			if err := os.Remove(f.Path); err != nil && !os.IsNotExist(err) {
+				stats.FilesFailed++
+				report.add(stageWrite, filepath.Dir(f.Path), fmt.Errorf("remove stale file: %w", err))
+				continue
+			}
+			stats.FilesRemoved++
+			logger.Info("removed stale file", "path", f.Path)
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(f.Path), 0755); err != nil {
+			stats.FilesFailed++
+			report.add(stageWrite, filepath.Dir(f.Path), fmt.Errorf("create dir: %w", err))
+			continue
 		}
+		if err := fsutil.AtomicWriteFile(f.Path, f.Content, 0644); err != nil {
+			stats.FilesFailed++
+			report.add(stageWrite, filepath.Dir(f.Path), err)
+			continue
+		}
+		stats.FilesWritten++
+		logger.Info("generated file", "path", f.Path)
+	}
+}
+
+type staleFile struct {
+	generatedFile
+	Old    []byte
+	Exists bool
+}
 
-		fs := token.NewFileSet()
-		node, err := parser.ParseFile(fs, path, nil, parser.AllErrors)
-		if err != nil || node.Name.Name == "main" {
-			return nil
+// staleFiles 对比磁盘上的文件，返回内容不一致（或尚不存在）的文件
+func staleFiles(files []generatedFile) []staleFile {
+	var stale []staleFile
+	for _, f := range files {
+		old, err := os.ReadFile(f.Path)
+		if err != nil && !os.IsNotExist(err) {
+			stats.FilesFailed++
+			report.add(stageRead, filepath.Dir(f.Path), err)
+			continue
+		}
+		if f.Remove && err != nil {
+			continue
+		}
+		if !f.Remove && err == nil && bytes.Equal(old, f.Content) {
+			continue
 		}
+		stale = append(stale, staleFile{generatedFile: f, Old: old, Exists: err == nil})
+	}
+	return stale
+}
 
-		pkgDir := filepath.Dir(path)
-		pkgName := node.Name.Name
+// scanPackages 通过 go/packages 加载并类型检查 dir 下的包，同时返回已存在的生成文件；
+// 源文件内容未变化的包直接复用 cache 中的结果，文件解析与逐包扫描均以 cfg.Jobs 为并发上限
+func scanPackages(dir string, cfg *config, cache *scanCache) ([]packageData, []string) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		fatal("failed to resolve dir", "dir", dir, "err", err)
+	}
+	tree := loadSourceTree(absDir, cfg)
 
-		pkg, ok := pkgMap[pkgDir]
-		if !ok {
-			pkg = &packageData{
-				PackageName: pkgName,
-				Dir:         pkgDir,
-				ImportPaths: make(map[string]string),
+	var result []packageData
+	misses := map[string][]string{}
+	hashes := map[string]string{}
+	dirs := map[string]string{}
+	for _, root := range tree.modules {
+		// 先只列出包与文件（不解析源码），据此判断哪些包需要重新扫描
+		patterns := []string{"./..."}
+		if cfg.singlePackage {
+			patterns = []string{"."}
+			tree.testdata = nil
+		}
+		for _, td := range tree.testdata {
+			if tree.moduleOf(td) == root {
+				rel, _ := filepath.Rel(root, td)
+				patterns = append(patterns, "./"+filepath.ToSlash(rel))
 			}
-			pkgMap[pkgDir] = pkg
+		}
+		listed, err := packages.Load(&packages.Config{
+			Mode:       packages.NeedName | packages.NeedFiles,
+			Dir:        root,
+			BuildFlags: cfg.buildFlags(),
+		}, patterns...)
+		relRoot, _ := filepath.Rel(absDir, root)
+		moduleDir := filepath.Join(dir, relRoot)
+		if err != nil {
+			stats.PackagesFailed++
+			report.add(stageList, moduleDir, err)
+			continue
 		}
 
-		relImportPath, _ := filepath.Rel(dir, pkgDir)
-		pkg.ImportPaths[pkgName] = relImportPath
+		for _, p := range listed {
+			if p.Name == "main" || len(p.GoFiles) == 0 {
+				continue
+			}
+			rel, _ := filepath.Rel(absDir, filepath.Dir(p.GoFiles[0]))
+			if strings.HasPrefix(rel, "..") || tree.skipPackage(rel) {
+				continue
+			}
+			var sources []string
+			for _, file := range p.GoFiles {
+				if !cfg.isOutputFile(filepath.Base(file)) {
+					sources = append(sources, file)
+				}
+			}
+			hash, err := cache.packageHash(absDir, sources)
+			if err != nil {
+				stats.PackagesFailed++
+				report.add(stageHash, filepath.Join(dir, rel), err)
+				continue
+			}
+			calls, ok := cache.lookup(p.PkgPath, hash)
+			if !ok {
+				hashes[p.PkgPath] = hash
+				dirs[p.PkgPath] = filepath.Join(dir, rel)
+				misses[root] = append(misses[root], p.PkgPath)
+				continue
+			}
+			result = append(result, packageData{
+				PackageName: p.Name,
+				Dir:         filepath.Join(dir, rel),
+				RawCalls:    calls,
+				ImportPaths: map[string]string{p.Name: p.PkgPath},
+				ModuleDir:   moduleDir,
+			})
+		}
+	}
+	stats.PackagesCached += len(result)
+	if len(result) > 0 {
+		logger.Info("reused cached scan results", "packages", len(result))
+	}
+
+	for _, root := range tree.modules {
+		if len(misses[root]) == 0 {
+			continue
+		}
+		scanned, err := loadAndScan(dir, absDir, root, misses[root], cfg)
+		if err != nil {
+			// 整个模块加载失败时，其中待扫描的包均计为失败
+			stats.PackagesFailed += len(misses[root])
+			for _, pkgPath := range misses[root] {
+				report.add(stageLoad, dirs[pkgPath], err)
+			}
+			continue
+		}
+		relRoot, _ := filepath.Rel(absDir, root)
+		for i := range scanned {
+			scanned[i].ModuleDir = filepath.Join(dir, relRoot)
+		}
+		for _, pkg := range scanned {
+			cache.store(pkg.ImportPaths[pkg.PackageName], hashes[pkg.ImportPaths[pkg.PackageName]], pkg.RawCalls)
+		}
+		result = append(result, scanned...)
+	}
 
-		importMap := map[string]string{}
-		for _, imp := range node.Imports {
-			importPath := strings.Trim(imp.Path.Value, "\"")
-			if imp.Name != nil {
-				importMap[imp.Name.Name] = importPath
-			} else {
-				segments := strings.Split(importPath, "/")
-				importMap[segments[len(segments)-1]] = importPath
+	stats.PackagesScanned += len(result)
+	var existing []string
+	for _, pkg := range result {
+		stats.CallsFound += len(pkg.RawCalls)
+		existing = append(existing, cfg.existingOutputs(pkg.Dir)...)
+	}
+	return result, existing
+}
+
+// loadAndScan 在模块根目录 root 下加载并类型检查 pkgPaths 对应的包，扫描其中的标记调用；
+// 存在解析错误的包记录到 report 后跳过，其已有的生成文件保持不变
+func loadAndScan(dir, absDir, root string, pkgPaths []string, cfg *config) ([]packageData, error) {
+	sem := make(chan struct{}, cfg.Jobs)
+	// 依赖包同样从源码做类型检查（NeedDeps），不读取编译产物的导出数据，
+	// 避免 x/tools 版本落后于 Go 工具链时无法解析新格式的导出数据
+	pkgs, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps |
+			packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo,
+		Dir:        root,
+		BuildFlags: cfg.buildFlags(),
+		ParseFile: func(fset *token.FileSet, filename string, src []byte) (*ast.File, error) {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			return parser.ParseFile(fset, filename, src, parser.AllErrors|parser.ParseComments|parser.SkipObjectResolution)
+		},
+	}, pkgPaths...)
+	if err != nil {
+		return nil, err
+	}
+	var ok []*packages.Package
+	for _, p := range pkgs {
+		broken := slices.ContainsFunc(p.Errors, func(e packages.Error) bool {
+			return e.Kind != packages.TypeError
+		})
+		for _, e := range p.Errors {
+			switch {
+			case e.Kind != packages.TypeError:
+				report.add(stageParse, packageDir(dir, absDir, p), e)
+			case !broken:
+				// 生成文件过期时可能引用已不存在的函数，类型错误不影响其余文件的扫描
+				logger.Warn("package error", "pkg", p.PkgPath, "err", e)
 			}
 		}
+		if broken {
+			stats.PackagesFailed++
+			continue
+		}
+		if len(p.GoFiles) > 0 {
+			ok = append(ok, p)
+		}
+	}
 
+	result := make([]packageData, len(ok))
+	parallel(len(ok), cfg.Jobs, func(i int) {
+		result[i] = scanPackage(ok[i], dir, absDir, cfg)
+	})
+	return result, nil
+}
+
+// packageDir 返回包 p 相对 -dir 的目录，无法定位时退回到导入路径
+func packageDir(dir, absDir string, p *packages.Package) string {
+	files := append(slices.Clone(p.GoFiles), p.CompiledGoFiles...)
+	files = append(files, p.OtherFiles...)
+	if len(files) == 0 {
+		return p.PkgPath
+	}
+	rel, _ := filepath.Rel(absDir, filepath.Dir(files[0]))
+	return filepath.Join(dir, rel)
+}
+
+func scanPackage(p *packages.Package, dir, absDir string, cfg *config) packageData {
+	rel, _ := filepath.Rel(absDir, filepath.Dir(p.GoFiles[0]))
+	pkg := packageData{
+		PackageName: p.Name,
+		Dir:         filepath.Join(dir, rel),
+		ImportPaths: map[string]string{p.Name: p.PkgPath},
+	}
+	for _, node := range p.Syntax {
+		filename := p.Fset.Position(node.Pos()).Filename
+		relFile, _ := filepath.Rel(absDir, filename)
+		if cfg.isOutputFile(filepath.Base(filename)) || cfg.skipFile(relFile) || fileIgnored(node) {
+			continue
+		}
+		build := fileConstraint(node, filename)
 		for _, decl := range node.Decls {
 			funcDecl, ok := decl.(*ast.FuncDecl)
-			if !ok || funcDecl.Body == nil {
+			if !ok || funcDecl.Body == nil || hasDirective(funcDecl.Doc, skipDirective) {
 				continue
 			}
 
-			// Skip generic functions
-			if funcDecl.Type.TypeParams != nil {
-				continue
+			// 泛型函数（及泛型类型的方法）只在提供了实例化提示时才处理
+			exprs := []string{buildFunctionCall(p.Name, funcDecl)}
+			if isGeneric(funcDecl) {
+				exprs = buildGenericCalls(funcDecl, instantiationHints(funcDecl, p.PkgPath, cfg))
+				if len(exprs) == 0 {
+					continue
+				}
 			}
 
 			ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
 				callExpr, ok := n.(*ast.CallExpr)
-				if !ok || !containsGetCurrentFunc(callExpr.Fun, importMap) {
+				if !ok || !isMarkerCall(callExpr, p.TypesInfo, cfg) {
 					return true
 				}
 
-				funcPos := fs.Position(funcDecl.Pos())
-				callPos := fs.Position(callExpr.Lparen)
+				callPos := p.Fset.Position(callExpr.Lparen)
+				comment := fmt.Sprintf("%s:%d", filepath.ToSlash(relFile), callPos.Line)
 
-				fullCall := buildFunctionCall(pkgName, funcDecl)
-				relPath, _ := filepath.Rel(dir, funcPos.Filename)
-				comment := fmt.Sprintf("%s:%d", relPath, callPos.Line)
-
-				log.Printf("Found function: %s in file %s", fullCall, comment)
-				pkg.RawCalls = append(pkg.RawCalls, rawCall{Expr: fullCall, Comment: comment})
+				for _, fullCall := range exprs {
+					logger.Debug("found function", "func", fullCall, "pos", comment)
+					pkg.RawCalls = append(pkg.RawCalls, rawCall{Expr: fullCall, Comment: comment, Build: build, Func: funcKey(funcDecl)})
+				}
 				return true
 			})
 		}
-
-		return nil
-	})
-
-	var result []packageData
-	for _, pkg := range pkgMap {
-		result = append(result, *pkg)
 	}
-	return result
+	return pkg
 }
 
 func buildFunctionCall(currentPkg string, funcDecl *ast.FuncDecl) string {
@@ -152,118 +598,142 @@ func buildFunctionCall(currentPkg string, funcDecl *ast.FuncDecl) string {
 		return funcDecl.Name.Name
 	}
 
-	var structName string
-	var isPointer bool
+	structName, isPointer := receiverName(funcDecl)
+	if isPointer {
+		return fmt.Sprintf("(*%s).%s", structName, funcDecl.Name.Name)
+	}
+	return fmt.Sprintf("%s.%s", structName, funcDecl.Name.Name)
+}
+
+// receiverName 返回方法接收者的类型名，以及是否为指针接收者
+func receiverName(funcDecl *ast.FuncDecl) (string, bool) {
+	if funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+		return "", false
+	}
 	switch recvType := funcDecl.Recv.List[0].Type.(type) {
 	case *ast.Ident:
-		structName = recvType.Name
+		return recvType.Name, false
 	case *ast.StarExpr:
 		if ident, ok := recvType.X.(*ast.Ident); ok {
-			structName = ident.Name
-			isPointer = true
+			return ident.Name, true
 		}
 	}
-	if isPointer {
-		return fmt.Sprintf("(*%s).%s", structName, funcDecl.Name.Name)
-	}
-	return fmt.Sprintf("%s.%s", structName, funcDecl.Name.Name)
+	return "", false
 }
 
-func buildImportLines(importPaths map[string]string, modulePrefix string) []importLine {
+func buildImportLines(importPaths map[string]string) []importLine {
+	pkgNames := map[string]string{}
+	for name, path := range importPaths {
+		pkgNames[path] = name
+	}
 	var imports []importLine
-	for alias, path := range importPaths {
+	for path, alias := range assignAliases(pkgNames) {
 		imports = append(imports, importLine{
 			Alias: alias,
-			Path:  fmt.Sprintf("%s/%s", modulePrefix, path),
+			Path:  path,
 		})
 	}
 	return imports
 }
 
 func deduplicateCalls(rawCalls []rawCall) []warmupCall {
-	callMap := map[string][]string{}
+	type callKey struct {
+		expr, build string
+	}
+	callMap := map[callKey][]string{}
 	for _, c := range rawCalls {
-		callMap[c.Expr] = append(callMap[c.Expr], c.Comment)
+		key := callKey{expr: c.Expr, build: c.Build}
+		callMap[key] = append(callMap[key], c.Comment)
 	}
 	var result []warmupCall
-	for expr, comments := range callMap {
-		result = append(result, warmupCall{Expr: expr, Comments: comments})
+	for key, comments := range callMap {
+		result = append(result, warmupCall{Expr: key.expr, Comments: comments, Build: key.build})
 	}
 	return result
 }
 
-func generateWarmupCode(pkg packageData, imports []importLine, calls []warmupCall) {
+// generateWarmupCode 渲染包 pkg 的生成文件。受构建约束的调用在 splitBuild 时按约束拆分到各自的文件中；
+// 否则（生成的函数不能在多个文件中重复定义）跳过并给出警告
+func generateWarmupCode(pkg packageData, imports []importLine, calls []warmupCall, tplText string, splitBuild bool, cfg *config) []generatedFile {
 	slices.SortFunc(imports, func(a, b importLine) int {
 		return strings.Compare(a.Path, b.Path)
 	})
 	slices.SortFunc(calls, func(a, b warmupCall) int {
 		return strings.Compare(a.Expr, b.Expr)
 	})
-	if len(calls) == 0 {
-		return
+	builds, groups := splitByBuild(calls)
+	files := make([]generatedFile, 0, len(builds))
+	for _, build := range builds {
+		if build != "" && !splitBuild {
+			logger.Warn("skipping functions guarded by build constraints", "dir", pkg.Dir, "build", build, "count", len(groups[build]))
+			continue
+		}
+		files = append(files, renderWarmupFile(pkg, imports, groups[build], build, tplText, cfg))
+	}
+	return files
+}
+
+func renderWarmupFile(pkg packageData, imports []importLine, calls []warmupCall, build, tplText string, cfg *config) generatedFile {
+	outputFile := filepath.Join(pkg.Dir, cfg.buildFileName(build))
+	tpl, err := parseTemplate(tplText)
+	if err != nil {
+		return generatedFile{Path: outputFile, Err: fmt.Errorf("parse template: %w", err)}
 	}
-	tpl := template.Must(template.New("warmup").Funcs(template.FuncMap{
-		"join": strings.Join,
-	}).Parse(warmupTemplateText))
 
 	var buf bytes.Buffer
 	tplData := warmupTemplateData{
 		PackageName: pkg.PackageName,
 		Imports:     imports,
 		WarmupCalls: calls,
+		FuncMode:    cfg.Emit == emitFunc,
+		OnDemand:    cfg.OnDemand,
+		Header:      cfg.headerText,
 	}
 
 	if err := tpl.Execute(&buf, tplData); err != nil {
-		log.Fatalf("template execution failed: %v", err)
+		return generatedFile{Path: outputFile, Err: fmt.Errorf("execute template: %w", err)}
 	}
 
-	formattedCode, err := format.Source(buf.Bytes())
+	formattedCode, err := format.Source(addConstraint(buf.Bytes(), build))
 	if err != nil {
-		log.Fatalf("failed to format code: %v", err)
+		return generatedFile{Path: outputFile, Err: fmt.Errorf("format: %w", err)}
 	}
 
-	outputFile := filepath.Join(pkg.Dir, "warmup.gen.go")
-	if err := os.WriteFile(outputFile, formattedCode, 0644); err != nil {
-		log.Fatalf("failed to write file: %v", err)
-	}
-
-	if err := exec.Command("goimports", "-w", outputFile).Run(); err != nil {
-		log.Fatalf("goimports failed: %v", err)
+	importedCode, err := processImports(outputFile, formattedCode, cfg.ExecGoimports)
+	if err != nil {
+		return generatedFile{Path: outputFile, Err: fmt.Errorf("goimports: %w", err)}
 	}
 
-	log.Printf("Generated file at: %s", outputFile)
+	return generatedFile{Path: outputFile, Content: importedCode}
 }
 
-func containsGetCurrentFunc(expr ast.Expr, importMap map[string]string) bool {
-	switch e := expr.(type) {
-	case *ast.SelectorExpr:
-		if ident, ok := e.X.(*ast.Ident); ok && e.Sel.Name == "GetCurrentFunc" {
-			if path, exists := importMap[ident.Name]; exists && path == "github.com/BetaGoRobot/go_utils/reflecting" {
-				return true
-			}
-		}
-	case *ast.CallExpr:
-		if fun, ok := e.Fun.(*ast.SelectorExpr); ok {
-			if ident, ok := fun.X.(*ast.Ident); ok && fun.Sel.Name == "GetCurrentFunc" {
-				if path, exists := importMap[ident.Name]; exists && path == "github.com/BetaGoRobot/go_utils/reflecting" {
-					return true
-				}
-			}
-		}
+// processImports 整理生成代码的 import；默认在进程内使用 x/tools/imports，execGoimports 时调用外部 goimports
+func processImports(filename string, src []byte, execGoimports bool) ([]byte, error) {
+	if !execGoimports {
+		return imports.Process(filename, src, &imports.Options{Comments: true, TabIndent: true, TabWidth: 8})
 	}
-	return false
+	cmd := exec.Command("goimports", "-srcdir", filepath.Dir(filename))
+	cmd.Stdin = bytes.NewReader(src)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil && stderr.Len() > 0 {
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func getGoModModuleName(dir string) string {
-	data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
-	if err != nil {
-		log.Fatalf("failed to read go.mod: %v", err)
+// isMarkerCall 借助类型信息解析被调用的函数，别名导入与点导入均能正确识别
+func isMarkerCall(call *ast.CallExpr, info *types.Info, cfg *config) bool {
+	fn, ok := typeutil.Callee(info, call).(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return false
 	}
-	for _, line := range strings.Split(string(data), "\n") {
-		if strings.HasPrefix(line, "module ") {
-			return strings.TrimSpace(strings.TrimPrefix(line, "module "))
-		}
+	if sig, ok := fn.Type().(*types.Signature); !ok || sig.Recv() != nil {
+		return false
 	}
-	log.Fatal("module name not found in go.mod")
-	return ""
+	return cfg.isMarker(fn.Pkg().Path(), fn.Name())
 }
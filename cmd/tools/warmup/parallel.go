@@ -0,0 +1,28 @@
+package main
+
+import "sync"
+
+// parallel 以最多 jobs 个 goroutine 并发执行 fn(0..n-1)，全部完成后返回
+func parallel(n, jobs int, fn func(i int)) {
+	if jobs <= 0 {
+		jobs = 1
+	}
+	jobs = min(jobs, n)
+
+	idx := make(chan int)
+	var wg sync.WaitGroup
+	for range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range idx {
+				fn(i)
+			}
+		}()
+	}
+	for i := range n {
+		idx <- i
+	}
+	close(idx)
+	wg.Wait()
+}
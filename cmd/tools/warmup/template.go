@@ -1,6 +1,28 @@
 package main
 
-const warmupTemplateText = `// Code generated by warmup_gen.go; DO NOT EDIT.
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+const (
+	defaultOutputFile = "warmup.gen.go"
+	generatedHeader   = "// Code generated by warmup_gen.go; DO NOT EDIT."
+)
+
+const (
+	emitInit = "init"
+	emitFunc = "func"
+)
+
+const warmupTemplateText = `{{ with .Header }}{{ . }}
+
+{{ end }}// Code generated by warmup_gen.go; DO NOT EDIT.
 // Code generated by warmup_gen.go; DO NOT EDIT.
 // Code generated by warmup_gen.go; DO NOT EDIT.
 
@@ -13,9 +35,124 @@ import (
 	"github.com/BetaGoRobot/go_utils/reflecting"
 )
 
+{{- if .FuncMode }}
+// WarmupFuncNames 预热本包中调用了标记函数的函数名
+func WarmupFuncNames() {
+{{- template "calls" . }}
+}
+{{- if not .OnDemand }}
+
+func init() {
+	WarmupFuncNames()
+}
+{{- end }}
+{{- else }}
 func init() {
+{{- template "calls" . }}
+}
+{{- end }}
+`
+
+// centralTemplateText -mode=central 时生成的集中预热包，由调用方显式执行 warmup.All()
+const centralTemplateText = `{{ with .Header }}{{ . }}
+
+{{ end }}// Code generated by warmup_gen.go; DO NOT EDIT.
+
+package {{.PackageName}}
+
+import (
+{{- range .Imports }}
+	{{ .Alias }} "{{ .Path }}"
+{{- end }}
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+// All 预热所有被扫描包中调用了标记函数的函数名
+func All() {
+{{- template "calls" . }}
+}
+`
+
+const callsTemplateText = `{{ define "calls" }}
+{{- if .FuncMode }}
 {{- range .WarmupCalls }}
 	reflecting.GetFunctionName({{ .Expr }}) // from {{ join .Comments ", " }}
 {{- end }}
+{{- else if .WarmupCalls }}
+	reflecting.WarmFuncs(
+{{- range .WarmupCalls }}
+		{{ .Expr }}, // from {{ join .Comments ", " }}
+{{- end }}
+	)
+{{- end }}
+{{- end }}`
+
+// parseTemplate 解析生成文件模板，附带 "calls" 子模板与 join 函数
+func parseTemplate(text string) (*template.Template, error) {
+	return template.New("warmup").Funcs(template.FuncMap{
+		"join": strings.Join,
+	}).Parse(text + callsTemplateText)
+}
+
+// loadTemplates 读取 -template 与 -header 指定的文件，模板在此处预先解析以便尽早报错；
+// 配置文件中的相对路径相对于配置文件所在目录
+func (c *config) loadTemplates(templatePath, headerPath string) error {
+	if templatePath == "" && c.Template != "" {
+		templatePath = resolvePath(c.baseDir, c.Template)
+	}
+	if headerPath == "" && c.Header != "" {
+		headerPath = resolvePath(c.baseDir, c.Header)
+	}
+	if templatePath != "" {
+		data, err := os.ReadFile(templatePath)
+		if err != nil {
+			return fmt.Errorf("read template: %w", err)
+		}
+		if _, err := parseTemplate(string(data)); err != nil {
+			return fmt.Errorf("parse template %s: %w", templatePath, err)
+		}
+		c.templateText = string(data)
+	}
+	if headerPath != "" {
+		data, err := os.ReadFile(headerPath)
+		if err != nil {
+			return fmt.Errorf("read header: %w", err)
+		}
+		c.headerText = strings.TrimRight(string(data), "\n")
+	}
+	return nil
+}
+
+// template 返回自定义模板，未指定时返回 defaultText
+func (c *config) template(defaultText string) string {
+	if c.templateText != "" {
+		return c.templateText
+	}
+	return defaultText
+}
+
+func resolvePath(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+// isGeneratedByUs 判断 path 是否为本工具生成的文件：package 子句之前（自定义头部之后）含生成标记行
+func isGeneratedByUs(path string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == generatedHeader {
+			return true
+		}
+		if strings.HasPrefix(line, "package ") {
+			return false
+		}
+	}
+	return false
 }
-`
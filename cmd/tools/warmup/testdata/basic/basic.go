@@ -0,0 +1,35 @@
+// Package basic warmup 生成结果的测试输入
+package basic
+
+import (
+	"context"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+	"github.com/BetaGoRobot/go_utils/trace"
+)
+
+// Service 带方法的类型
+type Service struct{}
+
+// Handle 方法中的标记调用
+func (s *Service) Handle(ctx context.Context) error {
+	_, end := trace.Span(ctx)
+	defer end()
+	return nil
+}
+
+// Name 值接收者方法
+func (s Service) Name() string {
+	return reflecting.GetCurrentFunc()
+}
+
+// Load 包级函数
+func Load(ctx context.Context) (err error) {
+	_, end := trace.SpanErr(ctx, &err)
+	defer end()
+	return nil
+}
+
+func helper() string {
+	return reflecting.GetCurrentFunc()
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bufio"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// sourceTree 扫描前对目录树的一次遍历结果：需要加载的模块根目录、额外的 testdata 包目录以及 .gitignore 规则
+//
+//	vendor 目录始终跳过（其中是依赖的副本，不应生成文件）；testdata、嵌套模块与 .gitignore 忽略的目录
+//	默认跳过，可分别通过 -testdata、-nested-modules、-no-gitignore 重新纳入
+type sourceTree struct {
+	root     string
+	modules  []string        // 根目录（或 go.work 的成员模块）及（开启 -nested-modules 时）嵌套模块的绝对路径
+	members  map[string]bool // go.work 中 use 的模块，不视为嵌套模块
+	testdata []string        // 开启 -testdata 时 testdata 下含 .go 文件的目录
+	ignores  []gitignore
+	cfg      *config
+}
+
+type gitignore struct {
+	base  string // .gitignore 所在目录，相对 root
+	rules []ignoreRule
+}
+
+type ignoreRule struct {
+	pattern string
+	negate  bool
+	dirOnly bool
+}
+
+func loadSourceTree(absDir string, cfg *config) *sourceTree {
+	t := &sourceTree{root: absDir, members: map[string]bool{}, cfg: cfg}
+	if uses, ok := workspaceModules(absDir); ok {
+		for _, u := range uses {
+			t.members[u] = true
+			t.modules = append(t.modules, u)
+		}
+	} else {
+		t.modules = append(t.modules, absDir)
+	}
+	_ = filepath.WalkDir(absDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		rel, _ := filepath.Rel(absDir, path)
+		rel = filepath.ToSlash(rel)
+		if rel != "." {
+			if t.skipDir(rel) {
+				return filepath.SkipDir
+			}
+			if !t.members[path] && fileExists(filepath.Join(path, "go.mod")) {
+				t.modules = append(t.modules, path)
+			}
+			if cfg.Testdata && inTestdata(rel) && hasGoFiles(path) {
+				t.testdata = append(t.testdata, path)
+			}
+		}
+		if !cfg.NoGitignore {
+			if rules := parseGitignore(filepath.Join(path, ".gitignore")); len(rules) > 0 {
+				t.ignores = append(t.ignores, gitignore{base: rel, rules: rules})
+			}
+		}
+		return nil
+	})
+	return t
+}
+
+// skipDir 判断相对 root 的目录 rel 是否不应扫描，rel 使用 / 分隔
+func (t *sourceTree) skipDir(rel string) bool {
+	if rel == "." {
+		return false
+	}
+	name := rel[strings.LastIndexByte(rel, '/')+1:]
+	switch {
+	case name == "vendor",
+		strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_"),
+		name == "testdata" && !t.cfg.Testdata,
+		!t.cfg.NestedModules && !t.members[filepath.Join(t.root, rel)] && fileExists(filepath.Join(t.root, rel, "go.mod")),
+		t.cfg.skipDir(rel):
+		return true
+	}
+	return t.ignored(rel, true)
+}
+
+// skipPackage 判断包目录 rel 及其任一上级目录是否被跳过
+func (t *sourceTree) skipPackage(rel string) bool {
+	rel = filepath.ToSlash(rel)
+	for {
+		if t.skipDir(rel) {
+			return true
+		}
+		i := strings.LastIndexByte(rel, '/')
+		if i < 0 {
+			return false
+		}
+		rel = rel[:i]
+	}
+}
+
+// ignored 按 .gitignore 规则判断路径是否被忽略，后出现（更深层）的规则优先
+func (t *sourceTree) ignored(rel string, isDir bool) bool {
+	ignored := false
+	for _, g := range t.ignores {
+		sub := rel
+		if g.base != "." {
+			var ok bool
+			if sub, ok = strings.CutPrefix(rel, g.base+"/"); !ok {
+				continue
+			}
+		}
+		for _, r := range g.rules {
+			if r.dirOnly && !isDir {
+				continue
+			}
+			if globMatch(r.pattern, sub) {
+				ignored = !r.negate
+			}
+		}
+	}
+	return ignored
+}
+
+// parseGitignore 解析 .gitignore 中的常用语法：注释、! 取反、/ 结尾仅匹配目录、含 / 的模式相对文件所在目录锚定
+func parseGitignore(path string) []ignoreRule {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var rules []ignoreRule
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var r ignoreRule
+		if rest, ok := strings.CutPrefix(line, "!"); ok {
+			r.negate, line = true, rest
+		}
+		if rest, ok := strings.CutSuffix(line, "/"); ok {
+			r.dirOnly, line = true, rest
+		}
+		if anchored, ok := strings.CutPrefix(line, "/"); ok {
+			line = anchored
+		} else if !strings.Contains(line, "/") {
+			line = "**/" + line
+		}
+		r.pattern = line
+		rules = append(rules, r)
+	}
+	return rules
+}
+
+// workspaceModules 读取 absDir 下的 go.work，返回其中 use 的模块目录（绝对路径）
+func workspaceModules(absDir string) ([]string, bool) {
+	path := filepath.Join(absDir, "go.work")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	wf, err := modfile.ParseWork(path, data, nil)
+	if err != nil {
+		logger.Warn("failed to parse go.work", "path", path, "err", err)
+		return nil, false
+	}
+	var dirs []string
+	for _, u := range wf.Use {
+		dir := filepath.FromSlash(u.Path)
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(absDir, dir)
+		}
+		dirs = append(dirs, filepath.Clean(dir))
+	}
+	return dirs, true
+}
+
+// moduleOf 返回包含 path 的最内层模块根目录
+func (t *sourceTree) moduleOf(path string) string {
+	best := t.root
+	for _, m := range t.modules {
+		if (path == m || strings.HasPrefix(path, m+string(filepath.Separator))) && len(m) > len(best) {
+			best = m
+		}
+	}
+	return best
+}
+
+func inTestdata(rel string) bool {
+	return rel == "testdata" || strings.HasPrefix(rel, "testdata/") || strings.Contains(rel, "/testdata/") || strings.HasSuffix(rel, "/testdata")
+}
+
+func hasGoFiles(dir string) bool {
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		if !e.IsDir() && filepath.Ext(e.Name()) == ".go" {
+			return true
+		}
+	}
+	return false
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watch 监听 dir 下的源文件变化，停止变化 debounce 时长后重新生成；
+// 未变化的包由 cache 直接复用结果，只有内容变化的生成文件才会被写入
+func watch(dir string, cfg *config, cache *scanCache, keepStale bool, debounce time.Duration) error {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	tree := loadSourceTree(absDir, cfg)
+	addTree := func(root string) {
+		_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || !d.IsDir() {
+				return nil
+			}
+			rel, _ := filepath.Rel(dir, path)
+			if tree.skipDir(filepath.ToSlash(rel)) {
+				return filepath.SkipDir
+			}
+			if err := w.Add(path); err != nil {
+				logger.Warn("failed to watch dir", "path", path, "err", err)
+			}
+			return nil
+		})
+	}
+	addTree(dir)
+	logger.Info("watching for changes", "dir", dir)
+
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	changed := map[string]bool{}
+	for {
+		select {
+		case ev, ok := <-w.Events:
+			if !ok {
+				return nil
+			}
+			if ev.Has(fsnotify.Create) && isDir(ev.Name) {
+				addTree(ev.Name)
+				continue
+			}
+			if filepath.Ext(ev.Name) != ".go" || cfg.isOutputFile(filepath.Base(ev.Name)) {
+				continue
+			}
+			changed[filepath.Dir(ev.Name)] = true
+			timer.Reset(debounce)
+		case err, ok := <-w.Errors:
+			if !ok {
+				return nil
+			}
+			logger.Warn("watch error", "err", err)
+		case <-timer.C:
+			logger.Info("change detected, regenerating", "dirs", len(changed))
+			clear(changed)
+			stats = runStats{start: time.Now()}
+			report.reset()
+
+			writeStale(generate(&dir, cfg, cache, keepStale))
+			if err := cache.save(); err != nil {
+				logger.Warn("failed to save scan cache", "err", err)
+			}
+			stats.logSummary()
+			report.log(stats.packagesTotal())
+		}
+	}
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,97 @@
+package commonutils_test
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"strconv"
+	"testing"
+
+	commonutils "github.com/BetaGoRobot/go_utils/common_utils"
+	"github.com/BetaGoRobot/go_utils/testx"
+)
+
+func TestTransSliceProperties(t *testing.T) {
+	ints := testx.Int(-1000, 1000)
+
+	testx.ForAllSlices(t, ints, func(s []int) bool {
+		res := commonutils.TransSlice(s, strconv.Itoa)
+		if len(res) != len(s) {
+			return false
+		}
+		for i, v := range s {
+			if res[i] != strconv.Itoa(v) {
+				return false
+			}
+		}
+		return true
+	})
+
+	// 不返回错误时与 TransSlice 一致；出现错误时返回 nil 与该错误
+	errNegative := errors.New("negative")
+	testx.ForAllSlices(t, ints, func(s []int) bool {
+		res, err := commonutils.TransSliceWithErr(s, func(v int) (string, error) {
+			if v < 0 {
+				return "", errNegative
+			}
+			return strconv.Itoa(v), nil
+		})
+		if slices.ContainsFunc(s, func(v int) bool { return v < 0 }) {
+			return res == nil && errors.Is(err, errNegative)
+		}
+		return err == nil && slices.Equal(res, commonutils.TransSlice(s, strconv.Itoa))
+	})
+
+	// 只保留未跳过的元素且保持顺序
+	testx.ForAllSlices(t, ints, func(s []int) bool {
+		res := commonutils.TransSliceWithSkip(s, func(v int) (int, bool) { return v, v%3 == 0 })
+		want := slices.DeleteFunc(slices.Clone(s), func(v int) bool { return v%3 == 0 })
+		return slices.Equal(res, want)
+	})
+
+	testx.ForAllSlices(t, ints, func(s []int) bool {
+		seq := slices.Collect(commonutils.TransSliceSeq(slices.Values(s), strconv.Itoa))
+		return slices.Equal(seq, commonutils.TransSlice(s, strconv.Itoa))
+	})
+}
+
+func TestTransMapProperties(t *testing.T) {
+	gen := testx.MapOf(testx.String(), testx.Int(0, 9))
+
+	testx.ForAll(t, gen, func(m map[string]int) bool {
+		res := commonutils.TransMapByValue(m, strconv.Itoa)
+		if len(res) != len(m) {
+			return false
+		}
+		for k, v := range m {
+			if res[k] != strconv.Itoa(v) {
+				return false
+			}
+		}
+		return true
+	})
+
+	// 被跳过的 key 不会出现在结果中，未跳过的 key 都会出现
+	testx.ForAll(t, gen, func(m map[string]int) bool {
+		skipped := func(k string, v int) bool { return v%2 == 0 || len(k) > 3 }
+		res, err := commonutils.TransMapWithSkip(m, func(k string, v int) (string, int, bool) {
+			return k, v, skipped(k, v)
+		})
+		if err != nil {
+			return false
+		}
+		for k, v := range m {
+			if _, ok := res[k]; ok == skipped(k, v) {
+				return false
+			}
+		}
+		return len(res) <= len(m)
+	})
+
+	testx.ForAll(t, gen, func(m map[string]int) bool {
+		skip := func(k string, v int) (string, int, bool) { return k, v, v > 4 }
+		want, _ := commonutils.TransMapWithSkip(m, skip)
+		got := maps.Collect(commonutils.TransMapWithSkipSeq(maps.All(m), skip))
+		return maps.Equal(want, got)
+	})
+}
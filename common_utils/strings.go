@@ -14,3 +14,73 @@ func RemoveFromStringRune(s string, charsToRemove ...rune) string {
 	}
 	return s
 }
+
+// NaturalCompare 以自然顺序比较 a 与 b：连续的数字按数值比较，其余按字节比较，如 rc9 < rc10、file2 < file10
+//
+//	数值相同但前导零不同时，前导零少的在前
+//
+//	@param a string
+//	@param b string
+//	@return int -1、0 或 1
+//	@update 2026-10-18 04:31:06
+func NaturalCompare(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		ca, cb := a[i], b[j]
+		if !isDigit(ca) || !isDigit(cb) {
+			if ca != cb {
+				if ca < cb {
+					return -1
+				}
+				return 1
+			}
+			i++
+			j++
+			continue
+		}
+		// 两边都是数字：取出完整的数字串，去掉前导零后先比长度再逐位比较
+		si, sj := i, j
+		for i < len(a) && isDigit(a[i]) {
+			i++
+		}
+		for j < len(b) && isDigit(b[j]) {
+			j++
+		}
+		na := strings.TrimLeft(a[si:i], "0")
+		nb := strings.TrimLeft(b[sj:j], "0")
+		if len(na) != len(nb) {
+			if len(na) < len(nb) {
+				return -1
+			}
+			return 1
+		}
+		if c := strings.Compare(na, nb); c != 0 {
+			return c
+		}
+		if za, zb := i-si, j-sj; za != zb {
+			if za < zb {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case len(a)-i < len(b)-j:
+		return -1
+	case len(a)-i > len(b)-j:
+		return 1
+	}
+	return 0
+}
+
+// NaturalLess 以自然顺序判断 a 是否排在 b 之前，可直接用于 sort.Slice
+//
+//	@param a string
+//	@param b string
+//	@return bool
+//	@update 2026-10-18 04:31:06
+func NaturalLess(a, b string) bool {
+	return NaturalCompare(a, b) < 0
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
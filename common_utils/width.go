@@ -0,0 +1,105 @@
+package commonutils
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// wideRanges 终端中占两列的字符区间（东亚宽字符、全角字符与常见 emoji），按起点升序
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F}, {0x231A, 0x231B}, {0x2329, 0x232A}, {0x23E9, 0x23EC}, {0x23F0, 0x23F0},
+	{0x23F3, 0x23F3}, {0x25FD, 0x25FE}, {0x2614, 0x2615}, {0x2648, 0x2653}, {0x267F, 0x267F},
+	{0x2693, 0x2693}, {0x26A1, 0x26A1}, {0x26AA, 0x26AB}, {0x26BD, 0x26BE}, {0x26C4, 0x26C5},
+	{0x26CE, 0x26CE}, {0x26D4, 0x26D4}, {0x26EA, 0x26EA}, {0x26F2, 0x26F3}, {0x26F5, 0x26F5},
+	{0x26FA, 0x26FA}, {0x26FD, 0x26FD}, {0x2705, 0x2705}, {0x270A, 0x270B}, {0x2728, 0x2728},
+	{0x274C, 0x274C}, {0x274E, 0x274E}, {0x2753, 0x2755}, {0x2757, 0x2757}, {0x2795, 0x2797},
+	{0x27B0, 0x27B0}, {0x27BF, 0x27BF}, {0x2B1B, 0x2B1C}, {0x2B50, 0x2B50}, {0x2B55, 0x2B55},
+	{0x2E80, 0x303E}, {0x3041, 0x33FF}, {0x3400, 0x4DBF}, {0x4E00, 0x9FFF}, {0xA000, 0xA4CF},
+	{0xA960, 0xA97F}, {0xAC00, 0xD7A3}, {0xF900, 0xFAFF}, {0xFE10, 0xFE19}, {0xFE30, 0xFE6F},
+	{0xFF00, 0xFF60}, {0xFFE0, 0xFFE6}, {0x16FE0, 0x16FE4}, {0x17000, 0x18AFF}, {0x1B000, 0x1B2FF},
+	{0x1F004, 0x1F004}, {0x1F0CF, 0x1F0CF}, {0x1F18E, 0x1F18E}, {0x1F191, 0x1F19A}, {0x1F200, 0x1F251},
+	{0x1F300, 0x1F64F}, {0x1F680, 0x1F6FF}, {0x1F7E0, 0x1F7EB}, {0x1F90C, 0x1F9FF}, {0x1FA70, 0x1FAFF},
+	{0x20000, 0x2FFFD}, {0x30000, 0x3FFFD},
+}
+
+// RuneWidth 返回 r 在等宽终端中占的列数：控制字符与组合字符为 0，东亚宽字符、全角字符与常见 emoji 为 2，其余为 1
+//
+//	@param r rune
+//	@return int
+//	@update 2026-10-18 08:35:27
+func RuneWidth(r rune) int {
+	switch {
+	case r < 0x20 || r >= 0x7F && r < 0xA0:
+		return 0
+	case r < 0x1100:
+		if unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+			return 0
+		}
+		return 1
+	case r == 0x200B || unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	}
+	lo, hi := 0, len(wideRanges)
+	for lo < hi {
+		mid := (lo + hi) / 2
+		switch rg := wideRanges[mid]; {
+		case r < rg[0]:
+			hi = mid
+		case r > rg[1]:
+			lo = mid + 1
+		default:
+			return 2
+		}
+	}
+	return 1
+}
+
+// DisplayWidth 返回 s 在等宽终端中占的列数，规则见 RuneWidth
+//
+//	@param s string
+//	@return int
+//	@update 2026-10-18 08:35:27
+//
+// for example:
+//
+//	DisplayWidth("abc")  // 3
+//	DisplayWidth("中文") // 4
+func DisplayWidth(s string) int {
+	w := 0
+	for _, r := range s {
+		w += RuneWidth(r)
+	}
+	return w
+}
+
+// TruncateWidth 将 s 截断到不超过 width 列，发生截断时以 tail（如 "…"）结尾且总宽度仍不超过 width
+//
+//	@param s string
+//	@param width int
+//	@param tail string
+//	@return string
+//	@update 2026-10-18 08:35:27
+func TruncateWidth(s string, width int, tail string) string {
+	if DisplayWidth(s) <= width {
+		return s
+	}
+	limit := width - DisplayWidth(tail)
+	if limit < 0 {
+		return ""
+	}
+	var sb strings.Builder
+	w := 0
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		rw := RuneWidth(r)
+		if w+rw > limit {
+			break
+		}
+		sb.WriteString(s[i : i+size])
+		w += rw
+		i += size
+	}
+	sb.WriteString(tail)
+	return sb.String()
+}
@@ -0,0 +1,140 @@
+// Package compress 压缩算法的统一接口与 gzip 实现，提供字节级的压缩/解压与复用内部状态的流式读写
+package compress
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/BetaGoRobot/go_utils/ioutilx"
+)
+
+// Codec 压缩算法，NewWriter / NewReader 返回的对象在 Close 后不可再使用，实现可借此复用内部状态
+//
+//	接入 zstd 等算法时实现该接口并调用 Register 即可，例如基于 github.com/klauspost/compress/zstd：
+//
+//	type zstdCodec struct{}
+//
+//	func (zstdCodec) Name() string { return "zstd" }
+//	func (zstdCodec) NewWriter(w io.Writer) (io.WriteCloser, error) { return zstd.NewWriter(w) }
+//	func (zstdCodec) NewReader(r io.Reader) (io.ReadCloser, error) {
+//		d, err := zstd.NewReader(r)
+//		if err != nil {
+//			return nil, err
+//		}
+//		return d.IOReadCloser(), nil
+//	}
+type Codec interface {
+	Name() string
+	NewWriter(w io.Writer) (io.WriteCloser, error)
+	NewReader(r io.Reader) (io.ReadCloser, error)
+}
+
+// Magician 可选接口，返回压缩数据的魔数，供 Detect 识别格式
+type Magician interface {
+	Magic() []byte
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Codec{}
+	order      []string
+)
+
+// Register 注册 Codec，同名的会被替换；Gzip 已默认注册
+//
+//	@param c Codec
+//	@update 2026-10-18 03:49:02
+func Register(c Codec) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := registry[c.Name()]; !ok {
+		order = append(order, c.Name())
+	}
+	registry[c.Name()] = c
+}
+
+// Lookup 按名称查找已注册的 Codec，如 "gzip"，可用于按 Content-Encoding 选择算法
+//
+//	@param name string
+//	@return Codec
+//	@return bool
+//	@update 2026-10-18 03:49:02
+func Lookup(name string) (Codec, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	c, ok := registry[name]
+	return c, ok
+}
+
+// Detect 按魔数识别 data 使用的已注册压缩算法，只识别实现了 Magician 的 Codec
+//
+//	@param data []byte
+//	@return Codec
+//	@return bool
+//	@update 2026-10-18 03:49:02
+func Detect(data []byte) (Codec, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for _, name := range order {
+		if m, ok := registry[name].(Magician); ok && bytes.HasPrefix(data, m.Magic()) {
+			return registry[name], true
+		}
+	}
+	return nil, false
+}
+
+// Compress 使用 c 压缩 data
+//
+//	@param c Codec
+//	@param data []byte
+//	@return []byte
+//	@return error
+//	@update 2026-10-18 03:49:02
+func Compress(c Codec, data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := c.NewWriter(&buf)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decompress 使用 c 解压 data
+//
+//	@param c Codec
+//	@param data []byte
+//	@return []byte
+//	@return error
+//	@update 2026-10-18 03:49:02
+func Decompress(c Codec, data []byte) ([]byte, error) {
+	return DecompressLimit(c, data, -1)
+}
+
+// DecompressLimit 同 Decompress，解压后超过 max 字节时返回 ioutilx.ErrTooLarge，用于防御压缩炸弹；max<0 表示不限制
+//
+//	@param c Codec
+//	@param data []byte
+//	@param max int64
+//	@return []byte
+//	@return error
+//	@update 2026-10-18 03:49:02
+func DecompressLimit(c Codec, data []byte, max int64) (res []byte, err error) {
+	r, err := c.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("compress: %s: %w", c.Name(), err)
+	}
+	defer ioutilx.CloseQuietly(r, &err)
+	if max < 0 {
+		return io.ReadAll(r)
+	}
+	return ioutilx.LimitedReadAll(r, max)
+}
@@ -0,0 +1,166 @@
+package compress
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"sync"
+)
+
+var errClosed = errors.New("compress: use of closed writer or reader")
+
+// GzipCodec 复用 gzip.Writer / gzip.Reader 的 gzip 实现
+type GzipCodec struct {
+	level   int
+	writers sync.Pool
+	readers sync.Pool
+}
+
+// Gzip 默认压缩级别的 gzip Codec，已注册为 "gzip"
+var Gzip = NewGzip(gzip.DefaultCompression)
+
+func init() {
+	Register(Gzip)
+}
+
+// NewGzip 创建指定压缩级别的 gzip Codec，level 取值同 compress/gzip，非法时使用默认级别
+//
+//	@param level int
+//	@return *GzipCodec
+//	@update 2026-10-18 03:49:02
+func NewGzip(level int) *GzipCodec {
+	if level < gzip.HuffmanOnly || level > gzip.BestCompression {
+		level = gzip.DefaultCompression
+	}
+	return &GzipCodec{level: level}
+}
+
+// Name 实现 Codec
+//
+//	@receiver g *GzipCodec
+//	@return string
+//	@update 2026-10-18 03:49:02
+func (g *GzipCodec) Name() string { return "gzip" }
+
+// Magic 实现 Magician
+//
+//	@receiver g *GzipCodec
+//	@return []byte
+//	@update 2026-10-18 03:49:02
+func (g *GzipCodec) Magic() []byte { return []byte{0x1f, 0x8b} }
+
+// NewWriter 实现 Codec，返回的 Writer 在 Close 后放回池中复用
+//
+//	@receiver g *GzipCodec
+//	@param w io.Writer
+//	@return io.WriteCloser
+//	@return error
+//	@update 2026-10-18 03:49:02
+func (g *GzipCodec) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	if zw, ok := g.writers.Get().(*gzip.Writer); ok {
+		zw.Reset(w)
+		return &gzipWriter{zw: zw, pool: &g.writers}, nil
+	}
+	zw, err := gzip.NewWriterLevel(w, g.level)
+	if err != nil {
+		return nil, err
+	}
+	return &gzipWriter{zw: zw, pool: &g.writers}, nil
+}
+
+// NewReader 实现 Codec，返回的 Reader 在 Close 后放回池中复用
+//
+//	@receiver g *GzipCodec
+//	@param r io.Reader
+//	@return io.ReadCloser
+//	@return error
+//	@update 2026-10-18 03:49:02
+func (g *GzipCodec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	if zr, ok := g.readers.Get().(*gzip.Reader); ok {
+		if err := zr.Reset(r); err != nil {
+			g.readers.Put(zr)
+			return nil, err
+		}
+		return &gzipReader{zr: zr, pool: &g.readers}, nil
+	}
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return &gzipReader{zr: zr, pool: &g.readers}, nil
+}
+
+// gzipWriter Close 时将 gzip.Writer 放回池中
+type gzipWriter struct {
+	zw   *gzip.Writer
+	pool *sync.Pool
+}
+
+func (w *gzipWriter) Write(p []byte) (int, error) {
+	if w.zw == nil {
+		return 0, errClosed
+	}
+	return w.zw.Write(p)
+}
+
+// Flush 将已写入的数据刷新到底层 Writer，适用于流式传输
+func (w *gzipWriter) Flush() error {
+	if w.zw == nil {
+		return errClosed
+	}
+	return w.zw.Flush()
+}
+
+func (w *gzipWriter) Close() error {
+	if w.zw == nil {
+		return errClosed
+	}
+	err := w.zw.Close()
+	w.zw.Reset(io.Discard)
+	w.pool.Put(w.zw)
+	w.zw = nil
+	return err
+}
+
+// gzipReader Close 时将 gzip.Reader 放回池中
+type gzipReader struct {
+	zr   *gzip.Reader
+	pool *sync.Pool
+}
+
+func (r *gzipReader) Read(p []byte) (int, error) {
+	if r.zr == nil {
+		return 0, errClosed
+	}
+	return r.zr.Read(p)
+}
+
+func (r *gzipReader) Close() error {
+	if r.zr == nil {
+		return errClosed
+	}
+	err := r.zr.Close()
+	r.pool.Put(r.zr)
+	r.zr = nil
+	return err
+}
+
+// GzipBytes 以默认级别 gzip 压缩 data
+//
+//	@param data []byte
+//	@return []byte
+//	@return error
+//	@update 2026-10-18 03:49:02
+func GzipBytes(data []byte) ([]byte, error) {
+	return Compress(Gzip, data)
+}
+
+// GunzipBytes 解压 gzip 数据
+//
+//	@param data []byte
+//	@return []byte
+//	@return error
+//	@update 2026-10-18 03:49:02
+func GunzipBytes(data []byte) ([]byte, error) {
+	return Decompress(Gzip, data)
+}
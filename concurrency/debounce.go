@@ -0,0 +1,306 @@
+package concurrency
+
+import (
+	"sync"
+	"time"
+)
+
+// Debouncer 防抖：连续调用 Call 时，只在最后一次调用之后静默 d 时长才执行一次 fn
+type Debouncer struct {
+	mu      sync.Mutex
+	run     sync.Mutex // 保证 fn 不会并发执行
+	d       time.Duration
+	fn      func()
+	timer   *time.Timer
+	pending bool
+	stopped bool
+}
+
+// Debounce 返回 fn 的防抖包装，适合合并配置重载、缓存失效等突发事件
+//
+//	@param d time.Duration
+//	@param fn func()
+//	@return *Debouncer
+//	@update 2026-10-17 19:31:44
+//
+// for example:
+//
+//	reload := concurrency.Debounce(500*time.Millisecond, loadConfig)
+//	defer reload.Stop()
+//	for range watcher.Events {
+//		reload.Call()
+//	}
+func Debounce(d time.Duration, fn func()) *Debouncer {
+	return &Debouncer{d: d, fn: fn}
+}
+
+// Call 记录一次调用并重新开始计时
+//
+//	@receiver b *Debouncer
+//	@update 2026-10-17 19:31:44
+func (b *Debouncer) Call() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.stopped {
+		return
+	}
+	b.pending = true
+	if b.timer == nil {
+		b.timer = time.AfterFunc(b.d, b.fire)
+	} else {
+		b.timer.Reset(b.d)
+	}
+}
+
+// Flush 有待执行的调用时立即在当前 goroutine 执行 fn，不再等待静默期
+//
+//	@receiver b *Debouncer
+//	@update 2026-10-17 19:31:44
+func (b *Debouncer) Flush() {
+	b.mu.Lock()
+	if !b.pending {
+		b.mu.Unlock()
+		return
+	}
+	b.pending = false
+	b.timer.Stop()
+	b.mu.Unlock()
+	b.exec()
+}
+
+// Stop 丢弃待执行的调用，之后的 Call 不再生效
+//
+//	@receiver b *Debouncer
+//	@update 2026-10-17 19:31:44
+func (b *Debouncer) Stop() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.stopped, b.pending = true, false
+	if b.timer != nil {
+		b.timer.Stop()
+	}
+}
+
+func (b *Debouncer) fire() {
+	b.mu.Lock()
+	if !b.pending || b.stopped {
+		b.mu.Unlock()
+		return
+	}
+	b.pending = false
+	b.mu.Unlock()
+	b.exec()
+}
+
+func (b *Debouncer) exec() {
+	b.run.Lock()
+	defer b.run.Unlock()
+	b.fn()
+}
+
+// Throttler 节流：每个 d 时长的窗口内 fn 至多执行一次；窗口内的首次调用立即执行，
+// 其余调用合并为窗口结束时的一次执行
+type Throttler struct {
+	mu      sync.Mutex
+	run     sync.Mutex
+	d       time.Duration
+	fn      func()
+	timer   *time.Timer // 非 nil 表示处于窗口中
+	pending bool
+	stopped bool
+}
+
+// Throttle 返回 fn 的节流包装
+//
+//	@param d time.Duration
+//	@param fn func()
+//	@return *Throttler
+//	@update 2026-10-17 19:31:44
+func Throttle(d time.Duration, fn func()) *Throttler {
+	return &Throttler{d: d, fn: fn}
+}
+
+// Call 不在窗口中时立即在当前 goroutine 执行 fn 并开启窗口，否则记为窗口结束时执行
+//
+//	@receiver t *Throttler
+//	@update 2026-10-17 19:31:44
+func (t *Throttler) Call() {
+	t.mu.Lock()
+	if t.stopped {
+		t.mu.Unlock()
+		return
+	}
+	if t.timer != nil {
+		t.pending = true
+		t.mu.Unlock()
+		return
+	}
+	t.timer = time.AfterFunc(t.d, t.windowEnd)
+	t.mu.Unlock()
+	t.exec()
+}
+
+// Flush 有待执行的调用时立即在当前 goroutine 执行 fn
+//
+//	@receiver t *Throttler
+//	@update 2026-10-17 19:31:44
+func (t *Throttler) Flush() {
+	t.mu.Lock()
+	if !t.pending {
+		t.mu.Unlock()
+		return
+	}
+	t.pending = false
+	t.mu.Unlock()
+	t.exec()
+}
+
+// Stop 丢弃待执行的调用，之后的 Call 不再生效
+//
+//	@receiver t *Throttler
+//	@update 2026-10-17 19:31:44
+func (t *Throttler) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stopped, t.pending = true, false
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
+	}
+}
+
+// windowEnd 窗口结束：有合并的调用时执行并开启新窗口，否则退出窗口
+func (t *Throttler) windowEnd() {
+	t.mu.Lock()
+	if !t.pending || t.stopped {
+		t.timer = nil
+		t.mu.Unlock()
+		return
+	}
+	t.pending = false
+	t.timer = time.AfterFunc(t.d, t.windowEnd)
+	t.mu.Unlock()
+	t.exec()
+}
+
+func (t *Throttler) exec() {
+	t.run.Lock()
+	defer t.run.Unlock()
+	t.fn()
+}
+
+// KeyedDebouncer 按 key 分别防抖，每个 key 独立计时；执行后 key 的状态即被清理
+type KeyedDebouncer[K comparable] struct {
+	mu      sync.Mutex
+	d       time.Duration
+	fn      func(K)
+	timers  map[K]*keyedTimer
+	stopped bool
+}
+
+// keyedTimer 单个 key 的计时器；fire 通过指针比较判断是否已被 Flush 或重新创建
+type keyedTimer struct {
+	timer *time.Timer
+}
+
+// DebounceByKey 返回按 key 防抖的 fn 包装，如按缓存 key 合并失效通知
+//
+//	@param d time.Duration
+//	@param fn func(K)
+//	@return *KeyedDebouncer[K]
+//	@update 2026-10-17 19:31:44
+func DebounceByKey[K comparable](d time.Duration, fn func(K)) *KeyedDebouncer[K] {
+	return &KeyedDebouncer[K]{d: d, fn: fn, timers: map[K]*keyedTimer{}}
+}
+
+// Call 记录 key 的一次调用并重新开始该 key 的计时
+//
+//	@receiver k *KeyedDebouncer[K]
+//	@param key K
+//	@update 2026-10-17 19:31:44
+func (k *KeyedDebouncer[K]) Call(key K) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.stopped {
+		return
+	}
+	if t, ok := k.timers[key]; ok {
+		t.timer.Reset(k.d)
+		return
+	}
+	t := &keyedTimer{}
+	t.timer = time.AfterFunc(k.d, func() { k.fire(key, t) })
+	k.timers[key] = t
+}
+
+// Flush 立即在当前 goroutine 执行 key 待执行的调用
+//
+//	@receiver k *KeyedDebouncer[K]
+//	@param key K
+//	@update 2026-10-17 19:31:44
+func (k *KeyedDebouncer[K]) Flush(key K) {
+	k.mu.Lock()
+	t, ok := k.timers[key]
+	if ok {
+		t.timer.Stop()
+		delete(k.timers, key)
+	}
+	k.mu.Unlock()
+	if ok {
+		k.fn(key)
+	}
+}
+
+// FlushAll 立即在当前 goroutine 依次执行所有 key 待执行的调用
+//
+//	@receiver k *KeyedDebouncer[K]
+//	@update 2026-10-17 19:31:44
+func (k *KeyedDebouncer[K]) FlushAll() {
+	k.mu.Lock()
+	keys := make([]K, 0, len(k.timers))
+	for key, t := range k.timers {
+		t.timer.Stop()
+		keys = append(keys, key)
+	}
+	clear(k.timers)
+	k.mu.Unlock()
+	for _, key := range keys {
+		k.fn(key)
+	}
+}
+
+// Stop 丢弃所有待执行的调用，之后的 Call 不再生效
+//
+//	@receiver k *KeyedDebouncer[K]
+//	@update 2026-10-17 19:31:44
+func (k *KeyedDebouncer[K]) Stop() {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.stopped = true
+	for _, t := range k.timers {
+		t.timer.Stop()
+	}
+	clear(k.timers)
+}
+
+// Len 返回有待执行调用的 key 数量
+//
+//	@receiver k *KeyedDebouncer[K]
+//	@return int
+//	@update 2026-10-17 19:31:44
+func (k *KeyedDebouncer[K]) Len() int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return len(k.timers)
+}
+
+func (k *KeyedDebouncer[K]) fire(key K, t *keyedTimer) {
+	k.mu.Lock()
+	if k.timers[key] != t {
+		k.mu.Unlock()
+		return
+	}
+	delete(k.timers, key)
+	k.mu.Unlock()
+	k.fn(key)
+}
@@ -0,0 +1,193 @@
+// Package concurrency 并发控制相关的工具
+package concurrency
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+// Group 与 errgroup.Group 用法一致的 goroutine 组：goroutine 中的 panic 会被恢复为 *reflecting.PanicError，
+// 作为普通错误参与“首个错误”的收集，而不会使进程崩溃。零值可用
+type Group struct {
+	cancel context.CancelCauseFunc
+	wg     sync.WaitGroup
+	sem    chan struct{}
+
+	errOnce sync.Once
+	err     error
+}
+
+// WithContext 返回新的 Group 以及派生的 ctx，ctx 在首个 goroutine 出错（含 panic）或 Wait 返回时取消
+//
+//	@param ctx context.Context
+//	@return *Group
+//	@return context.Context
+//	@update 2026-10-17 18:52:36
+func WithContext(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	return &Group{cancel: cancel}, ctx
+}
+
+// Go 在新的 goroutine 中运行 f；设置了 SetLimit 且活跃 goroutine 已达上限时阻塞
+//
+//	@receiver g *Group
+//	@param f func() error
+//	@update 2026-10-17 18:52:36
+func (g *Group) Go(f func() error) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+	g.start(f)
+}
+
+// TryGo 活跃 goroutine 未达上限时运行 f 并返回 true，否则不运行并返回 false
+//
+//	@receiver g *Group
+//	@param f func() error
+//	@return bool
+//	@update 2026-10-17 18:52:36
+func (g *Group) TryGo(f func() error) bool {
+	if g.sem != nil {
+		select {
+		case g.sem <- struct{}{}:
+		default:
+			return false
+		}
+	}
+	g.start(f)
+	return true
+}
+
+// SetLimit 限制同时活跃的 goroutine 数，n < 0 表示不限制；有活跃 goroutine 时修改上限会 panic
+//
+//	@receiver g *Group
+//	@param n int
+//	@update 2026-10-17 18:52:36
+func (g *Group) SetLimit(n int) {
+	if n < 0 {
+		g.sem = nil
+		return
+	}
+	if len(g.sem) != 0 {
+		panic(fmt.Errorf("concurrency: modify limit while %v goroutines in the group are still active", len(g.sem)))
+	}
+	g.sem = make(chan struct{}, n)
+}
+
+// Wait 等待所有 goroutine 结束，返回首个非 nil 的错误（panic 时为 *reflecting.PanicError）
+//
+//	@receiver g *Group
+//	@return error
+//	@update 2026-10-17 18:52:36
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel(g.err)
+	}
+	return g.err
+}
+
+func (g *Group) start(f func() error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.done()
+		if err := run(f); err != nil {
+			g.errOnce.Do(func() {
+				g.err = err
+				if g.cancel != nil {
+					g.cancel(err)
+				}
+			})
+		}
+	}()
+}
+
+func (g *Group) done() {
+	if g.sem != nil {
+		<-g.sem
+	}
+	g.wg.Done()
+}
+
+func run(f func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = reflecting.NewPanicError(r)
+		}
+	}()
+	return f()
+}
+
+// Result GoValue 的结果，须在 Group.Wait 返回后读取
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// Value 返回 fn 的结果，fn 出错时为零值
+//
+//	@receiver r *Result[T]
+//	@return T
+//	@update 2026-10-17 18:52:36
+func (r *Result[T]) Value() T {
+	return r.value
+}
+
+// Err 返回 fn 自身的错误（含 panic），与 Group.Wait 返回的首个错误不一定相同
+//
+//	@receiver r *Result[T]
+//	@return error
+//	@update 2026-10-17 18:52:36
+func (r *Result[T]) Err() error {
+	return r.err
+}
+
+// GoValue 在 g 中运行返回值的 fn，结果在 g.Wait() 之后通过返回的 *Result[T] 读取
+//
+//	@param g *Group
+//	@param fn func() (T, error)
+//	@return *Result[T]
+//	@update 2026-10-17 18:52:36
+//
+// for example:
+//
+//	g, ctx := concurrency.WithContext(ctx)
+//	user := concurrency.GoValue(g, func() (*User, error) { return loadUser(ctx, id) })
+//	orders := concurrency.GoValue(g, func() ([]Order, error) { return loadOrders(ctx, id) })
+//	if err := g.Wait(); err != nil {
+//		return err
+//	}
+//	render(user.Value(), orders.Value())
+func GoValue[T any](g *Group, fn func() (T, error)) *Result[T] {
+	r := &Result[T]{}
+	g.Go(func() error {
+		r.err = run(func() (err error) {
+			r.value, err = fn()
+			return err
+		})
+		return r.err
+	})
+	return r
+}
+
+// GoMap 在 g 中为 items 的每个元素运行 fn，结果按 items 的顺序写入返回的切片，须在 g.Wait() 之后读取
+//
+//	@param g *Group
+//	@param items []In
+//	@param fn func(In) (Out, error)
+//	@return []Out
+//	@update 2026-10-17 18:52:36
+func GoMap[In, Out any](g *Group, items []In, fn func(In) (Out, error)) []Out {
+	res := make([]Out, len(items))
+	for i, item := range items {
+		g.Go(func() error {
+			v, err := fn(item)
+			res[i] = v
+			return err
+		})
+	}
+	return res
+}
@@ -0,0 +1,128 @@
+package concurrency
+
+import (
+	"context"
+	"sync"
+)
+
+// KeyedMutex 按 key 加锁的互斥锁，不同 key 之间互不阻塞；
+// 没有持有者和等待者的 key 会立即从内部表中移除，key 的数量不会无限增长
+//
+// 零值可直接使用
+type KeyedMutex[K comparable] struct {
+	mu    sync.Mutex
+	locks map[K]*keyedLock
+}
+
+// keyedLock 单个 key 的锁；ch 中有值表示锁空闲，refs 为持有者与等待者的数量
+type keyedLock struct {
+	ch   chan struct{}
+	refs int
+}
+
+// Lock 获取 key 的锁，已被持有时阻塞
+//
+//	@receiver m *KeyedMutex[K]
+//	@param key K
+//	@update 2026-10-17 19:49:20
+//
+// for example:
+//
+//	var userLocks concurrency.KeyedMutex[int64]
+//	userLocks.Lock(userID)
+//	defer userLocks.Unlock(userID)
+func (m *KeyedMutex[K]) Lock(key K) {
+	<-m.acquire(key).ch
+}
+
+// LockContext 获取 key 的锁，ctx 结束时放弃等待
+//
+//	@receiver m *KeyedMutex[K]
+//	@param ctx context.Context
+//	@param key K
+//	@return error ctx 结束时返回 ctx.Err()，此时未持有锁
+//	@update 2026-10-17 19:49:20
+func (m *KeyedMutex[K]) LockContext(ctx context.Context, key K) error {
+	l := m.acquire(key)
+	select {
+	case <-l.ch:
+		return nil
+	case <-ctx.Done():
+		m.release(key, l)
+		return ctx.Err()
+	}
+}
+
+// TryLock 不阻塞地尝试获取 key 的锁
+//
+//	@receiver m *KeyedMutex[K]
+//	@param key K
+//	@return bool
+//	@update 2026-10-17 19:49:20
+func (m *KeyedMutex[K]) TryLock(key K) bool {
+	l := m.acquire(key)
+	select {
+	case <-l.ch:
+		return true
+	default:
+		m.release(key, l)
+		return false
+	}
+}
+
+// Unlock 释放 key 的锁；key 未被加锁时 panic
+//
+//	@receiver m *KeyedMutex[K]
+//	@param key K
+//	@update 2026-10-17 19:49:20
+func (m *KeyedMutex[K]) Unlock(key K) {
+	m.mu.Lock()
+	l, ok := m.locks[key]
+	m.mu.Unlock()
+	if !ok {
+		panic("concurrency: unlock of unlocked key")
+	}
+	select {
+	case l.ch <- struct{}{}:
+	default:
+		panic("concurrency: unlock of unlocked key")
+	}
+	m.release(key, l)
+}
+
+// Len 返回当前被持有或等待中的 key 数量
+//
+//	@receiver m *KeyedMutex[K]
+//	@return int
+//	@update 2026-10-17 19:49:20
+func (m *KeyedMutex[K]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.locks)
+}
+
+// acquire 取得 key 对应的锁并增加引用计数
+func (m *KeyedMutex[K]) acquire(key K) *keyedLock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.locks == nil {
+		m.locks = map[K]*keyedLock{}
+	}
+	l, ok := m.locks[key]
+	if !ok {
+		l = &keyedLock{ch: make(chan struct{}, 1)}
+		l.ch <- struct{}{}
+		m.locks[key] = l
+	}
+	l.refs++
+	return l
+}
+
+// release 减少引用计数，无人使用时移除 key
+func (m *KeyedMutex[K]) release(key K, l *keyedLock) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if l.refs--; l.refs == 0 {
+		delete(m.locks, key)
+	}
+}
@@ -0,0 +1,276 @@
+// Package config 将环境变量等配置来源加载到结构体
+package config
+
+import (
+	"encoding"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+type options struct {
+	prefix   string
+	lookup   func(key string) (string, bool)
+	files    []configFile
+	flags    *flag.FlagSet
+	debounce time.Duration
+}
+
+// Option LoadEnv、Load、Watch 与 Explain 的选项
+type Option func(*options)
+
+// WithPrefix 所有环境变量名加上前缀，如 WithPrefix("APP_") 时字段 Port 对应 APP_PORT
+//
+//	@param prefix string
+//	@return Option
+//	@update 2026-10-18 00:57:31
+func WithPrefix(prefix string) Option {
+	return func(o *options) { o.prefix = prefix }
+}
+
+// WithLookup 替换读取环境变量的函数，默认 os.LookupEnv
+//
+//	@param fn func(key string) (string, bool)
+//	@return Option
+//	@update 2026-10-18 00:57:31
+func WithLookup(fn func(key string) (string, bool)) Option {
+	return func(o *options) { o.lookup = fn }
+}
+
+func newOptions(opts []Option) options {
+	o := options{lookup: os.LookupEnv, debounce: 200 * time.Millisecond}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// envField 一个对应环境变量的叶子字段
+type envField struct {
+	value    reflect.Value
+	env      string // 完整的环境变量名（含前缀）
+	name     string // 不含前缀的变量名，用于推导命令行参数名
+	fresh    bool   // 字段位于为 nil 指针新建的结构体中，尚未应用过默认值
+	def      string
+	hasDef   bool
+	required bool
+	secret   bool
+}
+
+// LoadEnv 将环境变量加载到 ptr 指向的结构体
+//
+//	字段规则：
+//	- `env:"NAME"` 指定变量名，未指定时由字段名转为大写下划线形式（MaxIdleConns → MAX_IDLE_CONNS）；`env:"-"` 忽略该字段
+//	- `env:"NAME,required"` 变量未设置且无默认值时报错；`env:"NAME,secret"` 在 Explain 中打码
+//	- `default:"..."` 变量未设置时使用的默认值；既未设置也无默认值时保留字段原值
+//	- 嵌套结构体（及结构体指针）的变量名以 <字段变量名>_ 为前缀，如 DB_HOST
+//	- 类型转换同 reflecting.ConvertTo，支持 time.Duration 与 encoding.TextUnmarshaler；
+//	  切片以逗号分隔（a,b,c），map 以逗号分隔键值对、冒号分隔键与值（k1:v1,k2:v2）
+//
+//	@param ptr any 结构体指针
+//	@param opts ...Option
+//	@return error 缺失必填变量或转换失败时返回，包含全部问题
+//	@update 2026-10-18 00:57:31
+//
+// for example:
+//
+//	type Config struct {
+//		Port     int           `env:"PORT" default:"8080"`
+//		Timeout  time.Duration `default:"5s"`
+//		Hosts    []string      `env:"HOSTS"`
+//		DB       struct {
+//			URL      string `env:"URL,required"`
+//			Password string `env:"PASSWORD,secret"`
+//		}
+//	}
+//	var cfg Config
+//	err := config.LoadEnv(&cfg, config.WithPrefix("APP_")) // APP_PORT、APP_DB_URL ...
+func LoadEnv(ptr any, opts ...Option) error {
+	o := newOptions(opts)
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config: expect non-nil pointer to struct, got %T", ptr)
+	}
+
+	_, errs := walkEnv(rv.Elem(), o.prefix, true, func(f envField) (bool, error) {
+		raw, ok := o.lookup(f.env)
+		switch {
+		case ok:
+		case f.hasDef:
+			raw = f.def
+		case f.required:
+			return false, fmt.Errorf("config: missing required env %s", f.env)
+		default:
+			return false, nil
+		}
+		if err := setValue(f.value, raw); err != nil {
+			return ok, fmt.Errorf("config: %s: %w", f.env, err)
+		}
+		return ok, nil
+	})
+	return errors.Join(errs...)
+}
+
+// walkEnv 遍历结构体的叶子字段，fn 返回该字段的环境变量是否已设置；返回值为是否有任一字段已设置以及全部错误
+//
+//	alloc 为 true 时（加载）：为 nil 的结构体指针只在其下有变量已设置时才分配，否则保持 nil 并忽略其中必填字段的错误；
+//	alloc 为 false 时（只读）：按零值遍历 nil 的结构体指针
+func walkEnv(sv reflect.Value, prefix string, alloc bool, fn func(f envField) (bool, error)) (bool, []error) {
+	return walkFields(sv, prefix, prefix, alloc, false, fn)
+}
+
+// walkFields walkEnv 的递归实现，rootPrefix 为 WithPrefix 指定的全局前缀
+func walkFields(sv reflect.Value, rootPrefix, prefix string, alloc, fresh bool, fn func(f envField) (bool, error)) (bool, []error) {
+	var (
+		found bool
+		errs  []error
+	)
+	for _, info := range reflecting.FieldsOfType(sv.Type(), "env") {
+		var fv reflect.Value
+		if alloc {
+			if fv = info.ValueAlloc(sv); !fv.CanSet() {
+				continue
+			}
+		} else if fv = info.Value(sv); !fv.IsValid() {
+			fv = reflect.New(info.Type).Elem()
+		}
+
+		name := info.TagName
+		if name == "" {
+			name = upperSnake(info.Name)
+		}
+		if isNested(info.Type) {
+			var (
+				subFound bool
+				subErrs  []error
+			)
+			switch {
+			case fv.Kind() != reflect.Pointer:
+				subFound, subErrs = walkFields(fv, rootPrefix, prefix+name+"_", alloc, fresh, fn)
+			case !fv.IsNil():
+				subFound, subErrs = walkFields(fv.Elem(), rootPrefix, prefix+name+"_", alloc, fresh, fn)
+			default:
+				tmp := reflect.New(info.Type.Elem())
+				if subFound, subErrs = walkFields(tmp.Elem(), rootPrefix, prefix+name+"_", alloc, true, fn); alloc && subFound {
+					fv.Set(tmp)
+				} else if alloc {
+					subErrs = nil
+				}
+			}
+			found = found || subFound
+			errs = append(errs, subErrs...)
+			continue
+		}
+
+		def, hasDef := info.StructTag.Lookup("default")
+		ok, err := fn(envField{
+			value:    fv,
+			env:      prefix + name,
+			name:     strings.TrimPrefix(prefix+name, rootPrefix),
+			fresh:    fresh,
+			def:      def,
+			hasDef:   hasDef,
+			required: info.HasOption("required"),
+			secret:   info.HasOption("secret"),
+		})
+		found = found || ok
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return found, errs
+}
+
+var (
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	timeType            = reflect.TypeFor[time.Time]()
+)
+
+// isNested 判断字段是否按嵌套结构体展开，而非作为单个值解析
+func isNested(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && t != timeType && !reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// setValue 将字符串 raw 转换后写入字段
+func setValue(fv reflect.Value, raw string) error {
+	t := fv.Type()
+	elemType := t
+	if elemType.Kind() == reflect.Pointer {
+		elemType = elemType.Elem()
+	}
+	var src any = raw
+	if !reflect.PointerTo(elemType).Implements(textUnmarshalerType) {
+		switch elemType.Kind() {
+		case reflect.Slice, reflect.Array:
+			if elemType.Elem().Kind() != reflect.Uint8 {
+				src = splitList(raw)
+			}
+		case reflect.Map:
+			m, err := splitMap(raw)
+			if err != nil {
+				return err
+			}
+			src = m
+		}
+	}
+	v, err := reflecting.ConvertTo(src, t)
+	if err != nil {
+		return err
+	}
+	fv.Set(v)
+	return nil
+}
+
+func splitList(raw string) []any {
+	if strings.TrimSpace(raw) == "" {
+		return []any{}
+	}
+	parts := strings.Split(raw, ",")
+	res := make([]any, len(parts))
+	for i, p := range parts {
+		res[i] = strings.TrimSpace(p)
+	}
+	return res
+}
+
+func splitMap(raw string) (map[string]any, error) {
+	res := map[string]any{}
+	if strings.TrimSpace(raw) == "" {
+		return res, nil
+	}
+	for pair := range strings.SplitSeq(raw, ",") {
+		k, v, ok := strings.Cut(pair, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid map entry %q, expect key:value", pair)
+		}
+		res[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return res, nil
+}
+
+// upperSnake 将 Go 字段名转为大写下划线形式，连续的大写字母视为一个缩写：HTTPPort → HTTP_PORT
+func upperSnake(name string) string {
+	runes := []rune(name)
+	var sb strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				sb.WriteByte('_')
+			}
+		}
+		sb.WriteRune(unicode.ToUpper(r))
+	}
+	return sb.String()
+}
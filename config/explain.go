@@ -0,0 +1,86 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Explain 列出结构体各字段对应的环境变量、当前值与来源，secret 字段的值打码，用于启动时输出实际生效的配置
+//
+//	来源为 env（环境变量已设置）、default（使用默认值）或 unset
+//
+//	@param ptr any 结构体或结构体指针，通常为 LoadEnv 之后的配置
+//	@param opts ...Option 须与 LoadEnv 一致
+//	@return string 每行形如 APP_PORT=8080 (env)
+//	@update 2026-10-18 00:57:31
+func Explain(ptr any, opts ...Option) string {
+	o := newOptions(opts)
+	rv := reflect.ValueOf(ptr)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return ""
+	}
+
+	var sb strings.Builder
+	walkEnv(rv, o.prefix, false, func(f envField) (bool, error) {
+		source := "unset"
+		if _, ok := o.lookup(f.env); ok {
+			source = "env"
+		} else if f.hasDef {
+			source = "default"
+		}
+		value := formatValue(f.value)
+		if f.secret {
+			value = mask(value)
+		}
+		fmt.Fprintf(&sb, "%s=%s (%s)\n", f.env, value, source)
+		return false, nil
+	})
+	return sb.String()
+}
+
+// formatValue 以 LoadEnv 可解析的形式输出字段值
+func formatValue(v reflect.Value) string {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if !v.CanInterface() {
+		return ""
+	}
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		return s.String()
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			break
+		}
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = formatValue(v.Index(i))
+		}
+		return strings.Join(parts, ",")
+	case reflect.Map:
+		parts := make([]string, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			parts = append(parts, formatValue(iter.Key())+":"+formatValue(iter.Value()))
+		}
+		return strings.Join(parts, ",")
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// mask 空值保持为空以便看出未配置，其余一律替换为固定长度的 *
+func mask(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "******"
+}
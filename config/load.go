@@ -0,0 +1,280 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/BetaGoRobot/go_utils/jsonx"
+	"github.com/BetaGoRobot/go_utils/reflecting"
+	"gopkg.in/yaml.v3"
+)
+
+// configFile 配置文件及其是否可以不存在
+type configFile struct {
+	path     string
+	optional bool
+}
+
+// WithFiles 依次加载的配置文件，后面的文件优先；按扩展名解析 .yaml/.yml 或 .json，文件不存在时报错。仅 Load 与 Watch 使用
+//
+//	@param paths ...string
+//	@return Option
+//	@update 2026-10-18 01:20:16
+func WithFiles(paths ...string) Option {
+	return func(o *options) {
+		for _, p := range paths {
+			o.files = append(o.files, configFile{path: p})
+		}
+	}
+}
+
+// WithOptionalFiles 同 WithFiles，但文件不存在时跳过，如本地覆盖用的 config.local.yaml
+//
+//	@param paths ...string
+//	@return Option
+//	@update 2026-10-18 01:20:16
+func WithOptionalFiles(paths ...string) Option {
+	return func(o *options) {
+		for _, p := range paths {
+			o.files = append(o.files, configFile{path: p, optional: true})
+		}
+	}
+}
+
+// WithFlags 使用 fs 中显式设置过的命令行参数，优先级最高；fs 须已 Parse。仅 Load 与 Watch 使用
+//
+//	参数名由不含前缀的变量名转为小写中划线形式，如 DB_MAX_IDLE_CONNS → -db-max-idle-conns，
+//	可用 BindFlags 按该规则注册参数
+//
+//	@param fs *flag.FlagSet
+//	@return Option
+//	@update 2026-10-18 01:20:16
+func WithFlags(fs *flag.FlagSet) Option {
+	return func(o *options) { o.flags = fs }
+}
+
+// Load 按优先级从低到高合并各配置来源并加载到 ptr 指向的结构体：
+// default tag < 配置文件（按 WithFiles 的顺序以 jsonx.DeepMerge 深度合并）< 环境变量 < 命令行参数
+//
+//	配置文件的 key 按 json tag 匹配字段（YAML 文件同样使用 json tag），值的类型转换与环境变量一致（reflecting.MapToStruct），
+//	环境变量与参数名的规则同 LoadEnv；
+//	required 字段在所有来源中都没有提供值时报错，显式设置为零值（如 DEBUG=false）视为已提供
+//
+//	@param ptr any 结构体指针
+//	@param opts ...Option
+//	@return error
+//	@update 2026-10-18 14:33:10
+//
+// for example:
+//
+//	fs := flag.NewFlagSet("app", flag.ExitOnError)
+//	config.BindFlags(fs, &Config{})
+//	_ = fs.Parse(os.Args[1:])
+//	var cfg Config
+//	err := config.Load(&cfg,
+//		config.WithFiles("config.yaml"), config.WithOptionalFiles("config.local.yaml"),
+//		config.WithPrefix("APP_"), config.WithFlags(fs))
+func Load(ptr any, opts ...Option) error {
+	o := newOptions(opts)
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config: expect non-nil pointer to struct, got %T", ptr)
+	}
+	sv := rv.Elem()
+	// supplied 任一来源（default、配置文件、环境变量、命令行参数）提供了值的字段，按完整变量名记录；
+	// 必填校验据此判断，显式设置为 false、0 的字段同样视为已提供，与 LoadEnv 一致
+	supplied := map[string]bool{}
+
+	// default tag
+	_, errs := walkEnv(sv, o.prefix, true, func(f envField) (bool, error) {
+		if !f.hasDef {
+			return false, nil
+		}
+		supplied[f.env] = true
+		if err := setValue(f.value, f.def); err != nil {
+			return false, fmt.Errorf("config: default of %s: %w", f.env, err)
+		}
+		return false, nil
+	})
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	// 配置文件
+	merged, err := readFiles(o.files)
+	if err != nil {
+		return err
+	}
+	if len(merged) > 0 {
+		// 与环境变量使用同一套转换规则，如 timeout: 5s 可解析为 time.Duration
+		if err := reflecting.MapToStruct(merged, ptr); err != nil {
+			return fmt.Errorf("config: decode files: %w", err)
+		}
+		fileSupplied(sv.Type(), merged, o.prefix, supplied)
+	}
+
+	// 环境变量与命令行参数
+	flags := setFlags(o.flags)
+	_, errs = walkEnv(sv, o.prefix, true, func(f envField) (bool, error) {
+		raw, source := "", ""
+		if v, ok := flags[flagName(f.name)]; ok {
+			raw, source = v, "-"+flagName(f.name)
+		} else if v, ok := o.lookup(f.env); ok {
+			raw, source = v, f.env
+		} else if f.fresh && f.hasDef {
+			// 为 nil 指针新建的结构体没有经过 default 阶段
+			raw = f.def
+		} else {
+			return false, nil
+		}
+		supplied[f.env] = true
+		if err := setValue(f.value, raw); err != nil {
+			return source != "", fmt.Errorf("config: %s: %w", cmpOr(source, f.env), err)
+		}
+		return source != "", nil
+	})
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	// 必填校验：nil 的结构体指针视为未启用，不检查其中的字段
+	// 这里始终返回 false，只校验不分配指针
+	_, errs = walkEnv(sv, o.prefix, true, func(f envField) (bool, error) {
+		if f.required && !supplied[f.env] {
+			return false, fmt.Errorf("config: missing required value %s (env %s, flag -%s)", f.name, f.env, flagName(f.name))
+		}
+		return false, nil
+	})
+	return errors.Join(errs...)
+}
+
+// BindFlags 按 Load 的命名规则为 ptr 的每个字段在 fs 中注册字符串参数，默认值与用法说明取自 default 与 usage tag
+//
+//	@param fs *flag.FlagSet
+//	@param ptr any 结构体指针，只用于读取字段结构
+//	@param opts ...Option
+//	@update 2026-10-18 01:20:16
+func BindFlags(fs *flag.FlagSet, ptr any, opts ...Option) {
+	o := newOptions(opts)
+	rv := reflect.ValueOf(ptr)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return
+	}
+	walkEnv(rv, o.prefix, false, func(f envField) (bool, error) {
+		name := flagName(f.name)
+		if fs.Lookup(name) == nil {
+			usage := "env " + f.env
+			if f.secret {
+				usage += " (secret)"
+			}
+			fs.String(name, f.def, usage)
+		}
+		return false, nil
+	})
+}
+
+// readFiles 读取并深度合并配置文件
+func readFiles(files []configFile) (map[string]any, error) {
+	merged := map[string]any{}
+	for _, f := range files {
+		data, err := os.ReadFile(f.path)
+		if err != nil {
+			if f.optional && errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, fmt.Errorf("config: %w", err)
+		}
+		var m map[string]any
+		switch ext := strings.ToLower(filepath.Ext(f.path)); ext {
+		case ".yaml", ".yml":
+			err = yaml.Unmarshal(data, &m)
+		case ".json":
+			err = json.Unmarshal(data, &m)
+		default:
+			return nil, fmt.Errorf("config: unsupported config file type %q: %s", ext, f.path)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("config: parse %s: %w", f.path, err)
+		}
+		merged = jsonx.DeepMerge(merged, m)
+	}
+	return merged, nil
+}
+
+// fileSupplied 按 walkEnv 的命名规则记录配置文件 m 中出现的字段，字段的 key 取自 json tag，匹配规则同 reflecting.MapToStruct
+func fileSupplied(t reflect.Type, m map[string]any, prefix string, supplied map[string]bool) {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	keys := map[string]string{} // 字段下标 -> json key
+	for _, info := range reflecting.FieldsOfType(t, "json") {
+		keys[fmt.Sprint(info.Index)] = info.Key()
+	}
+	for _, info := range reflecting.FieldsOfType(t, "env") {
+		key, ok := keys[fmt.Sprint(info.Index)]
+		if !ok {
+			continue
+		}
+		v, ok := lookupKey(m, key)
+		if !ok {
+			continue
+		}
+		name := info.TagName
+		if name == "" {
+			name = upperSnake(info.Name)
+		}
+		if isNested(info.Type) {
+			if sub, ok := v.(map[string]any); ok {
+				fileSupplied(info.Type, sub, prefix+name+"_", supplied)
+			}
+			continue
+		}
+		supplied[prefix+name] = true
+	}
+}
+
+// lookupKey 先精确匹配再忽略大小写匹配，与 reflecting.MapToStruct 一致
+func lookupKey(m map[string]any, key string) (any, bool) {
+	if v, ok := m[key]; ok {
+		return v, true
+	}
+	for k, v := range m {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// setFlags 返回 fs 中显式设置过的参数
+func setFlags(fs *flag.FlagSet) map[string]string {
+	res := map[string]string{}
+	if fs == nil {
+		return res
+	}
+	fs.Visit(func(f *flag.Flag) {
+		res[f.Name] = f.Value.String()
+	})
+	return res
+}
+
+// flagName DB_MAX_IDLE_CONNS → db-max-idle-conns
+func flagName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", "-"))
+}
+
+func cmpOr(a, b string) string {
+	if a != "" {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,91 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/BetaGoRobot/go_utils/config"
+	"github.com/BetaGoRobot/go_utils/testx"
+)
+
+type loadConfig struct {
+	Name    string        `json:"name"`
+	Port    int           `json:"port"`
+	Timeout time.Duration `json:"timeout"`
+	DB      struct {
+		Hosts []string      `json:"hosts"`
+		Idle  time.Duration `json:"idle"`
+	} `json:"db"`
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	testx.ErrorIs(t, os.WriteFile(path, []byte(content), 0o600), nil)
+	return path
+}
+
+func noEnv(string) (string, bool) { return "", false }
+
+// TestLoadFileCoercion 配置文件的值与环境变量使用同一套转换规则
+func TestLoadFileCoercion(t *testing.T) {
+	tests := []struct {
+		name, file, content string
+	}{
+		{name: "yaml", file: "c.yaml", content: "name: svc\nport: \"08080\"\ntimeout: 5s\ndb:\n  hosts: [a, b]\n  idle: 1m\n"},
+		{name: "json", file: "c.json", content: `{"name":"svc","port":8080,"timeout":"5s","db":{"hosts":["a","b"],"idle":"1m"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg loadConfig
+			err := config.Load(&cfg, config.WithFiles(writeFile(t, tt.file, tt.content)), config.WithLookup(noEnv))
+			testx.ErrorIs(t, err, nil)
+			testx.Equal(t, "svc", cfg.Name)
+			testx.Equal(t, 8080, cfg.Port)
+			testx.Equal(t, 5*time.Second, cfg.Timeout)
+			testx.DeepEqual(t, []string{"a", "b"}, cfg.DB.Hosts)
+			testx.Equal(t, time.Minute, cfg.DB.Idle)
+		})
+	}
+}
+
+type requiredConfig struct {
+	Debug bool   `env:"DEBUG,required" json:"debug"`
+	Port  int    `env:"PORT,required" json:"port" default:"0"`
+	Name  string `env:"NAME" json:"name"`
+	DB    *struct {
+		URL string `env:"URL,required" json:"url"`
+	} `json:"db"`
+}
+
+func TestLoadRequiredZeroValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		file    string
+		wantErr bool
+	}{
+		{name: "env false", env: map[string]string{"DEBUG": "false"}},
+		{name: "file false", file: "debug: false\n"},
+		{name: "missing", wantErr: true},
+		{name: "nil nested struct is not checked", env: map[string]string{"DEBUG": "true"}},
+		{name: "nested struct from file", file: "debug: true\ndb:\n  name: x\n", wantErr: true},
+		{name: "nested required from file", file: "debug: true\ndb:\n  url: \"\"\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []config.Option{config.WithLookup(func(k string) (string, bool) {
+				v, ok := tt.env[k]
+				return v, ok
+			})}
+			if tt.file != "" {
+				opts = append(opts, config.WithFiles(writeFile(t, "c.yaml", tt.file)))
+			}
+			var cfg requiredConfig
+			err := config.Load(&cfg, opts...)
+			testx.Equal(t, tt.wantErr, err != nil, "err: %v", err)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/BetaGoRobot/go_utils/concurrency"
+	"github.com/fsnotify/fsnotify"
+)
+
+// ErrNoFiles Watch 未指定任何配置文件
+var ErrNoFiles = errors.New("config: no files to watch")
+
+// WithDebounce 配置文件变化后等待的静默时长，期间的多次变化只触发一次重新加载，默认 200ms。仅 Watch 使用
+//
+//	@param d time.Duration
+//	@return Option
+//	@update 2026-10-18 01:20:16
+func WithDebounce(d time.Duration) Option {
+	return func(o *options) { o.debounce = d }
+}
+
+// Watch 以 Load 的规则加载配置，并在配置文件变化时重新加载，直到 ctx 结束
+//
+//	每次重新加载都写入新的 *T 后回调 onChange；加载失败时 cfg 为 nil、err 非 nil，调用方应继续使用旧配置。
+//	监听的是文件所在目录，因此编辑器的原子替换写入与 WithOptionalFiles 文件的新建、删除都能被感知。
+//	onChange 的调用是串行的
+//
+//	@param ctx context.Context
+//	@param onChange func(cfg *T, err error)
+//	@param opts ...Option
+//	@return *T 首次加载的配置
+//	@return error 首次加载失败或无法监听时返回
+//	@update 2026-10-18 01:20:16
+//
+// for example:
+//
+//	var current atomic.Pointer[Config]
+//	cfg, err := config.Watch(ctx, func(cfg *Config, err error) {
+//		if err != nil {
+//			slog.Warn("reload config failed", "err", err)
+//			return
+//		}
+//		current.Store(cfg)
+//	}, config.WithFiles("config.yaml"), config.WithPrefix("APP_"))
+func Watch[T any](ctx context.Context, onChange func(cfg *T, err error), opts ...Option) (*T, error) {
+	o := newOptions(opts)
+	if len(o.files) == 0 {
+		return nil, ErrNoFiles
+	}
+	cfg := new(T)
+	if err := Load(cfg, opts...); err != nil {
+		return nil, err
+	}
+
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	targets := map[string]bool{}
+	dirs := map[string]bool{}
+	for _, f := range o.files {
+		abs, err := filepath.Abs(f.path)
+		if err != nil {
+			w.Close()
+			return nil, err
+		}
+		targets[abs] = true
+		dirs[filepath.Dir(abs)] = true
+	}
+	for dir := range dirs {
+		if err := w.Add(dir); err != nil {
+			w.Close()
+			return nil, err
+		}
+	}
+
+	var mu sync.Mutex
+	reload := concurrency.Debounce(o.debounce, func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if ctx.Err() != nil {
+			return
+		}
+		next := new(T)
+		if err := Load(next, opts...); err != nil {
+			onChange(nil, err)
+			return
+		}
+		onChange(next, nil)
+	})
+
+	go func() {
+		defer w.Close()
+		defer reload.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev, ok := <-w.Events:
+				if !ok {
+					return
+				}
+				if abs, err := filepath.Abs(ev.Name); err == nil && targets[abs] && !ev.Has(fsnotify.Chmod) {
+					reload.Call()
+				}
+			case err, ok := <-w.Errors:
+				if !ok {
+					return
+				}
+				slog.Warn("config: watch error", "err", err)
+			}
+		}
+	}()
+	return cfg, nil
+}
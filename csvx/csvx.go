@@ -0,0 +1,308 @@
+// Package csvx 基于 csv tag 在 CSV 与结构体切片之间编解码，支持表头、自定义分隔符与基于 iter.Seq 的流式读写
+package csvx
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+// ErrUnsupportedType 字段类型无法与 CSV 单元格互相转换
+var ErrUnsupportedType = errors.New("csvx: unsupported field type")
+
+// ParseError 解码某个单元格失败
+type ParseError struct {
+	Line   int    // 从 1 开始的行号，含表头
+	Column string // 列名，无表头时为字段的 csv 名称
+	Err    error
+}
+
+// Error 实现 error
+//
+//	@receiver e *ParseError
+//	@return string
+//	@update 2026-10-18 03:34:20
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("csvx: line %d, column %q: %v", e.Line, e.Column, e.Err)
+}
+
+// Unwrap 返回底层错误
+//
+//	@receiver e *ParseError
+//	@return error
+//	@update 2026-10-18 03:34:20
+func (e *ParseError) Unwrap() error { return e.Err }
+
+type options struct {
+	comma      rune
+	noHeader   bool
+	timeLayout string
+	useCRLF    bool
+}
+
+// Option 编解码选项
+type Option func(*options)
+
+// WithComma 设置分隔符，默认 ','，如 '\t' 用于 TSV
+//
+//	@param r rune
+//	@return Option
+//	@update 2026-10-18 03:34:20
+func WithComma(r rune) Option {
+	return func(o *options) { o.comma = r }
+}
+
+// WithoutHeader 编码时不输出表头；解码时第一行即为数据，列按字段声明顺序对应
+//
+//	@return Option
+//	@update 2026-10-18 03:34:20
+func WithoutHeader() Option {
+	return func(o *options) { o.noHeader = true }
+}
+
+// WithTimeLayout 设置 time.Time 字段的默认格式，默认 time.RFC3339；字段可用 `csv:"at,format=2006-01-02"` 单独指定
+//
+//	@param layout string
+//	@return Option
+//	@update 2026-10-18 03:34:20
+func WithTimeLayout(layout string) Option {
+	return func(o *options) { o.timeLayout = layout }
+}
+
+// WithCRLF 编码时以 \r\n 作为行尾，便于 Excel 打开
+//
+//	@return Option
+//	@update 2026-10-18 03:34:20
+func WithCRLF() Option {
+	return func(o *options) { o.useCRLF = true }
+}
+
+func newOptions(opts []Option) options {
+	o := options{comma: ',', timeLayout: time.RFC3339}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// column 结构体字段与 CSV 列的对应关系
+type column struct {
+	name   string
+	field  reflecting.FieldInfo
+	layout string // time.Time 的格式
+}
+
+var (
+	timeType            = reflect.TypeFor[time.Time]()
+	durationType        = reflect.TypeFor[time.Duration]()
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// columnsOf 返回 T 的列定义，未设置 csv tag 的导出字段以字段名作为列名
+func columnsOf(t reflect.Type, o options) ([]column, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("csvx: expect struct, got %s", t)
+	}
+	fields := reflecting.FieldsOfType(t, "csv")
+	cols := make([]column, 0, len(fields))
+	for _, f := range fields {
+		if err := checkType(f.Type); err != nil {
+			return nil, fmt.Errorf("%w: field %s %s", err, f.Name, f.Type)
+		}
+		layout := o.timeLayout
+		if v, ok := f.Option("format"); ok {
+			layout = v
+		}
+		cols = append(cols, column{name: f.Key(), field: f, layout: layout})
+	}
+	return cols, nil
+}
+
+// checkType 检查字段类型是否可以表示为单个单元格
+func checkType(t reflect.Type) error {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType || t == durationType || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return nil
+	}
+	return ErrUnsupportedType
+}
+
+// format 将字段值格式化为单元格文本，nil 指针为空字符串
+func (c column) format(v reflect.Value) (string, error) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	switch v.Type() {
+	case timeType:
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return "", nil
+		}
+		return t.Format(c.layout), nil
+	case durationType:
+		return time.Duration(v.Int()).String(), nil
+	}
+	if v.Type().Implements(textMarshalerType) || reflect.PointerTo(v.Type()).Implements(textMarshalerType) {
+		if !v.CanAddr() {
+			tmp := reflect.New(v.Type()).Elem()
+			tmp.Set(v)
+			v = tmp
+		}
+		m, ok := v.Interface().(encoding.TextMarshaler)
+		if !ok {
+			m = v.Addr().Interface().(encoding.TextMarshaler)
+		}
+		b, err := m.MarshalText()
+		return string(b), err
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), nil
+	}
+	return "", ErrUnsupportedType
+}
+
+// parse 将单元格文本解析到字段，空字符串保持零值（指针为 nil）
+func (c column) parse(s string, v reflect.Value) error {
+	if s == "" {
+		v.SetZero()
+		return nil
+	}
+	if v.Kind() == reflect.Pointer {
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+	switch v.Type() {
+	case timeType:
+		t, err := time.Parse(c.layout, strings.TrimSpace(s))
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	case durationType:
+		d, err := time.ParseDuration(strings.TrimSpace(s))
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(s))
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(strings.TrimSpace(s), 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(strings.TrimSpace(s), 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(strings.TrimSpace(s), v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	default:
+		return ErrUnsupportedType
+	}
+	return nil
+}
+
+// Marshal 将 rows 编码为 CSV，默认首行为表头
+//
+//	@param rows []T
+//	@param opts ...Option
+//	@return []byte
+//	@return error
+//	@update 2026-10-18 03:34:20
+//
+// for example:
+//
+//	type Row struct {
+//		ID    int       `csv:"id"`
+//		Name  string    `csv:"name"`
+//		Day   time.Time `csv:"day,format=2006-01-02"`
+//		Notes string    `csv:"-"`
+//	}
+//	data, err := csvx.Marshal(rows)
+func Marshal[T any](rows []T, opts ...Option) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := NewWriter[T](&buf, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal 将 CSV 解码并追加到 *rows，默认首行为表头并按列名匹配字段，不认识的列被忽略
+//
+//	@param data []byte
+//	@param rows *[]T
+//	@param opts ...Option
+//	@return error 单元格转换失败时为 *ParseError
+//	@update 2026-10-18 03:34:20
+func Unmarshal[T any](data []byte, rows *[]T, opts ...Option) error {
+	r, err := NewReader[T](bytes.NewReader(data), opts...)
+	if err != nil {
+		return err
+	}
+	for row, err := range r.All() {
+		if err != nil {
+			return err
+		}
+		*rows = append(*rows, row)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package csvx
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"iter"
+	"reflect"
+	"strings"
+)
+
+// Reader 将 CSV 逐行解码为 T
+type Reader[T any] struct {
+	r       *csv.Reader
+	o       options
+	cols    []column
+	mapping []int // 每一列对应的 cols 下标，-1 表示忽略
+	header  []string
+	line    int
+}
+
+// NewReader 创建读取 r 的 Reader；有表头时立即读取表头，列按名称匹配（先精确、再忽略大小写）
+//
+//	@param r io.Reader
+//	@param opts ...Option
+//	@return *Reader[T]
+//	@return error
+//	@update 2026-10-18 03:34:20
+func NewReader[T any](r io.Reader, opts ...Option) (*Reader[T], error) {
+	o := newOptions(opts)
+	cols, err := columnsOf(reflect.TypeFor[T](), o)
+	if err != nil {
+		return nil, err
+	}
+	cr := csv.NewReader(r)
+	cr.Comma = o.comma
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+	res := &Reader[T]{r: cr, o: o, cols: cols}
+
+	if o.noHeader {
+		res.mapping = make([]int, len(cols))
+		res.header = make([]string, len(cols))
+		for i, c := range cols {
+			res.mapping[i], res.header[i] = i, c.name
+		}
+		return res, nil
+	}
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return res, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	res.line = 1
+	res.header = append([]string(nil), header...)
+	res.mapping = make([]int, len(header))
+	for i, h := range res.header {
+		res.mapping[i] = matchColumn(cols, h)
+	}
+	return res, nil
+}
+
+// matchColumn 按名称查找列，找不到时返回 -1
+func matchColumn(cols []column, name string) int {
+	for i, c := range cols {
+		if c.name == name {
+			return i
+		}
+	}
+	for i, c := range cols {
+		if strings.EqualFold(c.name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Header 返回表头；WithoutHeader 时为各字段的列名
+//
+//	@receiver r *Reader[T]
+//	@return []string
+//	@update 2026-10-18 03:34:20
+func (r *Reader[T]) Header() []string {
+	return r.header
+}
+
+// Read 解码下一行，没有更多数据时返回 io.EOF
+//
+//	@receiver r *Reader[T]
+//	@return T
+//	@return error 单元格转换失败时为 *ParseError
+//	@update 2026-10-18 03:34:20
+func (r *Reader[T]) Read() (T, error) {
+	var row T
+	record, err := r.r.Read()
+	if err != nil {
+		return row, err
+	}
+	r.line++
+	rv := reflect.ValueOf(&row).Elem()
+	for i, cell := range record {
+		if i >= len(r.mapping) || r.mapping[i] < 0 {
+			continue
+		}
+		c := r.cols[r.mapping[i]]
+		if err := c.parse(cell, c.field.ValueAlloc(rv)); err != nil {
+			return row, &ParseError{Line: r.line, Column: r.header[i], Err: err}
+		}
+	}
+	return row, nil
+}
+
+// All 依次产出每一行，遇到错误时产出该错误并结束
+//
+//	@receiver r *Reader[T]
+//	@return iter.Seq2[T, error]
+//	@update 2026-10-18 03:34:20
+//
+// for example:
+//
+//	r, err := csvx.NewReader[Row](f)
+//	for row, err := range r.All() {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (r *Reader[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		if r.mapping == nil {
+			return
+		}
+		for {
+			row, err := r.Read()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if !yield(row, err) || err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package csvx
+
+import (
+	"encoding/csv"
+	"io"
+	"iter"
+	"reflect"
+)
+
+// Writer 将 T 逐行编码为 CSV，表头在第一次 Write 时输出
+type Writer[T any] struct {
+	w           *csv.Writer
+	o           options
+	cols        []column
+	wroteHeader bool
+	record      []string
+}
+
+// NewWriter 创建写入 w 的 Writer，T 不是结构体或包含不支持的字段类型时返回错误
+//
+//	@param w io.Writer
+//	@param opts ...Option
+//	@return *Writer[T]
+//	@return error
+//	@update 2026-10-18 03:34:20
+func NewWriter[T any](w io.Writer, opts ...Option) (*Writer[T], error) {
+	o := newOptions(opts)
+	cols, err := columnsOf(reflect.TypeFor[T](), o)
+	if err != nil {
+		return nil, err
+	}
+	cw := csv.NewWriter(w)
+	cw.Comma = o.comma
+	cw.UseCRLF = o.useCRLF
+	return &Writer[T]{w: cw, o: o, cols: cols, record: make([]string, len(cols))}, nil
+}
+
+// WriteHeader 输出表头，通常无需显式调用；WithoutHeader 或已输出时什么也不做
+//
+//	@receiver w *Writer[T]
+//	@return error
+//	@update 2026-10-18 03:34:20
+func (w *Writer[T]) WriteHeader() error {
+	if w.wroteHeader || w.o.noHeader {
+		return nil
+	}
+	w.wroteHeader = true
+	header := make([]string, len(w.cols))
+	for i, c := range w.cols {
+		header[i] = c.name
+	}
+	return w.w.Write(header)
+}
+
+// Write 编码一行
+//
+//	@receiver w *Writer[T]
+//	@param row T
+//	@return error
+//	@update 2026-10-18 03:34:20
+func (w *Writer[T]) Write(row T) error {
+	if err := w.WriteHeader(); err != nil {
+		return err
+	}
+	rv := reflect.ValueOf(&row).Elem()
+	for i, c := range w.cols {
+		fv := c.field.Value(rv)
+		if !fv.IsValid() {
+			w.record[i] = ""
+			continue
+		}
+		s, err := c.format(fv)
+		if err != nil {
+			return err
+		}
+		w.record[i] = s
+	}
+	return w.w.Write(w.record)
+}
+
+// WriteAll 编码 seq 中的每一行并 Flush
+//
+//	@receiver w *Writer[T]
+//	@param seq iter.Seq[T]
+//	@return error
+//	@update 2026-10-18 03:34:20
+//
+// for example:
+//
+//	w, _ := csvx.NewWriter[Row](resp)
+//	err := w.WriteAll(slices.Values(rows))
+func (w *Writer[T]) WriteAll(seq iter.Seq[T]) error {
+	for row := range seq {
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// Flush 将缓冲的内容写入底层 io.Writer；没有任何数据行时也会输出表头
+//
+//	@receiver w *Writer[T]
+//	@return error
+//	@update 2026-10-18 03:34:20
+func (w *Writer[T]) Flush() error {
+	if err := w.WriteHeader(); err != nil {
+		return err
+	}
+	w.w.Flush()
+	return w.w.Error()
+}
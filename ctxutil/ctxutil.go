@@ -0,0 +1,187 @@
+// Package ctxutil context 的常用辅助：脱离取消、类型化的值、带原因的超时与合并
+package ctxutil
+
+import (
+	"context"
+	"time"
+)
+
+// Detach 返回保留 ctx 中的值、但不随 ctx 取消且没有截止时间的 context，
+// 用于请求结束后仍需完成的后台工作（如异步写审计日志）
+//
+//	@param ctx context.Context
+//	@return context.Context
+//	@update 2026-10-17 21:30:48
+func Detach(ctx context.Context) context.Context {
+	return context.WithoutCancel(ctx)
+}
+
+// typeKey 以类型 T 为 key，每种类型在 context 中只存一个值
+type typeKey[T any] struct{}
+
+// WithValue 以 v 的类型为 key 存入值，无需单独定义 key 类型
+//
+//	@param ctx context.Context
+//	@param v T
+//	@return context.Context
+//	@update 2026-10-17 21:30:48
+//
+// for example:
+//
+//	ctx = ctxutil.WithValue(ctx, &User{ID: 1})
+//	user, ok := ctxutil.Value[*User](ctx)
+func WithValue[T any](ctx context.Context, v T) context.Context {
+	return context.WithValue(ctx, typeKey[T]{}, v)
+}
+
+// Value 取出由 WithValue 存入的类型为 T 的值
+//
+//	@param ctx context.Context
+//	@return T
+//	@return bool
+//	@update 2026-10-17 21:30:48
+func Value[T any](ctx context.Context) (T, bool) {
+	v, ok := ctx.Value(typeKey[T]{}).(T)
+	return v, ok
+}
+
+// Key 带名字的类型化 key，同一类型需要存多个值时使用
+type Key[T any] struct {
+	name string
+}
+
+// NewKey 创建 key；每次调用得到不同的 key，通常定义为包级变量
+//
+//	@param name string 仅用于调试输出
+//	@return *Key[T]
+//	@update 2026-10-17 21:30:48
+//
+// for example:
+//
+//	var traceID = ctxutil.NewKey[string]("trace_id")
+//	ctx = traceID.WithValue(ctx, "abc")
+//	id, _ := traceID.Value(ctx)
+func NewKey[T any](name string) *Key[T] {
+	return &Key[T]{name: name}
+}
+
+// WithValue 以 k 为 key 存入值
+//
+//	@receiver k *Key[T]
+//	@param ctx context.Context
+//	@param v T
+//	@return context.Context
+//	@update 2026-10-17 21:30:48
+func (k *Key[T]) WithValue(ctx context.Context, v T) context.Context {
+	return context.WithValue(ctx, k, v)
+}
+
+// Value 取出以 k 为 key 的值
+//
+//	@receiver k *Key[T]
+//	@param ctx context.Context
+//	@return T
+//	@return bool
+//	@update 2026-10-17 21:30:48
+func (k *Key[T]) Value(ctx context.Context) (T, bool) {
+	v, ok := ctx.Value(k).(T)
+	return v, ok
+}
+
+// MustValue 取出以 k 为 key 的值，不存在时 panic
+//
+//	@receiver k *Key[T]
+//	@param ctx context.Context
+//	@return T
+//	@update 2026-10-17 21:30:48
+func (k *Key[T]) MustValue(ctx context.Context) T {
+	v, ok := k.Value(ctx)
+	if !ok {
+		panic("ctxutil: missing context value " + k.name)
+	}
+	return v
+}
+
+// String 返回 key 的名字
+//
+//	@receiver k *Key[T]
+//	@return string
+//	@update 2026-10-17 21:30:48
+func (k *Key[T]) String() string {
+	return k.name
+}
+
+// WithTimeoutCause 与 context.WithTimeoutCause 相同，超时后 context.Cause 返回 cause；
+// 区别在于返回的取消函数也可以携带原因，主动取消时 context.Cause 返回传入的错误
+//
+//	@param ctx context.Context
+//	@param d time.Duration
+//	@param cause error
+//	@return context.Context
+//	@return context.CancelCauseFunc 传入 nil 时原因为 context.Canceled
+//	@update 2026-10-17 21:30:48
+//
+// for example:
+//
+//	ctx, cancel := ctxutil.WithTimeoutCause(ctx, 3*time.Second, errUpstreamSlow)
+//	defer cancel(nil)
+func WithTimeoutCause(ctx context.Context, d time.Duration, cause error) (context.Context, context.CancelCauseFunc) {
+	ctx, cancelCause := context.WithCancelCause(ctx)
+	ctx, cancel := context.WithTimeoutCause(ctx, d, cause)
+	return ctx, func(err error) {
+		cancelCause(err)
+		cancel()
+	}
+}
+
+// mergedCtx 取消与值查找优先使用 Context，值找不到时再查 other，截止时间取两者中较早的
+type mergedCtx struct {
+	context.Context
+	other context.Context
+}
+
+func (c *mergedCtx) Deadline() (time.Time, bool) {
+	d1, ok1 := c.Context.Deadline()
+	d2, ok2 := c.other.Deadline()
+	switch {
+	case !ok2:
+		return d1, ok1
+	case !ok1 || d2.Before(d1):
+		return d2, true
+	default:
+		return d1, true
+	}
+}
+
+func (c *mergedCtx) Value(key any) any {
+	if v := c.Context.Value(key); v != nil {
+		return v
+	}
+	return c.other.Value(key)
+}
+
+// Merge 合并两个 context：任一结束时结束，值先查 ctx1 再查 ctx2，截止时间取较早者
+//
+//	因 ctx2 结束而结束时 Err 为 context.Canceled，context.Cause 返回 ctx2 的原因
+//
+//	@param ctx1 context.Context
+//	@param ctx2 context.Context
+//	@return context.Context
+//	@return context.CancelFunc 须调用以释放对 ctx2 的监听
+//	@update 2026-10-17 21:30:48
+//
+// for example:
+//
+//	// 请求取消或服务关闭时都停止处理
+//	ctx, cancel := ctxutil.Merge(reqCtx, serverCtx)
+//	defer cancel()
+func Merge(ctx1, ctx2 context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(ctx1)
+	stop := context.AfterFunc(ctx2, func() {
+		cancel(context.Cause(ctx2))
+	})
+	return &mergedCtx{Context: ctx, other: ctx2}, func() {
+		stop()
+		cancel(context.Canceled)
+	}
+}
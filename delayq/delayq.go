@@ -0,0 +1,319 @@
+// Package delayq 延迟队列：条目在指定时间到期后投递到消费 channel，以哈希时间轮组织大量条目，支持取消与持久化钩子
+package delayq
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// ErrClosed 队列已关闭
+var ErrClosed = errors.New("delayq: closed")
+
+// ID 条目标识，Restore 恢复的条目沿用原 ID
+type ID uint64
+
+// Item 队列中的条目
+type Item[T any] struct {
+	ID    ID
+	Value T
+	Due   time.Time
+}
+
+// Persister 持久化钩子：Schedule 时 Save，投递到 C 或被取消后 Delete；进程重启后由调用方读出未完成的条目并 Restore
+//
+//	投递后才 Delete，因此崩溃恢复时条目可能被重复投递（至少一次）
+type Persister[T any] interface {
+	Save(item Item[T]) error
+	Delete(id ID) error
+}
+
+// entry 时间轮中的条目
+type entry[T any] struct {
+	item   Item[T]
+	slot   int
+	rounds int // 还需经过所在槽的圈数
+	elem   *list.Element
+}
+
+// Queue 基于哈希时间轮的延迟队列
+type Queue[T any] struct {
+	tick      time.Duration
+	start     time.Time
+	persister Persister[T] // 受 mu 保护
+	onError   func(ID, error)
+	out       chan Item[T]
+
+	mu      sync.Mutex
+	slots   []*list.List
+	cursor  int64 // 已处理到的刻度序号
+	entries map[ID]*entry[T]
+	nextID  ID
+	closed  bool
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New 创建延迟队列并启动时间轮，使用完毕后须调用 Close
+//
+//	@param opts ...Option
+//	@return *Queue[T]
+//	@update 2026-10-18 10:13:05
+//
+// for example:
+//
+//	q := delayq.New[Job](delayq.WithTick(100 * time.Millisecond))
+//	defer q.Close()
+//	go func() {
+//		for it := range q.C() {
+//			run(it.Value)
+//		}
+//	}()
+//	id, _ := q.After(job, 30*time.Second)
+//	...
+//	q.Cancel(id)
+func New[T any](opts ...Option) *Queue[T] {
+	o := options{tick: defaultTick, slots: defaultSlots}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	q := &Queue[T]{
+		tick:    o.tick,
+		start:   time.Now(),
+		onError: o.onError,
+		out:     make(chan Item[T], max(o.buffer, 0)),
+		slots:   make([]*list.List, max(o.slots, 1)),
+		entries: map[ID]*entry[T]{},
+		nextID:  1,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	if q.tick <= 0 {
+		q.tick = defaultTick
+	}
+	for i := range q.slots {
+		q.slots[i] = list.New()
+	}
+	if q.onError == nil {
+		q.onError = func(id ID, err error) {
+			slog.Default().Error("delayq: delete persisted item failed", "id", uint64(id), "err", err)
+		}
+	}
+	go q.run()
+	return q
+}
+
+// C 到期条目的投递 channel，Close 后被关闭
+//
+//	@receiver q *Queue[T]
+//	@return <-chan Item[T]
+//	@update 2026-10-18 10:13:05
+func (q *Queue[T]) C() <-chan Item[T] {
+	return q.out
+}
+
+// WithPersister 设置持久化钩子，p 为 nil 时取消；返回 q 以便链式调用
+//
+//	应在 Schedule 与 Restore 之前调用，此前安排的条目不会被 Save
+//
+//	@receiver q *Queue[T]
+//	@param p Persister[T]
+//	@return *Queue[T]
+//	@update 2026-10-18 14:00:36
+//
+// for example:
+//
+//	q := delayq.New[Job]().WithPersister(store)
+//	_ = q.Restore(store.Pending()...)
+func (q *Queue[T]) WithPersister(p Persister[T]) *Queue[T] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.persister = p
+	return q
+}
+
+// Schedule 安排 v 在 at 时刻投递，at 已过时在下一个刻度投递
+//
+//	@receiver q *Queue[T]
+//	@param v T
+//	@param at time.Time
+//	@return ID
+//	@return error 队列已关闭时返回 ErrClosed，Persister.Save 失败时返回其错误且条目不入队
+//	@update 2026-10-18 10:13:05
+func (q *Queue[T]) Schedule(v T, at time.Time) (ID, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return 0, ErrClosed
+	}
+	item := Item[T]{ID: q.nextID, Value: v, Due: at}
+	if q.persister != nil {
+		if err := q.persister.Save(item); err != nil {
+			return 0, fmt.Errorf("delayq: save: %w", err)
+		}
+	}
+	q.nextID++
+	q.add(item)
+	return item.ID, nil
+}
+
+// After 安排 v 在 d 之后投递
+//
+//	@receiver q *Queue[T]
+//	@param v T
+//	@param d time.Duration
+//	@return ID
+//	@return error
+//	@update 2026-10-18 10:13:05
+func (q *Queue[T]) After(v T, d time.Duration) (ID, error) {
+	return q.Schedule(v, time.Now().Add(d))
+}
+
+// Restore 恢复持久化的条目，沿用其 ID 与到期时间且不再调用 Persister.Save；ID 已在队列中的条目被忽略
+//
+//	@receiver q *Queue[T]
+//	@param items ...Item[T]
+//	@return error 队列已关闭时返回 ErrClosed
+//	@update 2026-10-18 10:13:05
+func (q *Queue[T]) Restore(items ...Item[T]) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrClosed
+	}
+	for _, item := range items {
+		if _, ok := q.entries[item.ID]; ok {
+			continue
+		}
+		q.nextID = max(q.nextID, item.ID+1)
+		q.add(item)
+	}
+	return nil
+}
+
+// Cancel 取消尚未投递的条目
+//
+//	@receiver q *Queue[T]
+//	@param id ID
+//	@return bool 条目不存在或已投递时返回 false
+//	@update 2026-10-18 10:13:05
+func (q *Queue[T]) Cancel(id ID) bool {
+	q.mu.Lock()
+	e, ok := q.entries[id]
+	if ok {
+		q.slots[e.slot].Remove(e.elem)
+		delete(q.entries, id)
+	}
+	q.mu.Unlock()
+	if ok {
+		q.forget(id)
+	}
+	return ok
+}
+
+// Len 返回尚未投递的条目数
+//
+//	@receiver q *Queue[T]
+//	@return int
+//	@update 2026-10-18 10:13:05
+func (q *Queue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.entries)
+}
+
+// Close 停止时间轮并关闭 C，未投递的条目被丢弃（已持久化的仍保留在 Persister 中）；可重复调用
+//
+//	@receiver q *Queue[T]
+//	@update 2026-10-18 10:13:05
+func (q *Queue[T]) Close() {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.stop)
+	}
+	q.mu.Unlock()
+	<-q.done
+}
+
+// add 将条目放入到期刻度对应的槽，调用方须持有锁
+func (q *Queue[T]) add(item Item[T]) {
+	target := int64((item.Due.Sub(q.start) + q.tick - 1) / q.tick)
+	target = max(target, q.cursor+1)
+	n := int64(len(q.slots))
+	e := &entry[T]{item: item, slot: int(target % n), rounds: int((target - q.cursor - 1) / n)}
+	e.elem = q.slots[e.slot].PushBack(e)
+	q.entries[item.ID] = e
+}
+
+// run 每个刻度推进时间轮，落后时（如消费方阻塞）逐刻补齐
+func (q *Queue[T]) run() {
+	defer close(q.done)
+	defer close(q.out)
+	ticker := time.NewTicker(q.tick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-q.stop:
+			return
+		case now := <-ticker.C:
+			current := int64(now.Sub(q.start) / q.tick)
+			for {
+				due, more := q.advance(current)
+				for _, item := range due {
+					select {
+					case q.out <- item:
+						q.forget(item.ID)
+					case <-q.stop:
+						return
+					}
+				}
+				if !more {
+					break
+				}
+			}
+		}
+	}
+}
+
+// advance 处理下一个刻度，返回其中到期的条目与是否仍未追上 now
+func (q *Queue[T]) advance(now int64) ([]Item[T], bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.cursor >= now {
+		return nil, false
+	}
+	q.cursor++
+	slot := q.slots[q.cursor%int64(len(q.slots))]
+	var due []Item[T]
+	for el := slot.Front(); el != nil; {
+		next := el.Next()
+		e := el.Value.(*entry[T])
+		if e.rounds > 0 {
+			e.rounds--
+		} else {
+			slot.Remove(el)
+			delete(q.entries, e.item.ID)
+			due = append(due, e.item)
+		}
+		el = next
+	}
+	return due, q.cursor < now
+}
+
+// forget 投递或取消后删除持久化的条目
+func (q *Queue[T]) forget(id ID) {
+	q.mu.Lock()
+	p := q.persister
+	q.mu.Unlock()
+	if p == nil {
+		return
+	}
+	if err := p.Delete(id); err != nil {
+		q.onError(id, err)
+	}
+}
@@ -0,0 +1,53 @@
+package delayq_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/BetaGoRobot/go_utils/delayq"
+	"github.com/BetaGoRobot/go_utils/testx"
+)
+
+type memPersister struct {
+	mu    sync.Mutex
+	items map[delayq.ID]delayq.Item[string]
+}
+
+func (m *memPersister) Save(item delayq.Item[string]) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.items[item.ID] = item
+	return nil
+}
+
+func (m *memPersister) Delete(id delayq.ID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.items, id)
+	return nil
+}
+
+func (m *memPersister) len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.items)
+}
+
+func TestQueuePersister(t *testing.T) {
+	store := &memPersister{items: map[delayq.ID]delayq.Item[string]{}}
+	q := delayq.New[string](delayq.WithTick(time.Millisecond)).WithPersister(store)
+	defer q.Close()
+
+	id, err := q.After("later", time.Hour)
+	testx.ErrorIs(t, err, nil)
+	_, err = q.After("soon", 0)
+	testx.ErrorIs(t, err, nil)
+	testx.Equal(t, 2, store.len())
+
+	testx.Equal(t, "soon", (<-q.C()).Value)
+	testx.Eventually(t, func() bool { return store.len() == 1 }, time.Second, time.Millisecond)
+
+	testx.Equal(t, true, q.Cancel(id))
+	testx.Equal(t, 0, store.len())
+}
@@ -0,0 +1,54 @@
+package delayq
+
+import "time"
+
+const (
+	defaultTick  = 10 * time.Millisecond
+	defaultSlots = 512
+)
+
+type options struct {
+	tick    time.Duration
+	slots   int
+	buffer  int
+	onError func(id ID, err error)
+}
+
+// Option New 的选项
+type Option func(*options)
+
+// WithTick 时间轮的刻度，即投递的精度，默认 10ms
+//
+//	@param d time.Duration
+//	@return Option
+//	@update 2026-10-18 10:13:05
+func WithTick(d time.Duration) Option {
+	return func(o *options) { o.tick = d }
+}
+
+// WithSlots 时间轮的槽数，默认 512；到期时间超过一圈的条目按圈数留在槽中，槽数越多每个刻度扫描的条目越少
+//
+//	@param n int
+//	@return Option
+//	@update 2026-10-18 10:13:05
+func WithSlots(n int) Option {
+	return func(o *options) { o.slots = n }
+}
+
+// WithBuffer C 的缓冲大小，默认 0；消费方跟不上时到期的条目会延后投递
+//
+//	@param n int
+//	@return Option
+//	@update 2026-10-18 10:13:05
+func WithBuffer(n int) Option {
+	return func(o *options) { o.buffer = n }
+}
+
+// OnPersistError 投递或取消后 Persister.Delete 失败时调用 fn，默认以 slog.Default() 记录
+//
+//	@param fn func(id ID, err error)
+//	@return Option
+//	@update 2026-10-18 10:13:05
+func OnPersistError(fn func(id ID, err error)) Option {
+	return func(o *options) { o.onError = fn }
+}
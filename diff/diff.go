@@ -0,0 +1,374 @@
+// Package diff 比较两个结构体，给出带点分路径的变更列表，用于配置更新的审计日志等场景
+package diff
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+// Kind 变更类型
+type Kind uint8
+
+const (
+	// Modify 值被修改
+	Modify Kind = iota
+	// Add 新增的 map key、切片元素或由 nil 变为非 nil 的值
+	Add
+	// Remove 删除的 map key、切片元素或由非 nil 变为 nil 的值
+	Remove
+)
+
+// String 返回 add、remove 或 modify
+//
+//	@receiver k Kind
+//	@return string
+//	@update 2026-10-18 07:46:33
+func (k Kind) String() string {
+	switch k {
+	case Add:
+		return "add"
+	case Remove:
+		return "remove"
+	default:
+		return "modify"
+	}
+}
+
+// Redacted 带 diff:"redact" tag 的字段在变更中的值
+const Redacted = "***"
+
+// Change 一处变更
+type Change struct {
+	Path string // 如 db.hosts[0]、labels.app、labels["a.b"]，与 reflecting.GetPath 的语法一致；顶层为空
+	Kind Kind
+	Old  any // Add 时为 nil
+	New  any // Remove 时为 nil
+}
+
+// String 返回 "path: kind old -> new" 形式的描述
+//
+//	@receiver c Change
+//	@return string
+//	@update 2026-10-18 07:46:33
+func (c Change) String() string {
+	path := c.Path
+	if path == "" {
+		path = "<root>"
+	}
+	switch c.Kind {
+	case Add:
+		return fmt.Sprintf("%s: add %v", path, c.New)
+	case Remove:
+		return fmt.Sprintf("%s: remove %v", path, c.Old)
+	default:
+		return fmt.Sprintf("%s: modify %v -> %v", path, c.Old, c.New)
+	}
+}
+
+type options struct {
+	tag    string
+	ignore []string
+}
+
+// Option Structs 的选项
+type Option func(*options)
+
+// WithNameTag 路径中的字段名取自该 tag，默认 json，未设置 tag 的字段使用 Go 字段名
+//
+//	@param tag string
+//	@return Option
+//	@update 2026-10-18 07:46:33
+func WithNameTag(tag string) Option {
+	return func(o *options) { o.tag = tag }
+}
+
+// IgnorePaths 忽略这些路径及其下的所有变更，路径写法同 Change.Path
+//
+//	@param paths ...string
+//	@return Option
+//	@update 2026-10-18 07:46:33
+func IgnorePaths(paths ...string) Option {
+	return func(o *options) { o.ignore = append(o.ignore, paths...) }
+}
+
+// Structs 比较 old 与 new，返回按遍历顺序排列的变更；结构体逐字段比较，map 按 key 排序后比较，切片按下标比较，
+// 长度不同时多出的元素记为 Add 或 Remove
+//
+//	带 diff:"-" tag 的字段被忽略，带 diff:"redact" tag 的字段只报告是否变更，值替换为 Redacted；
+//	time.Time 使用 Equal 比较；未导出字段与命名 tag 为 "-" 的字段不参与比较
+//
+//	@param old any
+//	@param new any
+//	@param opts ...Option
+//	@return []Change
+//	@update 2026-10-18 07:46:33
+//
+// for example:
+//
+//	changes := diff.Structs(oldCfg, newCfg)
+//	for _, c := range changes {
+//		slog.Info("config changed", "path", c.Path, "kind", c.Kind, "old", c.Old, "new", c.New)
+//	}
+//	// db.hosts[1]: add "10.0.0.2"
+//	// db.password: modify *** -> ***
+func Structs(old, new any, opts ...Option) []Change {
+	o := options{tag: "json"}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	d := &differ{o: o, visited: map[visit]bool{}}
+	d.diff("", reflect.ValueOf(old), reflect.ValueOf(new), false)
+	return d.changes
+}
+
+// visit 当前比较路径上的一对指针、map 或切片，用于识别循环引用
+type visit struct {
+	a, b uintptr
+	n    int // 切片长度，共享底层数组但长度不同的切片视为不同
+	t    reflect.Type
+}
+
+type differ struct {
+	o       options
+	visited map[visit]bool
+	changes []Change
+}
+
+func (d *differ) ignored(path string) bool {
+	for _, p := range d.o.ignore {
+		if path == p || strings.HasPrefix(path, p) && (path[len(p)] == '.' || path[len(p)] == '[') {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *differ) report(path string, kind Kind, a, b reflect.Value, redact bool) {
+	c := Change{Path: path, Kind: kind, Old: valueOf(a), New: valueOf(b)}
+	if redact {
+		if c.Old != nil {
+			c.Old = Redacted
+		}
+		if c.New != nil {
+			c.New = Redacted
+		}
+	}
+	d.changes = append(d.changes, c)
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+func (d *differ) diff(path string, a, b reflect.Value, redact bool) {
+	if d.ignored(path) {
+		return
+	}
+	a, b, keys, ok := d.enter(a, b)
+	if !ok {
+		return
+	}
+	defer d.leave(keys)
+	switch {
+	case !a.IsValid() && !b.IsValid():
+		return
+	case !a.IsValid():
+		d.report(path, Add, a, b, redact)
+		return
+	case !b.IsValid():
+		d.report(path, Remove, a, b, redact)
+		return
+	case a.Type() != b.Type():
+		d.report(path, Modify, a, b, redact)
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Map, reflect.Slice:
+		if a.IsNil() && b.IsNil() {
+			return
+		}
+		key := visit{a: a.Pointer(), b: b.Pointer(), n: a.Len(), t: a.Type()}
+		if d.visited[key] {
+			return
+		}
+		d.visited[key] = true
+		defer delete(d.visited, key)
+	}
+
+	switch {
+	case a.Type() == timeType:
+		if !a.Interface().(time.Time).Equal(b.Interface().(time.Time)) {
+			d.report(path, Modify, a, b, redact)
+		}
+	case a.Kind() == reflect.Struct:
+		d.diffStruct(path, a, b, redact)
+	case a.Kind() == reflect.Map:
+		d.diffMap(path, a, b, redact)
+	case a.Kind() == reflect.Slice || a.Kind() == reflect.Array:
+		n := min(a.Len(), b.Len())
+		for i := range n {
+			d.diff(indexPath(path, i), a.Index(i), b.Index(i), redact)
+		}
+		for i := n; i < a.Len(); i++ {
+			if p := indexPath(path, i); !d.ignored(p) {
+				d.report(p, Remove, a.Index(i), reflect.Value{}, redact)
+			}
+		}
+		for i := n; i < b.Len(); i++ {
+			if p := indexPath(path, i); !d.ignored(p) {
+				d.report(p, Add, reflect.Value{}, b.Index(i), redact)
+			}
+		}
+	default:
+		if !equal(a, b) {
+			d.report(path, Modify, a, b, redact)
+		}
+	}
+}
+
+func (d *differ) diffStruct(path string, a, b reflect.Value, redact bool) {
+	fields := reflecting.FieldsOfType(a.Type(), d.o.tag)
+	if len(fields) == 0 {
+		// 没有可比较字段的结构体（如只含未导出字段）整体比较
+		if !equal(a, b) {
+			d.report(path, Modify, a, b, redact)
+		}
+		return
+	}
+	for _, f := range fields {
+		tag := f.StructTag.Get("diff")
+		if tag == "-" {
+			continue
+		}
+		d.diff(fieldPath(path, f.Key()), f.Value(a), f.Value(b), redact || tag == "redact")
+	}
+}
+
+func (d *differ) diffMap(path string, a, b reflect.Value, redact bool) {
+	keys := a.MapKeys()
+	for _, k := range b.MapKeys() {
+		if !a.MapIndex(k).IsValid() {
+			keys = append(keys, k)
+		}
+	}
+	slices.SortFunc(keys, compareKeys)
+	for _, k := range keys {
+		p := keyPath(path, k)
+		av, bv := a.MapIndex(k), b.MapIndex(k)
+		switch {
+		case !bv.IsValid():
+			if !d.ignored(p) {
+				d.report(p, Remove, av, bv, redact)
+			}
+		case !av.IsValid():
+			if !d.ignored(p) {
+				d.report(p, Add, av, bv, redact)
+			}
+		default:
+			d.diff(p, av, bv, redact)
+		}
+	}
+}
+
+// compareKeys 按 key 的自然顺序排序，类型无法直接比较时按格式化后的字符串
+func compareKeys(a, b reflect.Value) int {
+	switch a.Kind() {
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	}
+	return cmp.Compare(fmt.Sprint(valueOf(a)), fmt.Sprint(valueOf(b)))
+}
+
+// enter 解开 a、b 的指针与接口（nil 时为无效值），并将沿途成对的非 nil 指针记为正在比较；
+// 某对指针已在当前比较路径上（循环引用）时返回 false，此时不记录任何指针
+func (d *differ) enter(a, b reflect.Value) (reflect.Value, reflect.Value, []visit, bool) {
+	var keys []visit
+	for isIndirect(a) || isIndirect(b) {
+		if isIndirect(a) && isIndirect(b) && a.Kind() == reflect.Pointer && b.Kind() == reflect.Pointer && !a.IsNil() && !b.IsNil() {
+			key := visit{a: a.Pointer(), b: b.Pointer(), t: a.Type()}
+			if d.visited[key] {
+				d.leave(keys)
+				return a, b, nil, false
+			}
+			d.visited[key] = true
+			keys = append(keys, key)
+		}
+		a, b = step(a), step(b)
+	}
+	return a, b, keys, true
+}
+
+// leave 比较完成后移除 enter 记录的指针
+func (d *differ) leave(keys []visit) {
+	for _, k := range keys {
+		delete(d.visited, k)
+	}
+}
+
+func isIndirect(v reflect.Value) bool {
+	return v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface)
+}
+
+// step 解开一层指针或接口，nil 时返回无效值，其他值原样返回
+func step(v reflect.Value) reflect.Value {
+	if !isIndirect(v) {
+		return v
+	}
+	if v.IsNil() {
+		return reflect.Value{}
+	}
+	return v.Elem()
+}
+
+func equal(a, b reflect.Value) bool {
+	if a.CanInterface() && b.CanInterface() {
+		return reflect.DeepEqual(a.Interface(), b.Interface())
+	}
+	eq, _ := reflecting.DeepEqualDiff(valueOf(a), valueOf(b))
+	return eq
+}
+
+func valueOf(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+	if v.CanInterface() {
+		return v.Interface()
+	}
+	return fmt.Sprint(v)
+}
+
+func fieldPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+func indexPath(parent string, i int) string {
+	return parent + "[" + strconv.Itoa(i) + "]"
+}
+
+// keyPath 简单的字符串 key 使用 .key，其余字符串使用 ["key"]，非字符串 key 使用 [key]，以便 reflecting.GetPath 解析
+func keyPath(parent string, k reflect.Value) string {
+	s := fmt.Sprint(valueOf(k))
+	if k.Kind() != reflect.String {
+		return parent + "[" + s + "]"
+	}
+	if s != "" && !strings.ContainsAny(s, `.[]"'`) {
+		return fieldPath(parent, s)
+	}
+	return parent + "[" + strconv.Quote(s) + "]"
+}
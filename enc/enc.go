@@ -0,0 +1,146 @@
+// Package enc base64 与 hex 编解码的简写，解码失败时返回带编码名称的 *DecodeError
+package enc
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrDecode 所有解码错误都满足 errors.Is(err, ErrDecode)
+var ErrDecode = errors.New("enc: invalid input")
+
+// DecodeError 解码失败
+type DecodeError struct {
+	Encoding string // base64、base64url 或 hex
+	Err      error  // 标准库返回的错误，如 base64.CorruptInputError
+}
+
+// Error 实现 error
+//
+//	@receiver e *DecodeError
+//	@return string
+//	@update 2026-10-18 04:02:37
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("enc: invalid %s input: %v", e.Encoding, e.Err)
+}
+
+// Unwrap 返回标准库的错误
+//
+//	@receiver e *DecodeError
+//	@return error
+//	@update 2026-10-18 04:02:37
+func (e *DecodeError) Unwrap() error { return e.Err }
+
+// Is 使 errors.Is(err, ErrDecode) 成立
+//
+//	@receiver e *DecodeError
+//	@param target error
+//	@return bool
+//	@update 2026-10-18 04:02:37
+func (e *DecodeError) Is(target error) bool { return target == ErrDecode }
+
+// B64 标准 base64 编码（带填充）
+//
+//	@param v T string 或 []byte
+//	@return string
+//	@update 2026-10-18 04:02:37
+func B64[T ~string | ~[]byte](v T) string {
+	return base64.StdEncoding.EncodeToString([]byte(v))
+}
+
+// B64Decode 解码标准 base64，同时接受省略填充的输入
+//
+//	@param s string
+//	@return string
+//	@return error
+//	@update 2026-10-18 04:02:37
+func B64Decode(s string) (string, error) {
+	b, err := B64DecodeBytes(s)
+	return string(b), err
+}
+
+// B64DecodeBytes 同 B64Decode，返回 []byte
+//
+//	@param s string
+//	@return []byte
+//	@return error
+//	@update 2026-10-18 04:02:37
+func B64DecodeBytes(s string) ([]byte, error) {
+	return decodeBase64(base64.RawStdEncoding, "base64", s)
+}
+
+// B64URL URL 安全的 base64 编码（- 与 _，不带填充），可直接用于 URL 与文件名
+//
+//	@param v T string 或 []byte
+//	@return string
+//	@update 2026-10-18 04:02:37
+func B64URL[T ~string | ~[]byte](v T) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(v))
+}
+
+// B64URLDecode 解码 URL 安全的 base64，带不带填充均可
+//
+//	@param s string
+//	@return string
+//	@return error
+//	@update 2026-10-18 04:02:37
+func B64URLDecode(s string) (string, error) {
+	b, err := B64URLDecodeBytes(s)
+	return string(b), err
+}
+
+// B64URLDecodeBytes 同 B64URLDecode，返回 []byte
+//
+//	@param s string
+//	@return []byte
+//	@return error
+//	@update 2026-10-18 04:02:37
+func B64URLDecodeBytes(s string) ([]byte, error) {
+	return decodeBase64(base64.RawURLEncoding, "base64url", s)
+}
+
+// decodeBase64 去掉末尾填充后以无填充的编码解码
+func decodeBase64(e *base64.Encoding, name, s string) ([]byte, error) {
+	b, err := e.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return nil, &DecodeError{Encoding: name, Err: err}
+	}
+	return b, nil
+}
+
+// HexEncode 小写 hex 编码
+//
+//	@param v T string 或 []byte
+//	@return string
+//	@update 2026-10-18 04:02:37
+func HexEncode[T ~string | ~[]byte](v T) string {
+	return hex.EncodeToString([]byte(v))
+}
+
+// HexDecode 解码 hex，大小写均可
+//
+//	@param s string
+//	@return string
+//	@return error
+//	@update 2026-10-18 04:02:37
+func HexDecode(s string) (string, error) {
+	b, err := HexDecodeBytes(s)
+	return string(b), err
+}
+
+// HexDecodeBytes 同 HexDecode，返回 []byte
+//
+//	@param s string
+//	@return []byte
+//	@return error
+//	@update 2026-10-18 04:02:37
+func HexDecodeBytes(s string) ([]byte, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, &DecodeError{Encoding: "hex", Err: err}
+	}
+	return b, nil
+}
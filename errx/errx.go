@@ -0,0 +1,246 @@
+// Package errx 携带调用位置与结构化字段的错误
+package errx
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+// Error 由 New / Wrap 创建的错误，记录创建处的调用位置与可选的键值字段
+type Error struct {
+	msg    string
+	cause  error
+	frame  reflecting.Frame
+	fields []any
+}
+
+// New 创建错误并记录调用位置
+//
+//	@param msg string
+//	@param fields ...any 键值对，如 "user_id", 1
+//	@return error
+//	@update 2026-10-17 21:51:19
+//
+// for example:
+//
+//	return errx.New("quota exceeded", "user_id", uid, "limit", limit)
+func New(msg string, fields ...any) error {
+	return newError(msg, nil, fields)
+}
+
+// Newf 按格式创建错误并记录调用位置
+//
+//	@param format string
+//	@param args ...any
+//	@return error
+//	@update 2026-10-17 21:51:19
+func Newf(format string, args ...any) error {
+	return newError(fmt.Sprintf(format, args...), nil, nil)
+}
+
+// Wrap 为 err 附加说明与调用位置，err 为 nil 时返回 nil；结果支持 errors.Is / errors.As
+//
+//	@param err error
+//	@param msg string
+//	@param fields ...any 键值对
+//	@return error
+//	@update 2026-10-17 21:51:19
+//
+// for example:
+//
+//	if err := db.Save(u); err != nil {
+//		return errx.Wrap(err, "save user", "user_id", u.ID)
+//	}
+func Wrap(err error, msg string, fields ...any) error {
+	if err == nil {
+		return nil
+	}
+	return newError(msg, err, fields)
+}
+
+// Wrapf 按格式为 err 附加说明与调用位置，err 为 nil 时返回 nil
+//
+//	@param err error
+//	@param format string
+//	@param args ...any
+//	@return error
+//	@update 2026-10-17 21:51:19
+func Wrapf(err error, format string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+	return newError(fmt.Sprintf(format, args...), err, nil)
+}
+
+// newError 记录 New / Wrap 调用方的位置
+func newError(msg string, cause error, fields []any) *Error {
+	// skip: newError 以及 New / Wrap 等导出函数
+	frame, _ := reflecting.GetCallerInfo(2)
+	return &Error{msg: msg, cause: cause, frame: frame, fields: fields}
+}
+
+// Error 返回 "msg: cause" 形式的描述，与 fmt.Errorf("%s: %w") 一致
+//
+//	@receiver e *Error
+//	@return string
+//	@update 2026-10-17 21:51:19
+func (e *Error) Error() string {
+	if e.cause == nil {
+		return e.msg
+	}
+	if e.msg == "" {
+		return e.cause.Error()
+	}
+	return e.msg + ": " + e.cause.Error()
+}
+
+// Unwrap 返回被包装的错误
+//
+//	@receiver e *Error
+//	@return error
+//	@update 2026-10-17 21:51:19
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Message 返回本层的说明，不含被包装的错误
+//
+//	@receiver e *Error
+//	@return string
+//	@update 2026-10-17 21:51:19
+func (e *Error) Message() string {
+	return e.msg
+}
+
+// Caller 返回创建错误时的调用位置
+//
+//	@receiver e *Error
+//	@return reflecting.Frame
+//	@update 2026-10-17 21:51:19
+func (e *Error) Caller() reflecting.Frame {
+	return e.frame
+}
+
+// Fields 返回本层的键值字段
+//
+//	@receiver e *Error
+//	@return []any
+//	@update 2026-10-17 21:51:19
+func (e *Error) Fields() []any {
+	return e.fields
+}
+
+// Format 支持 %s、%v、%q 与 %+v；%+v 逐层输出因果链，每层附带调用位置与字段
+//
+//	@receiver e *Error
+//	@param s fmt.State
+//	@param verb rune
+//	@update 2026-10-17 21:51:19
+//
+// for example:
+//
+//	save user [service.(*UserService).Create user.go:42] user_id=1
+//	  caused by: insert row [store.(*DB).Insert db.go:88]
+//	  caused by: connection refused
+func (e *Error) Format(s fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && s.Flag('+'):
+		io.WriteString(s, Chain(e))
+	case verb == 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	default:
+		io.WriteString(s, e.Error())
+	}
+}
+
+// Chain 将 err 的因果链格式化为多行文本，errx 创建的层附带调用位置与字段
+//
+//	@param err error
+//	@return string
+//	@update 2026-10-17 21:51:19
+func Chain(err error) string {
+	var sb strings.Builder
+	for i := 0; err != nil; i++ {
+		if i > 0 {
+			sb.WriteString("\n  caused by: ")
+		}
+		e, ok := err.(*Error)
+		if !ok {
+			// 其他方式包装的错误，描述中已包含内层的内容
+			sb.WriteString(err.Error())
+			break
+		}
+		sb.WriteString(e.msg)
+		if e.frame.Function != "" {
+			fmt.Fprintf(&sb, " [%s %s:%d]", e.frame.ShortFunction(), filepath.Base(e.frame.File), e.frame.Line)
+		}
+		writeFields(&sb, e.fields)
+		err = e.cause
+	}
+	return sb.String()
+}
+
+// writeFields 以 k=v 形式输出字段，落单的值以 !BADKEY 作为键
+func writeFields(sb *strings.Builder, fields []any) {
+	for i := 0; i < len(fields); i += 2 {
+		key, val := fields[i], any("!MISSING")
+		if i+1 < len(fields) {
+			val = fields[i+1]
+		}
+		if _, ok := key.(string); !ok {
+			key, val = "!BADKEY", fields[i]
+			i--
+		}
+		fmt.Fprintf(sb, " %v=%v", key, val)
+	}
+}
+
+// FieldsOf 按从外到内的顺序收集 err 链上所有 errx 层的字段，同名字段保留最外层的值
+//
+//	@param err error
+//	@return map[string]any
+//	@update 2026-10-17 21:51:19
+func FieldsOf(err error) map[string]any {
+	res := map[string]any{}
+	for err != nil {
+		if e, ok := err.(*Error); ok {
+			for i := 0; i+1 < len(e.fields); i += 2 {
+				key, ok := e.fields[i].(string)
+				if !ok {
+					i--
+					continue
+				}
+				if _, exists := res[key]; !exists {
+					res[key] = e.fields[i+1]
+				}
+			}
+		}
+		err = errors.Unwrap(err)
+	}
+	return res
+}
+
+// CallerOf 返回 err 链上最内层（最先创建）的 errx 错误的调用位置
+//
+//	@param err error
+//	@return reflecting.Frame
+//	@return bool 链上没有 errx 创建的错误时返回 false
+//	@update 2026-10-17 21:51:19
+func CallerOf(err error) (reflecting.Frame, bool) {
+	var (
+		frame reflecting.Frame
+		found bool
+	)
+	for err != nil {
+		if e, ok := err.(*Error); ok {
+			frame, found = e.frame, true
+		}
+		err = errors.Unwrap(err)
+	}
+	return frame, found
+}
@@ -0,0 +1,260 @@
+package errx
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// MultiError 多个错误的集合，支持 errors.Is / errors.As 匹配其中任一错误
+type MultiError struct {
+	errs []error
+}
+
+// MessageCount 相同描述的错误及其出现次数
+type MessageCount struct {
+	Message string `json:"message"`
+	Count   int    `json:"count"`
+}
+
+// Collect 收集 errs 中非 nil 的错误，全部为 nil 时返回 nil，否则返回 *MultiError
+//
+//	@param errs ...error
+//	@return error
+//	@update 2026-10-17 22:10:37
+//
+// for example:
+//
+//	err := errx.Collect(closeA(), closeB(), closeC())
+func Collect(errs ...error) error {
+	var m MultiError
+	for _, err := range errs {
+		if err != nil {
+			m.errs = append(m.errs, err)
+		}
+	}
+	if len(m.errs) == 0 {
+		return nil
+	}
+	return &m
+}
+
+// Errors 返回收集到的全部错误
+//
+//	@receiver m *MultiError
+//	@return []error
+//	@update 2026-10-17 22:10:37
+func (m *MultiError) Errors() []error {
+	return m.errs
+}
+
+// Unwrap 返回全部错误，供 errors.Is / errors.As 使用
+//
+//	@receiver m *MultiError
+//	@return []error
+//	@update 2026-10-17 22:10:37
+func (m *MultiError) Unwrap() []error {
+	return m.errs
+}
+
+// Unique 按描述去重，保留每种描述首次出现的错误
+//
+//	@receiver m *MultiError
+//	@return []error
+//	@update 2026-10-17 22:10:37
+func (m *MultiError) Unique() []error {
+	seen := map[string]bool{}
+	var res []error
+	for _, err := range m.errs {
+		if msg := err.Error(); !seen[msg] {
+			seen[msg] = true
+			res = append(res, err)
+		}
+	}
+	return res
+}
+
+// Summary 按描述统计出现次数，按次数降序排列，次数相同时保持首次出现的顺序
+//
+//	@receiver m *MultiError
+//	@return []MessageCount
+//	@update 2026-10-17 22:10:37
+func (m *MultiError) Summary() []MessageCount {
+	index := map[string]int{}
+	var res []MessageCount
+	for _, err := range m.errs {
+		msg := err.Error()
+		if i, ok := index[msg]; ok {
+			res[i].Count++
+			continue
+		}
+		index[msg] = len(res)
+		res = append(res, MessageCount{Message: msg, Count: 1})
+	}
+	slices.SortStableFunc(res, func(a, b MessageCount) int {
+		return cmp.Compare(b.Count, a.Count)
+	})
+	return res
+}
+
+// Error 返回去重后的紧凑描述，如 "3 errors: timeout (x2); not found"
+//
+//	@receiver m *MultiError
+//	@return string
+//	@update 2026-10-17 22:10:37
+func (m *MultiError) Error() string {
+	if len(m.errs) == 1 {
+		return m.errs[0].Error()
+	}
+	parts := make([]string, 0, len(m.errs))
+	for _, s := range m.Summary() {
+		if s.Count > 1 {
+			parts = append(parts, fmt.Sprintf("%s (x%d)", s.Message, s.Count))
+		} else {
+			parts = append(parts, s.Message)
+		}
+	}
+	return fmt.Sprintf("%d errors: %s", len(m.errs), strings.Join(parts, "; "))
+}
+
+// Format 支持 %s、%v、%q 与 %+v；%+v 逐个输出每个错误的因果链
+//
+//	@receiver m *MultiError
+//	@param s fmt.State
+//	@param verb rune
+//	@update 2026-10-17 22:10:37
+func (m *MultiError) Format(s fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && s.Flag('+'):
+		fmt.Fprintf(s, "%d errors:", len(m.errs))
+		for i, err := range m.errs {
+			fmt.Fprintf(s, "\n[%d] %s", i, strings.ReplaceAll(Chain(err), "\n", "\n    "))
+		}
+	case verb == 'q':
+		fmt.Fprintf(s, "%q", m.Error())
+	default:
+		io.WriteString(s, m.Error())
+	}
+}
+
+// MarshalJSON 以 Tree 的结构序列化
+//
+//	@receiver m *MultiError
+//	@return []byte
+//	@return error
+//	@update 2026-10-17 22:10:37
+func (m *MultiError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Tree(m))
+}
+
+// Group 并发安全的错误收集器，零值可用
+type Group struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+// Add 记录错误，nil 会被忽略
+//
+//	@receiver g *Group
+//	@param err error
+//	@update 2026-10-17 22:10:37
+func (g *Group) Add(err error) {
+	if err == nil {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.errs = append(g.errs, err)
+}
+
+// Len 返回已记录的错误数
+//
+//	@receiver g *Group
+//	@return int
+//	@update 2026-10-17 22:10:37
+func (g *Group) Len() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.errs)
+}
+
+// Err 没有错误时返回 nil，否则返回包含当前全部错误的 *MultiError
+//
+//	@receiver g *Group
+//	@return error
+//	@update 2026-10-17 22:10:37
+//
+// for example:
+//
+//	var errs errx.Group
+//	for _, item := range items {
+//		errs.Add(process(item))
+//	}
+//	return errs.Err()
+func (g *Group) Err() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(g.errs) == 0 {
+		return nil
+	}
+	return &MultiError{errs: slices.Clone(g.errs)}
+}
+
+// Node 错误树中的一个节点，用于 JSON 输出
+type Node struct {
+	Message string         `json:"message"`
+	Caller  string         `json:"caller,omitempty"` // errx 创建的层的调用位置，如 "pkg.Func file.go:12"
+	Fields  map[string]any `json:"fields,omitempty"`
+	Causes  []*Node        `json:"causes,omitempty"`
+}
+
+// Tree 将 err 转为错误树：errx 的层展开为带调用位置与字段的节点，
+// 多错误（MultiError、errors.Join 等）的每个错误作为子节点，其他错误作为叶子节点
+//
+//	对外的 API 响应不希望暴露调用位置时，可清空各节点的 Caller
+//
+//	@param err error
+//	@return *Node
+//	@update 2026-10-17 22:10:37
+func Tree(err error) *Node {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case *Error:
+		n := &Node{Message: e.msg}
+		if e.frame.Function != "" {
+			n.Caller = fmt.Sprintf("%s %s:%d", e.frame.ShortFunction(), filepath.Base(e.frame.File), e.frame.Line)
+		}
+		if fields := FieldsOf(&Error{fields: e.fields}); len(fields) > 0 {
+			n.Fields = fields
+		}
+		if e.cause != nil {
+			n.Causes = []*Node{Tree(e.cause)}
+		}
+		return n
+	case interface{ Unwrap() []error }:
+		errs := e.Unwrap()
+		n := &Node{Message: fmt.Sprintf("%d errors", len(errs))}
+		for _, child := range errs {
+			n.Causes = append(n.Causes, Tree(child))
+		}
+		return n
+	default:
+		return &Node{Message: err.Error()}
+	}
+}
+
+// MarshalJSON 以 Tree 的结构序列化
+//
+//	@receiver e *Error
+//	@return []byte
+//	@return error
+//	@update 2026-10-17 22:10:37
+func (e *Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Tree(e))
+}
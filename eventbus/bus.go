@@ -0,0 +1,281 @@
+// Package eventbus 进程内按 topic 发布订阅的类型化事件总线
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+// ErrClosed 总线已关闭
+var ErrClosed = errors.New("eventbus: closed")
+
+// ErrUnbufferedDropOldest DropOldest 策略的缓冲区为 0，没有可丢弃的旧事件
+var ErrUnbufferedDropOldest = errors.New("eventbus: DropOldest requires WithBuffer > 0")
+
+// Bus 事件类型为 T 的事件总线；每个订阅者拥有独立的缓冲区与处理 goroutine，
+// 同一订阅者按发布顺序处理事件，不同订阅者之间互不影响
+type Bus[T any] struct {
+	opts   options
+	mu     sync.RWMutex
+	topics map[string]map[*Subscription[T]]struct{}
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// Subscription 一个订阅，用于取消订阅与查看丢弃的事件数
+type Subscription[T any] struct {
+	bus     *Bus[T]
+	topic   string
+	fn      func(T)
+	opts    options
+	ch      chan T
+	quit    chan struct{} // 取消订阅或关闭总线时关闭，唤醒阻塞中的 Publish
+	quitOne sync.Once
+	mu      sync.RWMutex
+	closed  bool
+	dropped atomic.Int64
+}
+
+// New 创建事件总线
+//
+//	@param opts ...Option 所有订阅的默认选项
+//	@return *Bus[T]
+//	@update 2026-10-17 20:46:30
+//
+// for example:
+//
+//	bus := eventbus.New[UserEvent](eventbus.WithPolicy(eventbus.DropOldest))
+//	sub, _ := bus.Subscribe("user.created", sendWelcomeMail)
+//	defer sub.Unsubscribe()
+//	_ = bus.Publish(ctx, "user.created", UserEvent{ID: id})
+func New[T any](opts ...Option) *Bus[T] {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Bus[T]{opts: o, topics: map[string]map[*Subscription[T]]struct{}{}}
+}
+
+// Subscribe 订阅 topic，事件在该订阅独立的 goroutine 中交给 fn 处理
+//
+//	@receiver b *Bus[T]
+//	@param topic string
+//	@param fn func(T)
+//	@param opts ...Option 覆盖 New 中的默认选项
+//	@return *Subscription[T]
+//	@return error 总线已关闭时返回 ErrClosed，DropOldest 策略且缓冲区为 0 时返回 ErrUnbufferedDropOldest
+//	@update 2026-10-18 11:17:48
+func (b *Bus[T]) Subscribe(topic string, fn func(T), opts ...Option) (*Subscription[T], error) {
+	o := b.opts
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.policy == DropOldest && o.buffer <= 0 {
+		return nil, ErrUnbufferedDropOldest
+	}
+	s := &Subscription[T]{
+		bus:   b,
+		topic: topic,
+		fn:    fn,
+		opts:  o,
+		ch:    make(chan T, max(o.buffer, 0)),
+		quit:  make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil, ErrClosed
+	}
+	subs, ok := b.topics[topic]
+	if !ok {
+		subs = map[*Subscription[T]]struct{}{}
+		b.topics[topic] = subs
+	}
+	subs[s] = struct{}{}
+	b.wg.Add(1)
+	go s.run()
+	return s, nil
+}
+
+// Publish 向 topic 的所有订阅者发布事件；订阅者缓冲区已满时按其 Policy 处理，
+// Block 策略下阻塞直到有空位或 ctx 结束
+//
+//	@receiver b *Bus[T]
+//	@param ctx context.Context
+//	@param topic string
+//	@param event T
+//	@return error 总线已关闭（包括阻塞期间被 Close）时返回 ErrClosed，ctx 结束时返回 ctx.Err()
+//	@update 2026-10-18 11:17:48
+func (b *Bus[T]) Publish(ctx context.Context, topic string, event T) error {
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return ErrClosed
+	}
+	subs := make([]*Subscription[T], 0, len(b.topics[topic]))
+	for s := range b.topics[topic] {
+		subs = append(subs, s)
+	}
+	b.mu.RUnlock()
+
+	for _, s := range subs {
+		if err := s.send(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Len 返回 topic 的订阅者数量
+//
+//	@receiver b *Bus[T]
+//	@param topic string
+//	@return int
+//	@update 2026-10-17 20:46:30
+func (b *Bus[T]) Len(topic string) int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.topics[topic])
+}
+
+// Close 关闭总线：不再接受发布与订阅，等待各订阅者处理完缓冲区中的事件，或直到 ctx 结束
+//
+//	阻塞在 Block 策略上的 Publish 被唤醒并返回 ErrClosed，其事件不再投递
+//
+//	@receiver b *Bus[T]
+//	@param ctx context.Context
+//	@return error ctx 先结束时返回 ctx.Err()，未处理完的事件仍会在后台继续处理
+//	@update 2026-10-18 11:17:48
+func (b *Bus[T]) Close(ctx context.Context) error {
+	b.mu.Lock()
+	var subs []*Subscription[T]
+	if !b.closed {
+		b.closed = true
+		for _, topicSubs := range b.topics {
+			for s := range topicSubs {
+				subs = append(subs, s)
+			}
+		}
+		clear(b.topics)
+	}
+	b.mu.Unlock()
+	for _, s := range subs {
+		s.close()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *Bus[T]) isClosed() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.closed
+}
+
+// Unsubscribe 取消订阅，缓冲区中已有的事件仍会被处理；可重复调用
+//
+//	@receiver s *Subscription[T]
+//	@update 2026-10-17 20:46:30
+func (s *Subscription[T]) Unsubscribe() {
+	s.bus.mu.Lock()
+	if subs, ok := s.bus.topics[s.topic]; ok {
+		delete(subs, s)
+		if len(subs) == 0 {
+			delete(s.bus.topics, s.topic)
+		}
+	}
+	s.bus.mu.Unlock()
+	s.close()
+}
+
+// Dropped 返回因缓冲区已满被丢弃的事件数
+//
+//	@receiver s *Subscription[T]
+//	@return int64
+//	@update 2026-10-17 20:46:30
+func (s *Subscription[T]) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// send 按订阅的 Policy 投递事件；订阅已取消时直接忽略
+func (s *Subscription[T]) send(ctx context.Context, event T) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return nil
+	}
+	switch s.opts.policy {
+	case DropNewest:
+		select {
+		case s.ch <- event:
+		default:
+			s.dropped.Add(1)
+		}
+	case DropOldest:
+		for {
+			select {
+			case s.ch <- event:
+				return nil
+			default:
+			}
+			select {
+			case <-s.ch:
+				s.dropped.Add(1)
+			default:
+			}
+		}
+	default:
+		select {
+		case s.ch <- event:
+		case <-s.quit:
+			if s.bus.isClosed() {
+				return ErrClosed
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// close 停止接收新事件，先唤醒阻塞在该订阅上的 Publish，使其释放 s.mu 的读锁
+func (s *Subscription[T]) close() {
+	s.quitOne.Do(func() { close(s.quit) })
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.ch)
+	}
+}
+
+func (s *Subscription[T]) run() {
+	defer s.bus.wg.Done()
+	for event := range s.ch {
+		s.handle(event)
+	}
+}
+
+func (s *Subscription[T]) handle(event T) {
+	defer func() {
+		if r := recover(); r != nil && s.opts.onError != nil {
+			s.opts.onError(s.topic, reflecting.NewPanicError(r))
+		}
+	}()
+	s.fn(event)
+}
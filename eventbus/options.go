@@ -0,0 +1,53 @@
+package eventbus
+
+// Policy 订阅者缓冲区已满时的处理策略
+type Policy int
+
+const (
+	// Block 阻塞 Publish 直到缓冲区有空位或 ctx 结束
+	Block Policy = iota
+	// DropNewest 丢弃正在发布的事件
+	DropNewest
+	// DropOldest 丢弃缓冲区中最早的事件，为新事件腾出位置
+	DropOldest
+)
+
+type options struct {
+	buffer  int
+	policy  Policy
+	onError func(topic string, err error)
+}
+
+func defaultOptions() options {
+	return options{buffer: 64, policy: Block}
+}
+
+// Option New 与 Subscribe 的选项；传给 New 时作为所有订阅的默认值，传给 Subscribe 时只作用于该订阅
+type Option func(*options)
+
+// WithBuffer 订阅者的缓冲区大小，默认 64；DropOldest 策略要求大于 0
+//
+//	@param n int
+//	@return Option
+//	@update 2026-10-18 11:17:48
+func WithBuffer(n int) Option {
+	return func(o *options) { o.buffer = n }
+}
+
+// WithPolicy 缓冲区已满时的策略，默认 Block
+//
+//	@param p Policy
+//	@return Option
+//	@update 2026-10-17 20:46:30
+func WithPolicy(p Policy) Option {
+	return func(o *options) { o.policy = p }
+}
+
+// OnError 订阅者的处理函数 panic 时调用 fn，err 为 *reflecting.PanicError；默认忽略
+//
+//	@param fn func(topic string, err error)
+//	@return Option
+//	@update 2026-10-17 20:46:30
+func OnError(fn func(topic string, err error)) Option {
+	return func(o *options) { o.onError = fn }
+}
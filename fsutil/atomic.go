@@ -0,0 +1,89 @@
+// Package fsutil 文件系统辅助函数：原子写入、复制文件与目录、存在性检查等
+package fsutil
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// AtomicWriteFile 原子地将 data 写入 path：先写入同目录下的临时文件并 fsync，再 rename 覆盖目标，
+// 读者要么看到旧内容要么看到完整的新内容，进程崩溃也不会留下写了一半的文件
+//
+//	@param path string
+//	@param data []byte
+//	@param perm fs.FileMode
+//	@return error
+//	@update 2026-10-18 03:05:27
+func AtomicWriteFile(path string, data []byte, perm fs.FileMode) error {
+	return AtomicWriteFunc(path, perm, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// AtomicWriteFunc 同 AtomicWriteFile，内容由 write 流式写入；write 返回错误时不会修改目标文件
+//
+//	@param path string
+//	@param perm fs.FileMode
+//	@param write func(w io.Writer) error
+//	@return err error
+//	@update 2026-10-18 03:05:27
+//
+// for example:
+//
+//	err := fsutil.AtomicWriteFunc("out.json", 0o644, func(w io.Writer) error {
+//		return json.NewEncoder(w).Encode(v)
+//	})
+func AtomicWriteFunc(path string, perm fs.FileMode, write func(w io.Writer) error) (err error) {
+	dir, name := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+	tmp, err := os.CreateTemp(dir, "."+name+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	bw := bufio.NewWriter(tmp)
+	if err = write(bw); err != nil {
+		return err
+	}
+	if err = bw.Flush(); err != nil {
+		return err
+	}
+	// CreateTemp 固定使用 0600，按 perm 修正
+	if err = tmp.Chmod(perm); err != nil {
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("fsutil: rename temp file: %w", err)
+	}
+	syncDir(dir)
+	return nil
+}
+
+// syncDir 尽力 fsync 目录以持久化 rename，部分平台不支持对目录 fsync，忽略错误
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	_ = d.Sync()
+	_ = d.Close()
+}
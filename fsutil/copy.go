@@ -0,0 +1,100 @@
+package fsutil
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// CopyFile 复制普通文件 src 到 dst 并保留权限位，dst 已存在时被覆盖；写入是原子的
+//
+//	@param src string
+//	@param dst string
+//	@return error
+//	@update 2026-10-18 03:05:27
+func CopyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("fsutil: %s is not a regular file", src)
+	}
+	return AtomicWriteFunc(dst, info.Mode().Perm(), func(w io.Writer) error {
+		_, err := io.Copy(w, in)
+		return err
+	})
+}
+
+// CopyDir 递归复制目录 src 到 dst，保留文件与目录的权限位，符号链接按原样重建（不跟随）
+//
+//	dst 不存在时创建，已存在时合并写入；遇到设备文件、命名管道等特殊文件时报错
+//
+//	@param src string
+//	@param dst string
+//	@return error
+//	@update 2026-10-18 03:05:27
+func CopyDir(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("fsutil: %s is not a directory", src)
+	}
+	type dirPerm struct {
+		path string
+		perm fs.FileMode
+	}
+	var dirs []dirPerm
+	err = filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch mode := info.Mode(); {
+		case mode.IsDir():
+			// 复制期间保证目录可写，权限在全部复制完成后再设置
+			dirs = append(dirs, dirPerm{target, mode.Perm()})
+			return os.MkdirAll(target, mode.Perm()|0o700)
+		case mode.IsRegular():
+			return CopyFile(path, target)
+		case mode&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			return os.Symlink(link, target)
+		default:
+			return fmt.Errorf("fsutil: unsupported file type %s: %s", mode.Type(), path)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	// 自内向外设置，MkdirAll 受 umask 影响，且不会修改已存在目录的权限
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if err := os.Chmod(dirs[i].path, dirs[i].perm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package fsutil
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// Exists 判断 path 是否存在（不跟随最后一级的符号链接）
+//
+//	@param path string
+//	@return bool
+//	@update 2026-10-18 03:05:27
+func Exists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+// IsDir 判断 path 是否为目录
+//
+//	@param path string
+//	@return bool
+//	@update 2026-10-18 03:05:27
+func IsDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// IsFile 判断 path 是否为普通文件
+//
+//	@param path string
+//	@return bool
+//	@update 2026-10-18 03:05:27
+func IsFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// EnsureDir 确保目录 path 存在，必要时以 0755 逐级创建；path 已存在但不是目录时报错
+//
+//	@param path string
+//	@return error
+//	@update 2026-10-18 03:05:27
+func EnsureDir(path string) error {
+	return os.MkdirAll(path, 0o755)
+}
+
+// Touch 文件不存在时以 0644 创建空文件，存在时将访问与修改时间更新为当前时间
+//
+//	@param path string
+//	@return error
+//	@update 2026-10-18 03:05:27
+func Touch(path string) error {
+	now := time.Now()
+	err := os.Chtimes(path, now, now)
+	if err == nil || !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
@@ -1,3 +1,15 @@
 module github.com/BetaGoRobot/go_utils
 
 go 1.24.2
+
+require (
+	github.com/fsnotify/fsnotify v1.10.1
+	golang.org/x/mod v0.31.0
+	golang.org/x/tools v0.40.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	golang.org/x/sync v0.19.0 // indirect
+	golang.org/x/sys v0.40.0 // indirect
+)
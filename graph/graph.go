@@ -0,0 +1,375 @@
+// Package graph 有向图工具：邻接表构建、确定性的拓扑排序、环检测与可达性查询，用于依赖有序的启动与迁移排序
+package graph
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrCycle 图中存在环，TopoSort 返回的 *CycleError 满足 errors.Is(err, ErrCycle)
+var ErrCycle = errors.New("graph: cycle detected")
+
+// CycleError 图中存在环，Cycle 为环上的节点，首尾相同，如 [a b c a] 表示 a → b → c → a
+type CycleError[N comparable] struct {
+	Cycle []N
+}
+
+func (e *CycleError[N]) Error() string {
+	parts := make([]string, len(e.Cycle))
+	for i, n := range e.Cycle {
+		parts[i] = fmt.Sprint(n)
+	}
+	return "graph: cycle detected: " + strings.Join(parts, " -> ")
+}
+
+// Is 使 errors.Is(err, ErrCycle) 成立
+func (e *CycleError[N]) Is(target error) bool { return target == ErrCycle }
+
+// Graph 有向图，节点按首次加入的顺序编号，该顺序用于排序与遍历中的平局裁决；零值可用，非并发安全
+type Graph[N comparable] struct {
+	index map[N]int
+	nodes []N
+	succ  [][]int
+	pred  [][]int
+	edges map[[2]int]struct{}
+}
+
+// New 创建空的有向图
+//
+//	@return *Graph[N]
+//	@update 2026-10-18 07:30:26
+//
+// for example:
+//
+//	g := graph.New[string]()
+//	g.DependsOn("api", "db", "cache")
+//	g.DependsOn("cache", "db")
+//	order, err := g.TopoSort() // [db cache api]
+func New[N comparable]() *Graph[N] {
+	return &Graph[N]{}
+}
+
+// AddNode 加入节点，已存在时不做修改
+//
+//	@receiver g *Graph[N]
+//	@param n N
+//	@update 2026-10-18 07:30:26
+func (g *Graph[N]) AddNode(n N) {
+	g.id(n)
+}
+
+// id 返回节点编号，不存在时加入
+func (g *Graph[N]) id(n N) int {
+	if i, ok := g.index[n]; ok {
+		return i
+	}
+	if g.index == nil {
+		g.index = map[N]int{}
+		g.edges = map[[2]int]struct{}{}
+	}
+	i := len(g.nodes)
+	g.index[n] = i
+	g.nodes = append(g.nodes, n)
+	g.succ = append(g.succ, nil)
+	g.pred = append(g.pred, nil)
+	return i
+}
+
+// AddEdge 加入边 from → to，即拓扑序中 from 在 to 之前；节点不存在时自动加入，重复的边被忽略
+//
+//	@receiver g *Graph[N]
+//	@param from N
+//	@param to N
+//	@update 2026-10-18 07:30:26
+func (g *Graph[N]) AddEdge(from, to N) {
+	f, t := g.id(from), g.id(to)
+	if _, ok := g.edges[[2]int{f, t}]; ok {
+		return
+	}
+	g.edges[[2]int{f, t}] = struct{}{}
+	g.succ[f] = append(g.succ[f], t)
+	g.pred[t] = append(g.pred[t], f)
+}
+
+// DependsOn 声明 n 依赖 deps，即为每个 dep 加入边 dep → n
+//
+//	@receiver g *Graph[N]
+//	@param n N
+//	@param deps ...N
+//	@update 2026-10-18 07:30:26
+func (g *Graph[N]) DependsOn(n N, deps ...N) {
+	g.AddNode(n)
+	for _, d := range deps {
+		g.AddEdge(d, n)
+	}
+}
+
+// Len 返回节点数
+//
+//	@receiver g *Graph[N]
+//	@return int
+//	@update 2026-10-18 07:30:26
+func (g *Graph[N]) Len() int {
+	return len(g.nodes)
+}
+
+// Nodes 按加入顺序返回所有节点
+//
+//	@receiver g *Graph[N]
+//	@return []N
+//	@update 2026-10-18 07:30:26
+func (g *Graph[N]) Nodes() []N {
+	return append([]N(nil), g.nodes...)
+}
+
+// Successors 按加边顺序返回 n 的直接后继
+//
+//	@receiver g *Graph[N]
+//	@param n N
+//	@return []N
+//	@update 2026-10-18 07:30:26
+func (g *Graph[N]) Successors(n N) []N {
+	i, ok := g.index[n]
+	if !ok {
+		return nil
+	}
+	return g.toNodes(g.succ[i])
+}
+
+// Predecessors 按加边顺序返回 n 的直接前驱（依赖）
+//
+//	@receiver g *Graph[N]
+//	@param n N
+//	@return []N
+//	@update 2026-10-18 07:30:26
+func (g *Graph[N]) Predecessors(n N) []N {
+	i, ok := g.index[n]
+	if !ok {
+		return nil
+	}
+	return g.toNodes(g.pred[i])
+}
+
+func (g *Graph[N]) toNodes(ids []int) []N {
+	res := make([]N, len(ids))
+	for i, id := range ids {
+		res[i] = g.nodes[id]
+	}
+	return res
+}
+
+// TopoSort 返回拓扑序，对每条边 from → to，from 都在 to 之前；同时可用的节点按加入顺序排列，结果对相同的构建过程是确定的
+//
+//	存在环时返回 *CycleError
+//
+//	@receiver g *Graph[N]
+//	@return []N
+//	@return error
+//	@update 2026-10-18 07:30:26
+func (g *Graph[N]) TopoSort() ([]N, error) {
+	indeg := g.inDegrees()
+	ready := &intHeap{}
+	for i, d := range indeg {
+		if d == 0 {
+			*ready = append(*ready, i)
+		}
+	}
+	heap.Init(ready)
+	res := make([]N, 0, len(g.nodes))
+	for ready.Len() > 0 {
+		i := heap.Pop(ready).(int)
+		res = append(res, g.nodes[i])
+		for _, s := range g.succ[i] {
+			if indeg[s]--; indeg[s] == 0 {
+				heap.Push(ready, s)
+			}
+		}
+	}
+	if len(res) < len(g.nodes) {
+		cycle, _ := g.FindCycle()
+		return nil, &CycleError[N]{Cycle: cycle}
+	}
+	return res, nil
+}
+
+// TopoLayers 将节点按依赖深度分层：每层的节点只依赖之前各层的节点，同层节点可以并行处理；层内按加入顺序排列
+//
+//	存在环时返回 *CycleError
+//
+//	@receiver g *Graph[N]
+//	@return [][]N
+//	@return error
+//	@update 2026-10-18 07:30:26
+func (g *Graph[N]) TopoLayers() ([][]N, error) {
+	indeg := g.inDegrees()
+	var layer []int
+	for i, d := range indeg {
+		if d == 0 {
+			layer = append(layer, i)
+		}
+	}
+	var (
+		res  [][]N
+		seen int
+	)
+	for len(layer) > 0 {
+		res = append(res, g.toNodes(layer))
+		seen += len(layer)
+		var next []int
+		for _, i := range layer {
+			for _, s := range g.succ[i] {
+				if indeg[s]--; indeg[s] == 0 {
+					next = append(next, s)
+				}
+			}
+		}
+		slices.Sort(next)
+		layer = next
+	}
+	if seen < len(g.nodes) {
+		cycle, _ := g.FindCycle()
+		return nil, &CycleError[N]{Cycle: cycle}
+	}
+	return res, nil
+}
+
+func (g *Graph[N]) inDegrees() []int {
+	indeg := make([]int, len(g.nodes))
+	for i := range g.nodes {
+		indeg[i] = len(g.pred[i])
+	}
+	return indeg
+}
+
+// FindCycle 返回图中的一个环（首尾相同的节点路径），没有环时 ok 为 false；按加入顺序搜索，结果是确定的
+//
+//	@receiver g *Graph[N]
+//	@return []N
+//	@return bool
+//	@update 2026-10-18 07:30:26
+func (g *Graph[N]) FindCycle() ([]N, bool) {
+	const (
+		white = iota
+		gray
+		black
+	)
+	color := make([]uint8, len(g.nodes))
+	type frame struct{ node, next int }
+	for root := range g.nodes {
+		if color[root] != white {
+			continue
+		}
+		stack := []frame{{node: root}}
+		color[root] = gray
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if top.next == len(g.succ[top.node]) {
+				color[top.node] = black
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			s := g.succ[top.node][top.next]
+			top.next++
+			switch color[s] {
+			case white:
+				color[s] = gray
+				stack = append(stack, frame{node: s})
+			case gray:
+				// s 在当前路径上：从 s 到栈顶即为环
+				var cycle []N
+				for j := len(stack) - 1; j >= 0; j-- {
+					if stack[j].node == s {
+						for _, f := range stack[j:] {
+							cycle = append(cycle, g.nodes[f.node])
+						}
+						break
+					}
+				}
+				return append(cycle, g.nodes[s]), true
+			}
+		}
+	}
+	return nil, false
+}
+
+// Reachable 按广度优先顺序返回从 from 出发可到达的节点，不含 from 自身（除非 from 在环上）
+//
+//	@receiver g *Graph[N]
+//	@param from N
+//	@return []N
+//	@update 2026-10-18 07:30:26
+func (g *Graph[N]) Reachable(from N) []N {
+	start, ok := g.index[from]
+	if !ok {
+		return nil
+	}
+	seen := make([]bool, len(g.nodes))
+	queue := append([]int(nil), g.succ[start]...)
+	for _, s := range queue {
+		seen[s] = true
+	}
+	var res []int
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		res = append(res, i)
+		for _, s := range g.succ[i] {
+			if !seen[s] {
+				seen[s] = true
+				queue = append(queue, s)
+			}
+		}
+	}
+	return g.toNodes(res)
+}
+
+// HasPath 判断是否存在从 from 到 to 的长度至少为 1 的路径
+//
+//	@receiver g *Graph[N]
+//	@param from N
+//	@param to N
+//	@return bool
+//	@update 2026-10-18 07:30:26
+func (g *Graph[N]) HasPath(from, to N) bool {
+	target, ok := g.index[to]
+	if !ok {
+		return false
+	}
+	start, ok := g.index[from]
+	if !ok {
+		return false
+	}
+	seen := make([]bool, len(g.nodes))
+	stack := []int{start}
+	for len(stack) > 0 {
+		i := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, s := range g.succ[i] {
+			if s == target {
+				return true
+			}
+			if !seen[s] {
+				seen[s] = true
+				stack = append(stack, s)
+			}
+		}
+	}
+	return false
+}
+
+// intHeap 按节点编号（加入顺序）出队的最小堆
+type intHeap []int
+
+func (h intHeap) Len() int           { return len(h) }
+func (h intHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h intHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *intHeap) Push(x any)        { *h = append(*h, x.(int)) }
+func (h *intHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,76 @@
+// Package hashring 客户端分片用的一致性哈希：带虚拟节点的哈希环与 rendezvous（最高随机权重）哈希
+package hashring
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// Picker 按 key 选择成员的策略，Ring 与 Rendezvous 均实现该接口
+type Picker[T comparable] interface {
+	// Add 加入成员，已存在的成员会被忽略
+	Add(members ...T)
+	// Remove 移除成员
+	Remove(members ...T)
+	// Get 返回 key 对应的成员，没有成员时返回零值
+	Get(key string) T
+	// GetN 返回 key 对应的至多 n 个不同成员，用于多副本；第一个与 Get 相同
+	GetN(key string, n int) []T
+	// Members 返回当前全部成员
+	Members() []T
+}
+
+type options struct {
+	replicas int
+	hash     func(data []byte) uint64
+}
+
+// Option New 与 NewRendezvous 的选项
+type Option func(*options)
+
+// WithReplicas 每个成员在环上的虚拟节点数，越多分布越均匀，默认 160；只作用于 Ring
+//
+//	@param n int
+//	@return Option
+//	@update 2026-10-17 23:40:26
+func WithReplicas(n int) Option {
+	return func(o *options) { o.replicas = n }
+}
+
+// WithHash 哈希函数，默认 FNV-1a 64 位；分片的所有客户端须使用相同的哈希函数
+//
+//	@param fn func(data []byte) uint64
+//	@return Option
+//	@update 2026-10-17 23:40:26
+func WithHash(fn func(data []byte) uint64) Option {
+	return func(o *options) { o.hash = fn }
+}
+
+func newOptions(opts []Option) options {
+	o := options{replicas: 160, hash: fnv64a}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+func fnv64a(data []byte) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write(data)
+	return h.Sum64()
+}
+
+// mix splitmix64 的终结函数，使相近输入的哈希值充分打散
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// memberName 成员参与哈希的名字；不同成员的 fmt.Sprint 结果须互不相同且在各进程间稳定
+func memberName[T any](m T) string {
+	return fmt.Sprint(m)
+}
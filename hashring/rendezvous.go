@@ -0,0 +1,130 @@
+package hashring
+
+import (
+	"slices"
+	"sync"
+)
+
+// Rendezvous 最高随机权重哈希：对每个 key 计算各成员的得分并取最高者；
+// 无需虚拟节点即可均匀分布，移除成员只影响原本属于它的 key，代价是 Get 为 O(成员数)。并发安全
+type Rendezvous[T comparable] struct {
+	opts options
+
+	mu      sync.RWMutex
+	members []T
+	hashes  []uint64 // 与 members 一一对应的成员哈希
+}
+
+// NewRendezvous 创建 rendezvous 哈希
+//
+//	@param opts ...Option
+//	@return *Rendezvous[T]
+//	@update 2026-10-17 23:40:26
+func NewRendezvous[T comparable](opts ...Option) *Rendezvous[T] {
+	return &Rendezvous[T]{opts: newOptions(opts)}
+}
+
+// Add 加入成员，已存在的成员会被忽略
+//
+//	@receiver r *Rendezvous[T]
+//	@param members ...T
+//	@update 2026-10-17 23:40:26
+func (r *Rendezvous[T]) Add(members ...T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, m := range members {
+		if slices.Contains(r.members, m) {
+			continue
+		}
+		r.members = append(r.members, m)
+		r.hashes = append(r.hashes, r.opts.hash([]byte(memberName(m))))
+	}
+}
+
+// Remove 移除成员
+//
+//	@receiver r *Rendezvous[T]
+//	@param members ...T
+//	@update 2026-10-17 23:40:26
+func (r *Rendezvous[T]) Remove(members ...T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, m := range members {
+		if i := slices.Index(r.members, m); i >= 0 {
+			r.members = slices.Delete(r.members, i, i+1)
+			r.hashes = slices.Delete(r.hashes, i, i+1)
+		}
+	}
+}
+
+// Get 返回 key 得分最高的成员，没有成员时返回零值
+//
+//	@receiver r *Rendezvous[T]
+//	@param key string
+//	@return T
+//	@update 2026-10-17 23:40:26
+func (r *Rendezvous[T]) Get(key string) T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var (
+		best      T
+		bestScore uint64
+	)
+	kh := r.opts.hash([]byte(key))
+	for i, m := range r.members {
+		if s := mix(kh ^ r.hashes[i]); i == 0 || s > bestScore {
+			best, bestScore = m, s
+		}
+	}
+	return best
+}
+
+// GetN 返回 key 得分最高的至多 n 个成员，按得分降序排列
+//
+//	@receiver r *Rendezvous[T]
+//	@param key string
+//	@param n int
+//	@return []T
+//	@update 2026-10-17 23:40:26
+func (r *Rendezvous[T]) GetN(key string, n int) []T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	n = min(n, len(r.members))
+	if n <= 0 {
+		return nil
+	}
+	type scored struct {
+		member T
+		score  uint64
+	}
+	kh := r.opts.hash([]byte(key))
+	all := make([]scored, len(r.members))
+	for i, m := range r.members {
+		all[i] = scored{m, mix(kh ^ r.hashes[i])}
+	}
+	slices.SortFunc(all, func(a, b scored) int {
+		switch {
+		case a.score > b.score:
+			return -1
+		case a.score < b.score:
+			return 1
+		}
+		return 0
+	})
+	res := make([]T, n)
+	for i := range res {
+		res[i] = all[i].member
+	}
+	return res
+}
+
+// Members 返回当前全部成员，按加入顺序排列
+//
+//	@receiver r *Rendezvous[T]
+//	@return []T
+//	@update 2026-10-17 23:40:26
+func (r *Rendezvous[T]) Members() []T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.Clone(r.members)
+}
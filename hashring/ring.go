@@ -0,0 +1,153 @@
+package hashring
+
+import (
+	"slices"
+	"strconv"
+	"sync"
+)
+
+// Ring 带虚拟节点的一致性哈希环，增删成员时只有约 1/n 的 key 会改变归属；并发安全
+//
+//	成员以 fmt.Sprint 的结果参与哈希，不同成员的结果须互不相同且在各进程间稳定（如地址字符串）
+type Ring[T comparable] struct {
+	opts options
+
+	mu      sync.RWMutex
+	points  []point[T] // 按 hash 升序
+	members []T
+}
+
+type point[T any] struct {
+	hash   uint64
+	member T
+}
+
+// New 创建哈希环
+//
+//	@param opts ...Option
+//	@return *Ring[T]
+//	@update 2026-10-17 23:40:26
+//
+// for example:
+//
+//	ring := hashring.New[string]()
+//	ring.Add("cache-0:6379", "cache-1:6379", "cache-2:6379")
+//	addr := ring.Get("user:42")
+func New[T comparable](opts ...Option) *Ring[T] {
+	return &Ring[T]{opts: newOptions(opts)}
+}
+
+// Add 加入成员，已存在的成员会被忽略
+//
+//	@receiver r *Ring[T]
+//	@param members ...T
+//	@update 2026-10-17 23:40:26
+func (r *Ring[T]) Add(members ...T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, m := range members {
+		if slices.Contains(r.members, m) {
+			continue
+		}
+		r.members = append(r.members, m)
+		name := memberName(m)
+		for i := range r.opts.replicas {
+			h := mix(r.opts.hash([]byte(name + "#" + strconv.Itoa(i))))
+			r.points = append(r.points, point[T]{hash: h, member: m})
+		}
+	}
+	slices.SortFunc(r.points, func(a, b point[T]) int {
+		switch {
+		case a.hash < b.hash:
+			return -1
+		case a.hash > b.hash:
+			return 1
+		}
+		return 0
+	})
+}
+
+// Remove 移除成员
+//
+//	@receiver r *Ring[T]
+//	@param members ...T
+//	@update 2026-10-17 23:40:26
+func (r *Ring[T]) Remove(members ...T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, m := range members {
+		r.members = slices.DeleteFunc(r.members, func(x T) bool { return x == m })
+	}
+	r.points = slices.DeleteFunc(r.points, func(p point[T]) bool {
+		return !slices.Contains(r.members, p.member)
+	})
+}
+
+// Get 返回 key 在环上顺时针方向遇到的第一个成员，没有成员时返回零值
+//
+//	@receiver r *Ring[T]
+//	@param key string
+//	@return T
+//	@update 2026-10-17 23:40:26
+func (r *Ring[T]) Get(key string) T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.points) == 0 {
+		var zero T
+		return zero
+	}
+	return r.points[r.search(key)].member
+}
+
+// GetN 从 key 的位置顺时针返回至多 n 个不同成员
+//
+//	@receiver r *Ring[T]
+//	@param key string
+//	@param n int
+//	@return []T
+//	@update 2026-10-17 23:40:26
+func (r *Ring[T]) GetN(key string, n int) []T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	n = min(n, len(r.members))
+	if n <= 0 {
+		return nil
+	}
+	res := make([]T, 0, n)
+	for i, start := 0, r.search(key); len(res) < n && i < len(r.points); i++ {
+		m := r.points[(start+i)%len(r.points)].member
+		if !slices.Contains(res, m) {
+			res = append(res, m)
+		}
+	}
+	return res
+}
+
+// Members 返回当前全部成员，按加入顺序排列
+//
+//	@receiver r *Ring[T]
+//	@return []T
+//	@update 2026-10-17 23:40:26
+func (r *Ring[T]) Members() []T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.Clone(r.members)
+}
+
+// search 返回 key 对应的虚拟节点下标；调用方需持有读锁且环非空
+func (r *Ring[T]) search(key string) int {
+	h := mix(r.opts.hash([]byte(key)))
+	i, _ := slices.BinarySearchFunc(r.points, h, func(p point[T], h uint64) int {
+		switch {
+		case p.hash < h:
+			return -1
+		case p.hash > h:
+			return 1
+		}
+		return 0
+	})
+	if i == len(r.points) {
+		i = 0
+	}
+	return i
+}
@@ -0,0 +1,212 @@
+package hashutil
+
+import (
+	"encoding"
+	"encoding/binary"
+	"math"
+	"reflect"
+	"slices"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+// 各类值在编码中的类型标记，避免不同类型的值编码相同
+const (
+	tagNil byte = iota
+	tagBool
+	tagInt
+	tagUint
+	tagFloat
+	tagComplex
+	tagString
+	tagBytes
+	tagList
+	tagMap
+	tagStruct
+	tagMarshaled
+	tagCycle
+	tagOpaque
+)
+
+var (
+	binaryMarshalerType = reflect.TypeFor[encoding.BinaryMarshaler]()
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// HashAny 计算任意值的稳定哈希，可用于由请求结构体生成确定的缓存 key
+//
+//	规则：
+//	- map 的结果与遍历顺序无关；指针按指向的值计算，循环引用只计算一次
+//	- 结构体按导出字段计算，`hash:"-"` 的字段被排除，字段名参与计算（tag 可重命名）
+//	- 实现了 encoding.BinaryMarshaler 或 encoding.TextMarshaler 的结构体（如 time.Time）按序列化结果计算
+//	- 有符号整数、无符号整数、浮点数分别按 64 位计算，int32(1) 与 int64(1) 的哈希相同
+//	- func、chan 等无法比较内容的值只计入类型种类
+//
+//	@param v any
+//	@return uint64
+//	@update 2026-10-17 23:58:14
+//
+// for example:
+//
+//	type Query struct {
+//		UserID  int64
+//		Filters map[string]string
+//		TraceID string `hash:"-"`
+//	}
+//	key := hashutil.HashAny(q)
+func HashAny(v any) uint64 {
+	e := encoder{seen: map[uintptr]bool{}}
+	e.encode(reflect.ValueOf(v))
+	return Sum64(e.buf)
+}
+
+// encoder 将值编码为规范的字节序列
+type encoder struct {
+	buf  []byte
+	seen map[uintptr]bool // 当前路径上的指针，用于检测循环引用
+}
+
+func (e *encoder) tag(t byte) {
+	e.buf = append(e.buf, t)
+}
+
+func (e *encoder) uint(u uint64) {
+	e.buf = binary.LittleEndian.AppendUint64(e.buf, u)
+}
+
+func (e *encoder) bytes(b []byte) {
+	e.uint(uint64(len(b)))
+	e.buf = append(e.buf, b...)
+}
+
+func (e *encoder) float(f float64) {
+	switch {
+	case f == 0:
+		f = 0 // 统一 -0 与 +0
+	case math.IsNaN(f):
+		f = math.NaN()
+	}
+	e.uint(math.Float64bits(f))
+}
+
+func (e *encoder) encode(v reflect.Value) {
+	if !v.IsValid() {
+		e.tag(tagNil)
+		return
+	}
+	if v.Kind() == reflect.Struct && e.marshaled(v) {
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		e.tag(tagBool)
+		if v.Bool() {
+			e.uint(1)
+		} else {
+			e.uint(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		e.tag(tagInt)
+		e.uint(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		e.tag(tagUint)
+		e.uint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		e.tag(tagFloat)
+		e.float(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		e.tag(tagComplex)
+		e.float(real(v.Complex()))
+		e.float(imag(v.Complex()))
+	case reflect.String:
+		e.tag(tagString)
+		e.bytes([]byte(v.String()))
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			e.tag(tagBytes)
+			e.bytes(v.Bytes())
+			return
+		}
+		e.tag(tagList)
+		e.uint(uint64(v.Len()))
+		for i := range v.Len() {
+			e.encode(v.Index(i))
+		}
+	case reflect.Map:
+		e.encodeMap(v)
+	case reflect.Struct:
+		e.encodeStruct(v)
+	case reflect.Pointer:
+		if v.IsNil() {
+			e.tag(tagNil)
+			return
+		}
+		ptr := v.Pointer()
+		if e.seen[ptr] {
+			e.tag(tagCycle)
+			return
+		}
+		e.seen[ptr] = true
+		e.encode(v.Elem())
+		delete(e.seen, ptr)
+	case reflect.Interface:
+		e.encode(v.Elem())
+	default:
+		e.tag(tagOpaque)
+		e.uint(uint64(v.Kind()))
+	}
+}
+
+// encodeMap 每个键值对单独哈希后排序写入，结果与遍历顺序无关
+func (e *encoder) encodeMap(v reflect.Value) {
+	e.tag(tagMap)
+	e.uint(uint64(v.Len()))
+	hashes := make([]uint64, 0, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		sub := encoder{seen: e.seen}
+		sub.encode(iter.Key())
+		sub.encode(iter.Value())
+		hashes = append(hashes, Sum64(sub.buf))
+	}
+	slices.Sort(hashes)
+	for _, h := range hashes {
+		e.uint(h)
+	}
+}
+
+func (e *encoder) encodeStruct(v reflect.Value) {
+	e.tag(tagStruct)
+	fields := reflecting.FieldsOfType(v.Type(), "hash")
+	e.uint(uint64(len(fields)))
+	for _, f := range fields {
+		e.bytes([]byte(f.Key()))
+		e.encode(f.Value(v))
+	}
+}
+
+// marshaled 结构体实现了 BinaryMarshaler 或 TextMarshaler 时按序列化结果编码
+func (e *encoder) marshaled(v reflect.Value) bool {
+	if !v.CanInterface() {
+		return false
+	}
+	var (
+		data []byte
+		err  error
+	)
+	switch {
+	case v.Type().Implements(binaryMarshalerType):
+		data, err = v.Interface().(encoding.BinaryMarshaler).MarshalBinary()
+	case v.Type().Implements(textMarshalerType):
+		data, err = v.Interface().(encoding.TextMarshaler).MarshalText()
+	default:
+		return false
+	}
+	if err != nil {
+		return false
+	}
+	e.tag(tagMarshaled)
+	e.bytes(data)
+	return true
+}
@@ -0,0 +1,88 @@
+// Package hashutil 快速字符串/字节哈希（xxHash64）与任意值的稳定哈希
+package hashutil
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// 声明为变量：prime1+prime2 等运算需要按 uint64 回绕，常量表达式会溢出报错
+var (
+	prime1 uint64 = 11400714785074694791
+	prime2 uint64 = 14029467366897019727
+	prime3 uint64 = 1609587929392839161
+	prime4 uint64 = 9650029242287828579
+	prime5 uint64 = 2870177450012600261
+)
+
+// Sum64 计算 b 的 xxHash64（seed 为 0），结果与 xxhash 的标准实现一致，跨进程、跨平台稳定
+//
+//	@param b []byte
+//	@return uint64
+//	@update 2026-10-17 23:58:14
+func Sum64(b []byte) uint64 {
+	n := len(b)
+	var h uint64
+	if n >= 32 {
+		v1 := prime1 + prime2
+		v2 := prime2
+		v3 := uint64(0)
+		v4 := -prime1
+		for len(b) >= 32 {
+			v1 = round(v1, binary.LittleEndian.Uint64(b[0:8]))
+			v2 = round(v2, binary.LittleEndian.Uint64(b[8:16]))
+			v3 = round(v3, binary.LittleEndian.Uint64(b[16:24]))
+			v4 = round(v4, binary.LittleEndian.Uint64(b[24:32]))
+			b = b[32:]
+		}
+		h = bits.RotateLeft64(v1, 1) + bits.RotateLeft64(v2, 7) + bits.RotateLeft64(v3, 12) + bits.RotateLeft64(v4, 18)
+		h = mergeRound(h, v1)
+		h = mergeRound(h, v2)
+		h = mergeRound(h, v3)
+		h = mergeRound(h, v4)
+	} else {
+		h = prime5
+	}
+	h += uint64(n)
+
+	for ; len(b) >= 8; b = b[8:] {
+		h ^= round(0, binary.LittleEndian.Uint64(b))
+		h = bits.RotateLeft64(h, 27)*prime1 + prime4
+	}
+	if len(b) >= 4 {
+		h ^= uint64(binary.LittleEndian.Uint32(b)) * prime1
+		h = bits.RotateLeft64(h, 23)*prime2 + prime3
+		b = b[4:]
+	}
+	for _, c := range b {
+		h ^= uint64(c) * prime5
+		h = bits.RotateLeft64(h, 11) * prime1
+	}
+
+	h ^= h >> 33
+	h *= prime2
+	h ^= h >> 29
+	h *= prime3
+	h ^= h >> 32
+	return h
+}
+
+// String 计算字符串的 xxHash64
+//
+//	@param s string
+//	@return uint64
+//	@update 2026-10-17 23:58:14
+func String(s string) uint64 {
+	return Sum64([]byte(s))
+}
+
+func round(acc, input uint64) uint64 {
+	acc += input * prime2
+	acc = bits.RotateLeft64(acc, 31)
+	return acc * prime1
+}
+
+func mergeRound(acc, val uint64) uint64 {
+	acc ^= round(0, val)
+	return acc*prime1 + prime4
+}
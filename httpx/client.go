@@ -0,0 +1,194 @@
+// Package httpx 对 net/http 的封装：默认超时、幂等请求的重试、JSON 编解码，以及记录指标、日志与 span 的 Hook
+package httpx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/BetaGoRobot/go_utils/retry"
+	"github.com/BetaGoRobot/go_utils/trace"
+)
+
+// errRetryableStatus 响应状态码可重试，仅在 Do 内部使用
+var errRetryableStatus = errors.New("httpx: retryable status")
+
+// Client 并发安全的 HTTP 客户端
+type Client struct {
+	o options
+}
+
+// New 创建 Client
+//
+//	@param opts ...Option
+//	@return *Client
+//	@update 2026-10-18 05:20:11
+//
+// for example:
+//
+//	c := httpx.New(
+//		httpx.WithTimeout(5*time.Second),
+//		httpx.WithHeader("User-Agent", "betago/1.0"),
+//		httpx.WithRetry(retry.Attempts(4), retry.Jitter(0.2)),
+//	)
+//	user, err := httpx.DoJSON[User](ctx, c, http.MethodGet, "https://api.example.com/users/42", nil)
+func New(opts ...Option) *Client {
+	return &Client{o: newOptions(opts)}
+}
+
+// Do 发送 req，幂等请求在网络错误或 429、502、503、504 时按 WithRetry 的策略重试；
+// 重试用尽后返回最后一次的响应（可重试状态码）或错误，非 2xx 响应不视为错误
+//
+//	每次尝试在请求 ctx 没有截止时间时使用 WithTimeout 的超时，超时在响应体关闭时释放，调用方必须关闭响应体
+//
+//	@receiver c *Client
+//	@param req *http.Request
+//	@return *http.Response
+//	@return error
+//	@update 2026-10-18 05:20:11
+func (c *Client) Do(req *http.Request) (resp *http.Response, err error) {
+	ctx, end := trace.SpanErr(req.Context(), &err, "HTTP "+req.Method+" "+req.URL.Host)
+	defer end()
+
+	for k, vs := range c.o.header {
+		if _, ok := req.Header[k]; !ok {
+			req.Header[k] = vs
+		}
+	}
+	if !c.canRetry(req) {
+		return c.attempt(ctx, req, 1)
+	}
+
+	var (
+		attempt int
+		last    *http.Response
+	)
+	resp, err = retry.DoValue(ctx, func(ctx context.Context) (*http.Response, error) {
+		if last != nil {
+			drain(last.Body)
+			last = nil
+		}
+		attempt++
+		r := req
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r = req.Clone(req.Context())
+			r.Body = body
+		}
+		resp, err := c.attempt(ctx, r, attempt)
+		if err != nil {
+			return nil, err
+		}
+		if retryableStatus(resp.StatusCode) {
+			last = resp
+			return nil, errRetryableStatus
+		}
+		return resp, nil
+	}, append([]retry.Option{retry.RetryIf(retryable(ctx))}, c.o.retry...)...)
+	if errors.Is(err, errRetryableStatus) && last != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			drain(last.Body)
+			return nil, ctxErr
+		}
+		return last, nil
+	}
+	return resp, err
+}
+
+// attempt 发送一次请求并调用 Hook
+func (c *Client) attempt(ctx context.Context, req *http.Request, n int) (*http.Response, error) {
+	cancel := context.CancelFunc(func() {})
+	if _, ok := ctx.Deadline(); !ok && c.o.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.o.timeout)
+	}
+	begin := time.Now()
+	resp, err := c.o.client.Do(req.WithContext(ctx))
+	rec := Record{
+		Method:   req.Method,
+		URL:      req.URL.Redacted(),
+		Host:     req.URL.Host,
+		Attempt:  n,
+		Duration: time.Since(begin),
+		Err:      err,
+	}
+	if resp != nil {
+		rec.Status = resp.StatusCode
+	}
+	for _, hook := range c.o.hooks {
+		hook(ctx, rec)
+	}
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// canRetry 请求方法幂等（或 WithRetryAllMethods）且请求体可以重放
+func (c *Client) canRetry(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if c.o.retryAll {
+		return true
+	}
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryable 请求自身的 ctx 结束时不再重试，单次尝试超时仍可重试
+func retryable(ctx context.Context) func(error) bool {
+	return func(error) bool { return ctx.Err() == nil }
+}
+
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// drain 读完并关闭响应体，以便复用连接
+func drain(body io.ReadCloser) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(body, 4<<10))
+	_ = body.Close()
+}
+
+// cancelBody 关闭响应体时释放单次尝试的超时
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// Get 发送 GET 请求
+//
+//	@receiver c *Client
+//	@param ctx context.Context
+//	@param url string
+//	@return *http.Response
+//	@return error
+//	@update 2026-10-18 05:20:11
+func (c *Client) Get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("httpx: %w", err)
+	}
+	return c.Do(req)
+}
@@ -0,0 +1,89 @@
+package httpx
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/BetaGoRobot/go_utils/metrics"
+)
+
+const (
+	// RequestsName MetricsHook 使用的计数器名称
+	RequestsName = "http_client_requests_total"
+	// DurationName MetricsHook 使用的直方图名称
+	DurationName = "http_client_request_duration_seconds"
+)
+
+// Record 一次请求尝试的信息
+type Record struct {
+	Method   string
+	URL      string
+	Host     string
+	Attempt  int
+	Status   int // 未收到响应时为 0
+	Duration time.Duration
+	Err      error
+}
+
+// Hook 每次请求尝试结束时调用，需要并发安全
+type Hook func(ctx context.Context, r Record)
+
+// MetricsHook 将请求记录到 reg 的 http_client_requests_total{method,host,status} 计数器
+// 与 http_client_request_duration_seconds{method,host} 直方图；未收到响应时 status 为 error
+//
+//	@param reg *metrics.Registry
+//	@return Hook
+//	@update 2026-10-18 05:20:11
+func MetricsHook(reg *metrics.Registry) Hook {
+	requests := reg.Counter(RequestsName, "Number of HTTP client requests.", "method", "host", "status")
+	duration := reg.Histogram(DurationName, "HTTP client request duration in seconds.", nil, "method", "host")
+	return func(_ context.Context, r Record) {
+		status := "error"
+		if r.Status != 0 {
+			status = strconv.Itoa(r.Status)
+		}
+		requests.With(r.Method, r.Host, status).Inc()
+		duration.With(r.Method, r.Host).Observe(r.Duration.Seconds())
+	}
+}
+
+// LogHook 将请求记录到 logger，成功的请求为 Debug 级别，出错或 5xx 为 Warn 级别
+//
+//	@param logger *slog.Logger
+//	@return Hook
+//	@update 2026-10-18 05:20:11
+func LogHook(logger *slog.Logger) Hook {
+	return func(ctx context.Context, r Record) {
+		level := slog.LevelDebug
+		if r.Err != nil || r.Status >= http.StatusInternalServerError {
+			level = slog.LevelWarn
+		}
+		if !logger.Enabled(ctx, level) {
+			return
+		}
+		attrs := []slog.Attr{
+			slog.String("method", r.Method),
+			slog.String("url", r.URL),
+			slog.Int("attempt", r.Attempt),
+			slog.Duration("duration", r.Duration),
+		}
+		if r.Status != 0 {
+			attrs = append(attrs, slog.Int("status", r.Status))
+		}
+		if r.Err != nil {
+			attrs = append(attrs, slog.Any("err", r.Err))
+		}
+		logger.LogAttrs(ctx, level, "http request", attrs...)
+	}
+}
+
+// defaultHooks 默认记录到 metrics.Default 与 slog.Default()，logger 在调用时取以便跟随 slog.SetDefault
+func defaultHooks() []Hook {
+	return []Hook{
+		MetricsHook(metrics.Default),
+		func(ctx context.Context, r Record) { LogHook(slog.Default())(ctx, r) },
+	}
+}
@@ -0,0 +1,85 @@
+package httpx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// StatusError 响应状态码不是 2xx
+type StatusError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Body       []byte // 截断到 WithMaxErrorBody
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("httpx: %s %s: status %d %s", e.Method, e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// DoJSON 发送 JSON 请求并将 2xx 响应体解码为 T；in 为 nil 时不发送请求体
+//
+//	非 2xx 响应返回 *StatusError；响应为 204 或响应体为空时返回零值
+//
+//	@param ctx context.Context
+//	@param c *Client
+//	@param method string
+//	@param url string
+//	@param in any
+//	@return T
+//	@return error
+//	@update 2026-10-18 05:20:11
+//
+// for example:
+//
+//	created, err := httpx.DoJSON[User](ctx, c, http.MethodPost, base+"/users", NewUser{Name: "bob"})
+//	var se *httpx.StatusError
+//	if errors.As(err, &se) && se.StatusCode == http.StatusConflict {
+//		...
+//	}
+func DoJSON[T any](ctx context.Context, c *Client, method, url string, in any) (T, error) {
+	var zero T
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return zero, fmt.Errorf("httpx: encode request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return zero, fmt.Errorf("httpx: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return zero, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, c.o.maxErrorBody))
+		return zero, &StatusError{Method: method, URL: req.URL.Redacted(), StatusCode: resp.StatusCode, Body: data}
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return zero, fmt.Errorf("httpx: read response: %w", err)
+	}
+	var out T
+	if len(bytes.TrimSpace(data)) == 0 {
+		return out, nil
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return zero, fmt.Errorf("httpx: decode response: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,106 @@
+package httpx
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/BetaGoRobot/go_utils/retry"
+)
+
+const (
+	defaultTimeout      = 30 * time.Second
+	defaultMaxErrorBody = 64 << 10
+)
+
+type options struct {
+	client       *http.Client
+	timeout      time.Duration
+	header       http.Header
+	retry        []retry.Option
+	retryAll     bool
+	hooks        []Hook
+	maxErrorBody int64
+}
+
+// Option New 的选项
+type Option func(*options)
+
+// WithHTTPClient 使用 c 发送请求，默认 http.DefaultClient；c.Timeout 对每次尝试同样生效
+//
+//	@param c *http.Client
+//	@return Option
+//	@update 2026-10-18 05:20:11
+func WithHTTPClient(c *http.Client) Option {
+	return func(o *options) { o.client = c }
+}
+
+// WithTimeout 请求 ctx 没有截止时间时每次尝试的超时，<= 0 表示不设置；默认 30s
+//
+//	@param d time.Duration
+//	@return Option
+//	@update 2026-10-18 05:20:11
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) { o.timeout = d }
+}
+
+// WithHeader 为每个请求设置默认请求头，请求中已有的同名头不会被覆盖；可多次设置
+//
+//	@param key string
+//	@param value string
+//	@return Option
+//	@update 2026-10-18 05:20:11
+func WithHeader(key, value string) Option {
+	return func(o *options) { o.header.Add(key, value) }
+}
+
+// WithRetry 设置重试选项，默认 retry.Do 的默认值（3 次、100ms 起的指数退避）；
+// 只有幂等方法（GET、HEAD、OPTIONS、TRACE、PUT、DELETE）在网络错误或 429、502、503、504 时重试，
+// 请求体无法重放（Request.GetBody 为 nil）时不重试
+//
+//	@param opts ...retry.Option 传入 retry.Attempts(1) 可关闭重试
+//	@return Option
+//	@update 2026-10-18 05:20:11
+func WithRetry(opts ...retry.Option) Option {
+	return func(o *options) { o.retry = append(o.retry, opts...) }
+}
+
+// WithRetryAllMethods 非幂等方法（POST、PATCH）同样重试，仅在服务端能够去重时使用
+//
+//	@return Option
+//	@update 2026-10-18 05:20:11
+func WithRetryAllMethods() Option {
+	return func(o *options) { o.retryAll = true }
+}
+
+// WithHooks 替换每次尝试结束时调用的 Hook，默认 MetricsHook(metrics.Default) 与 LogHook(slog.Default())；
+// 不传参数表示不调用任何 Hook
+//
+//	@param hooks ...Hook
+//	@return Option
+//	@update 2026-10-18 05:20:11
+func WithHooks(hooks ...Hook) Option {
+	return func(o *options) { o.hooks = hooks }
+}
+
+// WithMaxErrorBody 非 2xx 响应时 StatusError.Body 保留的最大字节数，默认 64KB
+//
+//	@param n int64
+//	@return Option
+//	@update 2026-10-18 05:20:11
+func WithMaxErrorBody(n int64) Option {
+	return func(o *options) { o.maxErrorBody = max(n, 0) }
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		client:       http.DefaultClient,
+		timeout:      defaultTimeout,
+		header:       http.Header{},
+		hooks:        defaultHooks(),
+		maxErrorBody: defaultMaxErrorBody,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,41 @@
+// Package id 不依赖第三方库的 ID 生成：UUIDv4/v7、ULID 与雪花 ID
+//
+//	UUIDv7、ULID 与雪花 ID 在同一进程内严格递增，字典序（雪花 ID 为数值）与生成顺序一致
+package id
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+// ErrInvalidFormat 解析的字符串格式不正确
+var ErrInvalidFormat = errors.New("id: invalid format")
+
+// randomBytes 以密码学安全的随机数填充 b；crypto/rand 在支持的平台上不会失败
+func randomBytes(b []byte) {
+	_, _ = rand.Read(b)
+}
+
+func randomUint16() uint16 {
+	var b [2]byte
+	randomBytes(b[:])
+	return binary.BigEndian.Uint16(b[:])
+}
+
+// putMillis 将毫秒时间戳写入 b 的前 6 字节
+func putMillis(b []byte, ms uint64) {
+	b[0], b[1], b[2] = byte(ms>>40), byte(ms>>32), byte(ms>>24)
+	b[3], b[4], b[5] = byte(ms>>16), byte(ms>>8), byte(ms)
+}
+
+// millis 读取 b 前 6 字节的毫秒时间戳
+func millis(b []byte) uint64 {
+	return uint64(b[0])<<40 | uint64(b[1])<<32 | uint64(b[2])<<24 |
+		uint64(b[3])<<16 | uint64(b[4])<<8 | uint64(b[5])
+}
+
+func nowMillis() uint64 {
+	return uint64(time.Now().UnixMilli())
+}
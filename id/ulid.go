@@ -0,0 +1,143 @@
+package id
+
+import (
+	"encoding/binary"
+	"sync"
+	"time"
+)
+
+// ULID 48 位毫秒时间戳加 80 位随机数，以 26 位 Crockford Base32 表示，字典序即时间顺序
+type ULID [16]byte
+
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// crockfordDec Crockford Base32 的解码表，兼容小写以及 I、L→1、O→0；无效字符为 0xff
+var crockfordDec = func() [256]byte {
+	var t [256]byte
+	for i := range t {
+		t[i] = 0xff
+	}
+	for i := range len(crockford) {
+		c := crockford[i]
+		t[c] = byte(i)
+		if c >= 'A' && c <= 'Z' {
+			t[c+'a'-'A'] = byte(i)
+		}
+	}
+	t['I'], t['i'], t['L'], t['l'] = 1, 1, 1, 1
+	t['O'], t['o'] = 0, 0
+	return t
+}()
+
+// ulidState 单调递增状态：同一毫秒内随机部分加一
+var ulidState struct {
+	mu   sync.Mutex
+	last ULID
+}
+
+// NewULID 生成 ULID，同一进程内严格递增
+//
+//	同一毫秒内在上一个 ULID 的随机部分上加一，随机部分溢出或系统时钟回拨时借用下一毫秒
+//
+//	@return ULID
+//	@update 2026-10-17 23:21:09
+func NewULID() ULID {
+	ulidState.mu.Lock()
+	defer ulidState.mu.Unlock()
+
+	last := &ulidState.last
+	ms := nowMillis()
+	if prev := millis(last[:]); ms <= prev && prev != 0 {
+		// 80 位随机部分加一：低 64 位与高 16 位
+		lo := binary.BigEndian.Uint64(last[8:]) + 1
+		binary.BigEndian.PutUint64(last[8:], lo)
+		if lo == 0 {
+			hi := binary.BigEndian.Uint16(last[6:8]) + 1
+			binary.BigEndian.PutUint16(last[6:8], hi)
+			if hi == 0 {
+				putMillis(last[:], prev+1)
+			}
+		}
+		return *last
+	}
+	putMillis(last[:], ms)
+	randomBytes(last[6:])
+	return *last
+}
+
+// ParseULID 解析 26 位 Crockford Base32 形式的 ULID，不区分大小写
+//
+//	@param s string
+//	@return ULID
+//	@return error 格式不正确时返回 ErrInvalidFormat
+//	@update 2026-10-17 23:21:09
+func ParseULID(s string) (ULID, error) {
+	var u ULID
+	// 26 个字符共 130 位，首字符只能占用低 3 位
+	if len(s) != 26 || crockfordDec[s[0]] > 7 {
+		return u, ErrInvalidFormat
+	}
+	var hi, lo uint64
+	for i := range len(s) {
+		v := crockfordDec[s[i]]
+		if v == 0xff {
+			return ULID{}, ErrInvalidFormat
+		}
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(v)
+	}
+	binary.BigEndian.PutUint64(u[:8], hi)
+	binary.BigEndian.PutUint64(u[8:], lo)
+	return u, nil
+}
+
+// String 返回 26 位大写 Crockford Base32 字符串
+//
+//	@receiver u ULID
+//	@return string
+//	@update 2026-10-17 23:21:09
+func (u ULID) String() string {
+	hi := binary.BigEndian.Uint64(u[:8])
+	lo := binary.BigEndian.Uint64(u[8:])
+	var buf [26]byte
+	for i := 25; i >= 0; i-- {
+		buf[i] = crockford[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(buf[:])
+}
+
+// Time 返回 ULID 中的时间戳
+//
+//	@receiver u ULID
+//	@return time.Time
+//	@update 2026-10-17 23:21:09
+func (u ULID) Time() time.Time {
+	return time.UnixMilli(int64(millis(u[:])))
+}
+
+// MarshalText 实现 encoding.TextMarshaler，JSON 中序列化为字符串
+//
+//	@receiver u ULID
+//	@return []byte
+//	@return error
+//	@update 2026-10-17 23:21:09
+func (u ULID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler
+//
+//	@receiver u *ULID
+//	@param text []byte
+//	@return error
+//	@update 2026-10-17 23:21:09
+func (u *ULID) UnmarshalText(text []byte) error {
+	v, err := ParseULID(string(text))
+	if err != nil {
+		return err
+	}
+	*u = v
+	return nil
+}
@@ -0,0 +1,157 @@
+package id
+
+import (
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// UUID RFC 9562 定义的 UUID
+type UUID [16]byte
+
+// Nil 全零的 UUID
+var Nil UUID
+
+// v7 单调递增状态：同一毫秒内 rand_a 的 12 位作为计数器
+var v7 struct {
+	mu      sync.Mutex
+	ms      uint64
+	counter uint16
+}
+
+// NewV4 生成随机 UUID（版本 4）
+//
+//	@return UUID
+//	@update 2026-10-17 23:21:09
+func NewV4() UUID {
+	var u UUID
+	randomBytes(u[:])
+	u[6] = u[6]&0x0f | 0x40
+	u[8] = u[8]&0x3f | 0x80
+	return u
+}
+
+// NewV7 生成以毫秒时间戳开头的 UUID（版本 7），同一进程内严格递增，适合作为数据库主键
+//
+//	同一毫秒内以 12 位计数器区分，计数器用尽或系统时钟回拨时借用下一毫秒，保证顺序
+//
+//	@return UUID
+//	@update 2026-10-17 23:21:09
+func NewV7() UUID {
+	v7.mu.Lock()
+	ms := nowMillis()
+	if ms > v7.ms {
+		// 计数器从随机值开始，保留一半空间用于递增
+		v7.ms, v7.counter = ms, randomUint16()&0x7ff
+	} else if v7.counter++; v7.counter > 0xfff {
+		v7.ms, v7.counter = v7.ms+1, randomUint16()&0x7ff
+	}
+	ms, counter := v7.ms, v7.counter
+	v7.mu.Unlock()
+
+	var u UUID
+	putMillis(u[:], ms)
+	u[6] = 0x70 | byte(counter>>8)
+	u[7] = byte(counter)
+	randomBytes(u[8:])
+	u[8] = u[8]&0x3f | 0x80
+	return u
+}
+
+// ParseUUID 解析 xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx 或 32 位十六进制形式的 UUID，不区分大小写
+//
+//	@param s string
+//	@return UUID
+//	@return error 格式不正确时返回 ErrInvalidFormat
+//	@update 2026-10-17 23:21:09
+func ParseUUID(s string) (UUID, error) {
+	var u UUID
+	switch len(s) {
+	case 32:
+	case 36:
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return Nil, ErrInvalidFormat
+		}
+		s = s[:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	default:
+		return Nil, ErrInvalidFormat
+	}
+	if _, err := hex.Decode(u[:], []byte(s)); err != nil {
+		return Nil, ErrInvalidFormat
+	}
+	return u, nil
+}
+
+// String 返回 xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx 形式的小写字符串
+//
+//	@receiver u UUID
+//	@return string
+//	@update 2026-10-17 23:21:09
+func (u UUID) String() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// Version 返回 UUID 的版本号
+//
+//	@receiver u UUID
+//	@return int
+//	@update 2026-10-17 23:21:09
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// Time 返回版本 7 的 UUID 中的时间戳，其他版本返回零值
+//
+//	@receiver u UUID
+//	@return time.Time
+//	@update 2026-10-17 23:21:09
+func (u UUID) Time() time.Time {
+	if u.Version() != 7 {
+		return time.Time{}
+	}
+	return time.UnixMilli(int64(millis(u[:])))
+}
+
+// IsNil 判断是否为全零的 UUID
+//
+//	@receiver u UUID
+//	@return bool
+//	@update 2026-10-17 23:21:09
+func (u UUID) IsNil() bool {
+	return u == Nil
+}
+
+// MarshalText 实现 encoding.TextMarshaler，JSON 中序列化为字符串
+//
+//	@receiver u UUID
+//	@return []byte
+//	@return error
+//	@update 2026-10-17 23:21:09
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler
+//
+//	@receiver u *UUID
+//	@param text []byte
+//	@return error
+//	@update 2026-10-17 23:21:09
+func (u *UUID) UnmarshalText(text []byte) error {
+	v, err := ParseUUID(string(text))
+	if err != nil {
+		return err
+	}
+	*u = v
+	return nil
+}
@@ -0,0 +1,186 @@
+// Package ioutilx io 辅助：计数读写、有上限的读取、tee 与 Close 的 defer 模式
+package ioutilx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+)
+
+// ErrTooLarge LimitedReadAll 读取的内容超过上限
+var ErrTooLarge = errors.New("ioutilx: content exceeds limit")
+
+// CountingReader 统计已读取字节数的 Reader，Count 可与 Read 并发调用
+type CountingReader struct {
+	r io.Reader
+	n atomic.Int64
+}
+
+// NewCountingReader 包装 r
+//
+//	@param r io.Reader
+//	@return *CountingReader
+//	@update 2026-10-18 03:18:44
+func NewCountingReader(r io.Reader) *CountingReader {
+	return &CountingReader{r: r}
+}
+
+// Read 实现 io.Reader
+//
+//	@receiver c *CountingReader
+//	@param p []byte
+//	@return int
+//	@return error
+//	@update 2026-10-18 03:18:44
+func (c *CountingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n.Add(int64(n))
+	return n, err
+}
+
+// Count 返回已读取的字节数
+//
+//	@receiver c *CountingReader
+//	@return int64
+//	@update 2026-10-18 03:18:44
+func (c *CountingReader) Count() int64 {
+	return c.n.Load()
+}
+
+// CountingWriter 统计已写入字节数的 Writer，Count 可与 Write 并发调用
+type CountingWriter struct {
+	w io.Writer
+	n atomic.Int64
+}
+
+// NewCountingWriter 包装 w，w 为 nil 时丢弃写入的内容只计数
+//
+//	@param w io.Writer
+//	@return *CountingWriter
+//	@update 2026-10-18 03:18:44
+func NewCountingWriter(w io.Writer) *CountingWriter {
+	if w == nil {
+		w = io.Discard
+	}
+	return &CountingWriter{w: w}
+}
+
+// Write 实现 io.Writer
+//
+//	@receiver c *CountingWriter
+//	@param p []byte
+//	@return int
+//	@return error
+//	@update 2026-10-18 03:18:44
+func (c *CountingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n.Add(int64(n))
+	return n, err
+}
+
+// Count 返回已写入的字节数
+//
+//	@receiver c *CountingWriter
+//	@return int64
+//	@update 2026-10-18 03:18:44
+func (c *CountingWriter) Count() int64 {
+	return c.n.Load()
+}
+
+// LimitedReadAll 读取 r 的全部内容，超过 max 字节时返回 ErrTooLarge 而不是像 io.LimitReader 那样静默截断
+//
+//	@param r io.Reader
+//	@param max int64
+//	@return []byte 超限时为已读取的前 max 字节
+//	@return error
+//	@update 2026-10-18 03:18:44
+//
+// for example:
+//
+//	body, err := ioutilx.LimitedReadAll(resp.Body, 1<<20)
+//	if errors.Is(err, ioutilx.ErrTooLarge) {
+//		return fmt.Errorf("response too large: %w", err)
+//	}
+func LimitedReadAll(r io.Reader, max int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, max+1))
+	if err != nil {
+		return data, err
+	}
+	if int64(len(data)) > max {
+		return data[:max], fmt.Errorf("%w of %d bytes", ErrTooLarge, max)
+	}
+	return data, nil
+}
+
+// TeeToBuffer 返回读取 r 的 Reader，读到的内容同时写入返回的 Buffer，适用于解析失败时打印原始内容
+//
+//	@param r io.Reader
+//	@return io.Reader
+//	@return *bytes.Buffer
+//	@update 2026-10-18 03:18:44
+//
+// for example:
+//
+//	tee, raw := ioutilx.TeeToBuffer(resp.Body)
+//	if err := json.NewDecoder(tee).Decode(&v); err != nil {
+//		return fmt.Errorf("decode %q: %w", raw.String(), err)
+//	}
+func TeeToBuffer(r io.Reader) (io.Reader, *bytes.Buffer) {
+	buf := &bytes.Buffer{}
+	return io.TeeReader(r, buf), buf
+}
+
+// multiCloser 按相反顺序关闭多个 Closer
+type multiCloser []io.Closer
+
+func (m multiCloser) Close() error {
+	var errs []error
+	for i := len(m) - 1; i >= 0; i-- {
+		if m[i] == nil {
+			continue
+		}
+		if err := m[i].Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// MultiCloser 返回依次关闭 closers 的 Closer：按传入的相反顺序关闭（与 defer 一致），
+// 某个失败不影响其余的关闭，错误以 errors.Join 合并；nil 元素被忽略
+//
+//	@param closers ...io.Closer
+//	@return io.Closer
+//	@update 2026-10-18 03:18:44
+func MultiCloser(closers ...io.Closer) io.Closer {
+	return multiCloser(closers)
+}
+
+// CloseQuietly 关闭 c，供 defer 使用：errp 非 nil 且 *errp 为 nil 时将关闭的错误写入 *errp，
+// 已有错误或 errp 为 nil 时丢弃关闭的错误；c 为 nil 时什么也不做
+//
+//	@param c io.Closer
+//	@param errp *error
+//	@update 2026-10-18 03:18:44
+//
+// for example:
+//
+//	func write(path string) (err error) {
+//		f, err := os.Create(path)
+//		if err != nil {
+//			return err
+//		}
+//		defer ioutilx.CloseQuietly(f, &err) // 不再丢失写入文件时 Close 返回的错误
+//		...
+//	}
+func CloseQuietly(c io.Closer, errp *error) {
+	if c == nil {
+		return
+	}
+	err := c.Close()
+	if err != nil && errp != nil && *errp == nil {
+		*errp = err
+	}
+}
@@ -0,0 +1,123 @@
+// Package jsonx encoding/json 的便捷封装：泛型反序列化、路径取值与深度合并
+package jsonx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+// MustMarshal 序列化 v，失败时 panic；用于确定可序列化的值（如常量配置、测试数据）
+//
+//	@param v any
+//	@return []byte
+//	@update 2026-10-18 00:16:45
+func MustMarshal(v any) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("jsonx: marshal %T: %v", v, err))
+	}
+	return data
+}
+
+// Pretty 返回 v 以两个空格缩进的 JSON，用于日志与调试；无法序列化时返回 %+v 的格式
+//
+//	@param v any
+//	@return string
+//	@update 2026-10-18 00:16:45
+func Pretty(v any) string {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("%+v", v)
+	}
+	return string(data)
+}
+
+// CompactString 返回 v 的单行 JSON；v 为 []byte、string 或 json.RawMessage 时视为 JSON 文本并去除空白。
+// 无法序列化或不是合法的 JSON 文本时返回 %+v 的格式
+//
+//	@param v any
+//	@return string
+//	@update 2026-10-18 00:16:45
+func CompactString(v any) string {
+	var text []byte
+	switch t := v.(type) {
+	case []byte:
+		text = t
+	case json.RawMessage:
+		text = t
+	case string:
+		text = []byte(t)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%+v", v)
+		}
+		return string(data)
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, text); err != nil {
+		return fmt.Sprintf("%+v", v)
+	}
+	return buf.String()
+}
+
+// Unmarshal 将 data 反序列化为 T
+//
+//	@param data []byte
+//	@return T
+//	@return error
+//	@update 2026-10-18 00:16:45
+//
+// for example:
+//
+//	user, err := jsonx.Unmarshal[User](body)
+func Unmarshal[T any](data []byte) (T, error) {
+	var v T
+	err := json.Unmarshal(data, &v)
+	return v, err
+}
+
+// Get 按路径从 JSON 文本中取值，路径语法同 reflecting.GetPath，如 a.b[0].c、labels["app.kubernetes.io/name"]
+//
+//	返回值为 encoding/json 解码到 any 的类型：对象为 map[string]any，数组为 []any，数字为 float64
+//
+//	@param data []byte
+//	@param path string
+//	@return any
+//	@return error 路径不存在时 errors.Is(err, reflecting.ErrPathNotFound)
+//	@update 2026-10-18 00:16:45
+func Get(data []byte, path string) (any, error) {
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return reflecting.GetPath(doc, path)
+}
+
+// GetAs 按路径取值并转换为 T，转换规则同 reflecting.ConvertTo
+//
+//	@param data []byte
+//	@param path string
+//	@return T
+//	@return error
+//	@update 2026-10-18 00:16:45
+//
+// for example:
+//
+//	port, err := jsonx.GetAs[int](cfg, "server.listeners[0].port")
+func GetAs[T any](data []byte, path string) (T, error) {
+	var zero T
+	v, err := Get(data, path)
+	if err != nil {
+		return zero, err
+	}
+	rv, err := reflecting.ConvertTo(v, reflect.TypeFor[T]())
+	if err != nil {
+		return zero, err
+	}
+	return rv.Interface().(T), nil
+}
@@ -0,0 +1,56 @@
+package jsonx
+
+import (
+	"encoding/json"
+	"maps"
+)
+
+// DeepMerge 将 src 深度合并到 dst 的副本并返回：两边都是对象的 key 递归合并，其余情况 src 的值覆盖 dst；
+// 数组整体替换而不拼接。dst 与 src 均不会被修改
+//
+//	@param dst map[string]any
+//	@param src map[string]any
+//	@return map[string]any
+//	@update 2026-10-18 00:16:45
+//
+// for example:
+//
+//	DeepMerge(
+//		map[string]any{"db": map[string]any{"host": "localhost", "port": 5432}},
+//		map[string]any{"db": map[string]any{"host": "db.prod"}},
+//	)
+//	// {"db": {"host": "db.prod", "port": 5432}}
+func DeepMerge(dst, src map[string]any) map[string]any {
+	res := maps.Clone(dst)
+	if res == nil {
+		res = make(map[string]any, len(src))
+	}
+	for k, sv := range src {
+		srcMap, srcIsMap := sv.(map[string]any)
+		dstMap, dstIsMap := res[k].(map[string]any)
+		if srcIsMap && dstIsMap {
+			res[k] = DeepMerge(dstMap, srcMap)
+			continue
+		}
+		res[k] = sv
+	}
+	return res
+}
+
+// Merge 依次深度合并多个 JSON 对象文本，后面的优先级更高，规则同 DeepMerge
+//
+//	@param docs ...[]byte 每个都须是 JSON 对象
+//	@return []byte
+//	@return error
+//	@update 2026-10-18 00:16:45
+func Merge(docs ...[]byte) ([]byte, error) {
+	res := map[string]any{}
+	for _, doc := range docs {
+		var m map[string]any
+		if err := json.Unmarshal(doc, &m); err != nil {
+			return nil, err
+		}
+		res = DeepMerge(res, m)
+	}
+	return json.Marshal(res)
+}
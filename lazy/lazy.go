@@ -0,0 +1,177 @@
+// Package lazy 延迟初始化：只缓存成功的结果，失败可重试，支持重置后重新初始化
+package lazy
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+	"github.com/BetaGoRobot/go_utils/retry"
+)
+
+type options struct {
+	retry    []retry.Option
+	errorTTL time.Duration
+}
+
+// Option New 的选项
+type Option func(*options)
+
+// WithRetry 单次初始化内按 retry 的选项重试失败
+//
+//	@param opts ...retry.Option
+//	@return Option
+//	@update 2026-10-17 22:45:12
+func WithRetry(opts ...retry.Option) Option {
+	return func(o *options) { o.retry = append([]retry.Option{}, opts...) }
+}
+
+// ErrorTTL 初始化失败后在 d 时长内直接返回该错误，避免频繁重试压垮下游；默认不缓存错误
+//
+//	@param d time.Duration
+//	@return Option
+//	@update 2026-10-17 22:45:12
+func ErrorTTL(d time.Duration) Option {
+	return func(o *options) { o.errorTTL = d }
+}
+
+// Lazy 延迟初始化的值；与 sync.OnceValues 不同，失败不会被永久缓存
+type Lazy[T any] struct {
+	init func() (T, error)
+	opts options
+
+	mu    sync.Mutex
+	done  bool
+	val   T
+	err   error
+	errAt time.Time
+	call  *initCall[T] // 进行中的初始化
+	gen   uint64       // 每次 Reset 递增，丢弃 Reset 之前发起的初始化结果
+}
+
+type initCall[T any] struct {
+	done chan struct{}
+	val  T
+	err  error
+}
+
+// New 创建延迟初始化的值，init 在首次 Get 时执行
+//
+//	@param init func() (T, error)
+//	@param opts ...Option
+//	@return *Lazy[T]
+//	@update 2026-10-17 22:45:12
+//
+// for example:
+//
+//	var db = lazy.New(func() (*sql.DB, error) { return openDB(cfg.DSN) },
+//		lazy.WithRetry(retry.Attempts(3)), lazy.ErrorTTL(5*time.Second))
+//	conn, err := db.Get(ctx)
+func New[T any](init func() (T, error), opts ...Option) *Lazy[T] {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Lazy[T]{init: init, opts: o}
+}
+
+// Get 返回初始化成功的值；并发调用只会执行一次 init，ctx 结束时放弃等待（初始化仍在后台继续）
+//
+//	@receiver l *Lazy[T]
+//	@param ctx context.Context
+//	@return T
+//	@return error init 的错误（panic 时为 *reflecting.PanicError），或 ctx.Err()
+//	@update 2026-10-17 22:45:12
+func (l *Lazy[T]) Get(ctx context.Context) (T, error) {
+	l.mu.Lock()
+	if l.done {
+		defer l.mu.Unlock()
+		return l.val, nil
+	}
+	if l.err != nil && time.Since(l.errAt) < l.opts.errorTTL {
+		defer l.mu.Unlock()
+		var zero T
+		return zero, l.err
+	}
+	if l.call == nil {
+		l.call = &initCall[T]{done: make(chan struct{})}
+		go l.run(l.call, l.gen)
+	}
+	c := l.call
+	l.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.val, c.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Peek 不触发初始化，返回已初始化成功的值
+//
+//	@receiver l *Lazy[T]
+//	@return T
+//	@return bool
+//	@update 2026-10-17 22:45:12
+func (l *Lazy[T]) Peek() (T, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.val, l.done
+}
+
+// Reset 丢弃已缓存的值与错误，下次 Get 重新初始化，如配置重载后重建连接；
+// 进行中的初始化结果仍会返回给已在等待的调用方，但不会被缓存
+//
+//	@receiver l *Lazy[T]
+//	@update 2026-10-17 22:45:12
+func (l *Lazy[T]) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var zero T
+	l.done, l.val, l.err, l.call = false, zero, nil, nil
+	l.gen++
+}
+
+func (l *Lazy[T]) run(c *initCall[T], gen uint64) {
+	c.val, c.err = retry.DoValue(context.Background(), func(context.Context) (v T, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = reflecting.NewPanicError(r)
+			}
+		}()
+		return l.init()
+	}, l.retryOptions()...)
+
+	l.mu.Lock()
+	if l.gen == gen {
+		if c.err == nil {
+			l.done, l.val = true, c.val
+		} else {
+			l.err, l.errAt = c.err, time.Now()
+		}
+		l.call = nil
+	}
+	l.mu.Unlock()
+	close(c.done)
+}
+
+// retryOptions 未设置 WithRetry 时只执行一次
+func (l *Lazy[T]) retryOptions() []retry.Option {
+	if l.opts.retry == nil {
+		return []retry.Option{retry.Attempts(1)}
+	}
+	return l.opts.retry
+}
+
+// Func 返回与 sync.OnceValues 用法相同、但不缓存错误的函数
+//
+//	@param init func() (T, error)
+//	@param opts ...Option
+//	@return func(ctx context.Context) (T, error)
+//	@update 2026-10-17 22:45:12
+func Func[T any](init func() (T, error), opts ...Option) func(ctx context.Context) (T, error) {
+	return New(init, opts...).Get
+}
@@ -0,0 +1,149 @@
+// Package leaktest 测试用的 goroutine 泄漏检测：记录测试开始时的 goroutine，测试结束时仍存在的新 goroutine 视为泄漏
+package leaktest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+const defaultTimeout = 5 * time.Second
+
+// defaultIgnores 常驻的标准库 goroutine，首次使用时才启动，不视为泄漏
+var defaultIgnores = []string{
+	"os/signal.signal_recv",
+	"os/signal.loop",
+}
+
+type options struct {
+	timeout    time.Duration
+	ignores    []string
+	ignoreTops []string
+}
+
+// Option Check 的选项
+type Option func(*options)
+
+// WithTimeout 等待新 goroutine 退出的最长时间，默认 5s
+//
+//	@param d time.Duration
+//	@return Option
+//	@update 2026-10-18 09:08:36
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) { o.timeout = d }
+}
+
+// Ignore 调用栈中任一帧的函数名或包路径以 prefixes 之一开头的 goroutine 不视为泄漏
+//
+//	@param prefixes ...string
+//	@return Option
+//	@update 2026-10-18 09:08:36
+func Ignore(prefixes ...string) Option {
+	return func(o *options) { o.ignores = append(o.ignores, prefixes...) }
+}
+
+// IgnoreTopFunction 栈顶函数为 fn（完整函数名，如 github.com/a/b.(*Pool).worker）的 goroutine 不视为泄漏
+//
+//	@param fn string
+//	@return Option
+//	@update 2026-10-18 09:08:36
+func IgnoreTopFunction(fn string) Option {
+	return func(o *options) { o.ignoreTops = append(o.ignoreTops, fn) }
+}
+
+// Check 记录当前的 goroutine，在测试结束（t.Cleanup）时检查是否有新的 goroutine 残留，
+// 残留的 goroutine 在超时前仍未退出时以过滤掉 runtime 帧的调用栈报告测试失败
+//
+//	应在测试开头调用，使检查在其他 Cleanup（如关闭服务）之后执行；不适用于 t.Parallel 的测试，
+//	并行测试启动的 goroutine 会被误报
+//
+//	@param t testing.TB
+//	@param opts ...Option
+//	@update 2026-10-18 09:08:36
+//
+// for example:
+//
+//	func TestBatcher(t *testing.T) {
+//		leaktest.Check(t)
+//		b := batch.New(handler)
+//		defer b.Close(context.Background())
+//		...
+//	}
+func Check(t testing.TB, opts ...Option) {
+	t.Helper()
+	o := options{timeout: defaultTimeout}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	before := map[int]bool{}
+	for _, g := range goroutines() {
+		before[g.id] = true
+	}
+	t.Cleanup(func() {
+		t.Helper()
+		if leaked := wait(before, o); len(leaked) > 0 {
+			t.Errorf("leaktest: %d goroutine(s) leaked:\n\n%s", len(leaked), format(leaked))
+		}
+	})
+}
+
+// wait 在超时前反复检查，直到没有新 goroutine 残留
+func wait(before map[int]bool, o options) []goroutine {
+	deadline := time.Now().Add(o.timeout)
+	backoff := time.Millisecond
+	for {
+		leaked := find(before, o)
+		if len(leaked) == 0 || !time.Now().Before(deadline) {
+			return leaked
+		}
+		time.Sleep(min(backoff, time.Until(deadline)))
+		backoff = min(backoff*2, 100*time.Millisecond)
+	}
+}
+
+// find 返回不在 before 中且未被忽略的 goroutine
+func find(before map[int]bool, o options) []goroutine {
+	self := currentID()
+	var res []goroutine
+	for _, g := range goroutines() {
+		if g.id == self || before[g.id] || o.ignored(g) {
+			continue
+		}
+		res = append(res, g)
+	}
+	return res
+}
+
+func (o options) ignored(g goroutine) bool {
+	if len(g.frames) > 0 {
+		for _, fn := range o.ignoreTops {
+			if g.frames[0].Function == fn {
+				return true
+			}
+		}
+	}
+	for _, prefixes := range [][]string{defaultIgnores, o.ignores} {
+		if len(prefixes) > 0 && len(reflecting.FilterFrames(g.frames, reflecting.StackFormatOptions{KeepPrefixes: prefixes})) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// format 按 goroutine 输出调用栈，省略 runtime 帧
+func format(gs []goroutine) string {
+	var sb strings.Builder
+	opts := reflecting.StackFormatOptions{SkipRuntime: true, Indent: "\t", ElideFilteredCnt: true}
+	for _, g := range gs {
+		fmt.Fprintf(&sb, "goroutine %d [%s]:\n", g.id, g.state)
+		sb.WriteString(reflecting.FormatStack(g.frames, opts))
+		if g.createdBy.Function != "" {
+			fmt.Fprintf(&sb, "\tcreated by %s\n\t\t%s:%d\n", g.createdBy.Function, g.createdBy.File, g.createdBy.Line)
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
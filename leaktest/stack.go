@@ -0,0 +1,103 @@
+package leaktest
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+// goroutine runtime.Stack 输出中的一个 goroutine
+type goroutine struct {
+	id        int
+	state     string
+	frames    []reflecting.Frame
+	createdBy reflecting.Frame
+}
+
+// stacks 返回 runtime.Stack 的完整输出
+func stacks(all bool) []byte {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, all)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// goroutines 返回当前所有 goroutine
+func goroutines() []goroutine {
+	var res []goroutine
+	for _, block := range bytes.Split(stacks(true), []byte("\n\n")) {
+		if g, ok := parse(string(block)); ok {
+			res = append(res, g)
+		}
+	}
+	return res
+}
+
+// currentID 返回当前 goroutine 的 id
+func currentID() int {
+	g, _ := parse(string(stacks(false)))
+	return g.id
+}
+
+// parse 解析单个 goroutine 的文本：
+//
+//	goroutine 7 [chan receive, 2 minutes]:
+//	github.com/a/b.(*T).run(0xc000010000)
+//		/src/b/t.go:42 +0x1d
+//	created by github.com/a/b.New in goroutine 1
+//		/src/b/t.go:20 +0x9a
+func parse(block string) (goroutine, bool) {
+	lines := strings.Split(strings.TrimSpace(block), "\n")
+	header, ok := strings.CutPrefix(lines[0], "goroutine ")
+	if !ok {
+		return goroutine{}, false
+	}
+	idStr, rest, _ := strings.Cut(header, " ")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return goroutine{}, false
+	}
+	g := goroutine{id: id}
+	if i, j := strings.IndexByte(rest, '['), strings.IndexByte(rest, ']'); i >= 0 && j > i {
+		g.state, _, _ = strings.Cut(rest[i+1:j], ",")
+	}
+	for i := 1; i < len(lines); i += 2 {
+		fn := lines[i]
+		created := false
+		if s, ok := strings.CutPrefix(fn, "created by "); ok {
+			fn, _, _ = strings.Cut(s, " in goroutine ")
+			created = true
+		} else if k := strings.LastIndexByte(fn, '('); k > 0 {
+			fn = fn[:k]
+		}
+		f := reflecting.Frame{Function: fn, Package: reflecting.PackageOfFunc(fn)}
+		if i+1 < len(lines) {
+			f.File, f.Line = parseFileLine(lines[i+1])
+		}
+		if created {
+			g.createdBy = f
+		} else {
+			g.frames = append(g.frames, f)
+		}
+	}
+	return g, true
+}
+
+// parseFileLine 解析 "\t/src/b/t.go:42 +0x1d"
+func parseFileLine(s string) (string, int) {
+	s = strings.TrimSpace(s)
+	s, _, _ = strings.Cut(s, " +0x")
+	k := strings.LastIndexByte(s, ':')
+	if k < 0 {
+		return s, 0
+	}
+	line, _ := strconv.Atoi(s[k+1:])
+	return s[:k], line
+}
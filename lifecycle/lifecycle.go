@@ -0,0 +1,338 @@
+// Package lifecycle 进程生命周期管理：按依赖顺序启动组件、收到 SIGINT / SIGTERM 后逆序优雅停止并汇总停止错误
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"github.com/BetaGoRobot/go_utils/errx"
+	"github.com/BetaGoRobot/go_utils/graph"
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+var (
+	// ErrStarted Start 已经调用过
+	ErrStarted = errors.New("lifecycle: already started")
+	// ErrDuplicate 组件名重复
+	ErrDuplicate = errors.New("lifecycle: duplicate component")
+	// ErrUnknownDependency DependsOn 引用了未注册的组件
+	ErrUnknownDependency = errors.New("lifecycle: unknown dependency")
+)
+
+type hook struct {
+	name  string
+	start func(context.Context) error
+	stop  func(context.Context) error
+	o     hookOptions
+}
+
+// Manager 组件生命周期管理器，组件须在 Start / Run 之前注册
+type Manager struct {
+	o options
+
+	mu       sync.Mutex
+	hooks    []*hook
+	names    map[string]bool
+	started  []*hook
+	starting bool
+
+	stopOnce sync.Once
+	stopErr  error
+
+	shutdownOnce sync.Once
+	shutdown     chan struct{}
+	fatal        errx.Group // Go 注册的服务意外退出的错误
+}
+
+// New 创建生命周期管理器
+//
+//	@param opts ...Option
+//	@return *Manager
+//	@update 2026-10-18 08:52:03
+//
+// for example:
+//
+//	m := lifecycle.New(lifecycle.WithStopTimeout(20 * time.Second))
+//	m.Add("db", db.Connect, db.Close)
+//	m.Add("cache", cache.Start, cache.Stop, lifecycle.DependsOn("db"))
+//	m.Go("http", func(ctx context.Context) error { return serveHTTP(ctx, srv) }, lifecycle.DependsOn("db", "cache"))
+//	if err := m.Run(context.Background()); err != nil {
+//		slog.Error("exit with error", "err", err)
+//		os.Exit(1)
+//	}
+func New(opts ...Option) *Manager {
+	return &Manager{o: newOptions(opts), names: map[string]bool{}, shutdown: make(chan struct{})}
+}
+
+func (m *Manager) logger() *slog.Logger {
+	if m.o.logger != nil {
+		return m.o.logger
+	}
+	return slog.Default()
+}
+
+// Add 注册组件，start 与 stop 均可为 nil；启动按依赖与注册顺序进行，停止按启动的逆序进行，只有启动成功的组件会被停止
+//
+//	start 不应阻塞，需要长期运行的服务使用 Go 注册
+//
+//	@receiver m *Manager
+//	@param name string
+//	@param start func(ctx context.Context) error
+//	@param stop func(ctx context.Context) error
+//	@param opts ...HookOption
+//	@return error 名称重复时返回 ErrDuplicate，已启动时返回 ErrStarted
+//	@update 2026-10-18 08:52:03
+func (m *Manager) Add(name string, start, stop func(ctx context.Context) error, opts ...HookOption) error {
+	h := &hook{name: name, start: start, stop: stop}
+	for _, opt := range opts {
+		opt(&h.o)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.starting {
+		return ErrStarted
+	}
+	if m.names[name] {
+		return fmt.Errorf("%w: %s", ErrDuplicate, name)
+	}
+	m.names[name] = true
+	m.hooks = append(m.hooks, h)
+	return nil
+}
+
+// Go 注册长期运行的服务：启动时在新的 goroutine 中执行 run，停止时取消 run 的 ctx 并等待其返回
+//
+//	run 在停止之前返回非 nil 错误（context.Canceled 除外）时触发 Shutdown，该错误由 Run 返回
+//
+//	@receiver m *Manager
+//	@param name string
+//	@param run func(ctx context.Context) error
+//	@param opts ...HookOption
+//	@return error 同 Add
+//	@update 2026-10-18 08:52:03
+func (m *Manager) Go(name string, run func(ctx context.Context) error, opts ...HookOption) error {
+	var (
+		cancel context.CancelFunc
+		done   chan struct{}
+	)
+	start := func(ctx context.Context) error {
+		var runCtx context.Context
+		runCtx, cancel = context.WithCancel(context.WithoutCancel(ctx))
+		done = make(chan struct{})
+		go func() {
+			defer close(done)
+			err := call(runCtx, run)
+			if err != nil && runCtx.Err() == nil && !errors.Is(err, context.Canceled) {
+				m.fatal.Add(fmt.Errorf("lifecycle: %s exited: %w", name, err))
+				m.logger().Error("component exited unexpectedly", "component", name, "err", err)
+				m.Shutdown()
+			}
+		}()
+		return nil
+	}
+	stop := func(ctx context.Context) error {
+		cancel()
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return m.Add(name, start, stop, opts...)
+}
+
+// Start 按顺序启动所有组件；某个组件启动失败时逆序停止已启动的组件，并返回启动错误与停止错误
+//
+//	@receiver m *Manager
+//	@param ctx context.Context
+//	@return error
+//	@update 2026-10-18 08:52:03
+func (m *Manager) Start(ctx context.Context) error {
+	m.mu.Lock()
+	if m.starting {
+		m.mu.Unlock()
+		return ErrStarted
+	}
+	m.starting = true
+	m.mu.Unlock()
+
+	order, err := m.order()
+	if err != nil {
+		return err
+	}
+	for _, h := range order {
+		begin := time.Now()
+		if err := m.startHook(ctx, h); err != nil {
+			err = fmt.Errorf("lifecycle: start %s: %w", h.name, err)
+			m.logger().Error("component failed to start", "component", h.name, "err", err)
+			stopCtx, cancel := m.stopContext(ctx)
+			defer cancel()
+			return errors.Join(err, m.Stop(stopCtx))
+		}
+		m.mu.Lock()
+		m.started = append(m.started, h)
+		m.mu.Unlock()
+		m.logger().Info("component started", "component", h.name, "duration", time.Since(begin))
+	}
+	return nil
+}
+
+func (m *Manager) startHook(ctx context.Context, h *hook) error {
+	if h.start == nil {
+		return nil
+	}
+	timeout := m.o.startTimeout
+	if h.o.hasStart {
+		timeout = h.o.startTimeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return call(ctx, h.start)
+}
+
+// order 按依赖关系排序组件，无依赖关系的组件保持注册顺序
+func (m *Manager) order() ([]*hook, error) {
+	g := graph.New[string]()
+	byName := map[string]*hook{}
+	for _, h := range m.hooks {
+		g.AddNode(h.name)
+		byName[h.name] = h
+	}
+	for _, h := range m.hooks {
+		for _, dep := range h.o.deps {
+			if byName[dep] == nil {
+				return nil, fmt.Errorf("%w: %s depends on %s", ErrUnknownDependency, h.name, dep)
+			}
+			g.AddEdge(dep, h.name)
+		}
+	}
+	names, err := g.TopoSort()
+	if err != nil {
+		return nil, fmt.Errorf("lifecycle: %w", err)
+	}
+	res := make([]*hook, len(names))
+	for i, name := range names {
+		res[i] = byName[name]
+	}
+	return res, nil
+}
+
+// Stop 按启动的逆序停止已启动的组件，每个组件的停止错误都会被收集；只执行一次，之后的调用返回相同的结果
+//
+//	@receiver m *Manager
+//	@param ctx context.Context 整个停止过程的截止时间
+//	@return error 没有错误时为 nil，否则为 *errx.MultiError
+//	@update 2026-10-18 08:52:03
+func (m *Manager) Stop(ctx context.Context) error {
+	m.stopOnce.Do(func() {
+		m.mu.Lock()
+		started := m.started
+		m.started = nil
+		m.mu.Unlock()
+
+		var errs errx.Group
+		for i := len(started) - 1; i >= 0; i-- {
+			h := started[i]
+			if h.stop == nil {
+				continue
+			}
+			begin := time.Now()
+			if err := m.stopHook(ctx, h); err != nil {
+				errs.Add(fmt.Errorf("lifecycle: stop %s: %w", h.name, err))
+				m.logger().Error("component failed to stop", "component", h.name, "err", err)
+				continue
+			}
+			m.logger().Info("component stopped", "component", h.name, "duration", time.Since(begin))
+		}
+		m.stopErr = errs.Err()
+	})
+	return m.stopErr
+}
+
+func (m *Manager) stopHook(ctx context.Context, h *hook) error {
+	if h.o.stopTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.o.stopTimeout)
+		defer cancel()
+	}
+	return call(ctx, h.stop)
+}
+
+// stopContext 停止用的 ctx：保留 parent 中的值，不随其取消，带整体停止超时
+func (m *Manager) stopContext(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx := context.WithoutCancel(parent)
+	if m.o.stopTimeout > 0 {
+		return context.WithTimeout(ctx, m.o.stopTimeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// Shutdown 通知 Run 开始停止，可在任意 goroutine 中调用，可重复调用
+//
+//	@receiver m *Manager
+//	@update 2026-10-18 08:52:03
+func (m *Manager) Shutdown() {
+	m.shutdownOnce.Do(func() { close(m.shutdown) })
+}
+
+// Run 启动所有组件，阻塞到 ctx 结束、收到 WithSignals 中的信号、调用 Shutdown 或 Go 注册的服务意外退出，然后在
+// WithStopTimeout 内逆序停止所有组件；停止期间再次收到信号时立即放弃等待
+//
+//	@receiver m *Manager
+//	@param ctx context.Context
+//	@return error 启动错误，或服务意外退出的错误与停止错误的合并
+//	@update 2026-10-18 08:52:03
+func (m *Manager) Run(ctx context.Context) error {
+	sigs := make(chan os.Signal, 2)
+	if len(m.o.signals) > 0 {
+		signal.Notify(sigs, m.o.signals...)
+		defer signal.Stop(sigs)
+	}
+	if err := m.Start(ctx); err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		m.logger().Info("context done, shutting down")
+	case sig := <-sigs:
+		m.logger().Info("received signal, shutting down", "signal", sig.String())
+	case <-m.shutdown:
+		m.logger().Info("shutdown requested")
+	}
+
+	stopCtx, cancel := m.stopContext(ctx)
+	defer cancel()
+	stopped := make(chan struct{})
+	defer close(stopped)
+	go func() {
+		select {
+		case sig := <-sigs:
+			m.logger().Warn("received second signal, abandoning graceful shutdown", "signal", sig.String())
+			cancel()
+		case <-stopped:
+		}
+	}()
+	stopErr := m.Stop(stopCtx)
+	return errors.Join(m.fatal.Err(), stopErr)
+}
+
+// call 执行 fn，panic 转换为 *reflecting.PanicError
+func call(ctx context.Context, fn func(context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = reflecting.NewPanicError(r)
+		}
+	}()
+	return fn(ctx)
+}
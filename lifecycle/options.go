@@ -0,0 +1,108 @@
+package lifecycle
+
+import (
+	"log/slog"
+	"os"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultStartTimeout = 30 * time.Second
+	defaultStopTimeout  = 30 * time.Second
+)
+
+type options struct {
+	startTimeout time.Duration
+	stopTimeout  time.Duration
+	signals      []os.Signal
+	logger       *slog.Logger
+}
+
+// Option New 的选项
+type Option func(*options)
+
+// WithStartTimeout 每个组件启动的默认超时，<= 0 表示不限制；默认 30s
+//
+//	@param d time.Duration
+//	@return Option
+//	@update 2026-10-18 08:52:03
+func WithStartTimeout(d time.Duration) Option {
+	return func(o *options) { o.startTimeout = d }
+}
+
+// WithStopTimeout Run 中整个停止过程的超时，<= 0 表示不限制；默认 30s
+//
+//	@param d time.Duration
+//	@return Option
+//	@update 2026-10-18 08:52:03
+func WithStopTimeout(d time.Duration) Option {
+	return func(o *options) { o.stopTimeout = d }
+}
+
+// WithSignals Run 监听的信号，默认 SIGINT 与 SIGTERM；不传参数表示不监听信号
+//
+//	@param sigs ...os.Signal
+//	@return Option
+//	@update 2026-10-18 08:52:03
+func WithSignals(sigs ...os.Signal) Option {
+	return func(o *options) { o.signals = sigs }
+}
+
+// WithLogger 记录启动与停止过程的 logger，默认 slog.Default()
+//
+//	@param l *slog.Logger
+//	@return Option
+//	@update 2026-10-18 08:52:03
+func WithLogger(l *slog.Logger) Option {
+	return func(o *options) { o.logger = l }
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		startTimeout: defaultStartTimeout,
+		stopTimeout:  defaultStopTimeout,
+		signals:      []os.Signal{os.Interrupt, syscall.SIGTERM},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+type hookOptions struct {
+	startTimeout time.Duration
+	stopTimeout  time.Duration
+	deps         []string
+	hasStart     bool
+}
+
+// HookOption Add / Go 的选项
+type HookOption func(*hookOptions)
+
+// StartTimeout 该组件启动的超时，覆盖 WithStartTimeout；<= 0 表示不限制
+//
+//	@param d time.Duration
+//	@return HookOption
+//	@update 2026-10-18 08:52:03
+func StartTimeout(d time.Duration) HookOption {
+	return func(o *hookOptions) { o.startTimeout, o.hasStart = d, true }
+}
+
+// StopTimeout 该组件停止的超时，仍受整体停止超时的限制；默认只受整体超时限制
+//
+//	@param d time.Duration
+//	@return HookOption
+//	@update 2026-10-18 08:52:03
+func StopTimeout(d time.Duration) HookOption {
+	return func(o *hookOptions) { o.stopTimeout = d }
+}
+
+// DependsOn 该组件在 names 指定的组件启动之后启动、停止之前停止；未声明依赖的组件按注册顺序启动
+//
+//	@param names ...string
+//	@return HookOption
+//	@update 2026-10-18 08:52:03
+func DependsOn(names ...string) HookOption {
+	return func(o *hookOptions) { o.deps = append(o.deps, names...) }
+}
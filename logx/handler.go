@@ -0,0 +1,185 @@
+// Package logx slog.Handler 包装，自动为日志注入调用函数、包路径与 trace ID，并支持按包设置级别与采样
+package logx
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+// Handler 包装另一个 slog.Handler，在转发前为记录追加调用方信息
+type Handler struct {
+	next    slog.Handler
+	o       *options
+	sampler *sampler
+}
+
+// NewHandler 包装 next，函数名取自 reflecting 的缓存，无需在每个日志点调用 GetCurrentFunc
+//
+//	@param next slog.Handler
+//	@param opts ...Option
+//	@return *Handler
+//	@update 2026-10-18 02:31:52
+//
+// for example:
+//
+//	h := logx.NewHandler(slog.NewJSONHandler(os.Stdout, nil),
+//		logx.WithPackageLevel("github.com/xx/noisy", slog.LevelWarn),
+//		logx.WithSampling(time.Second, 100, 10))
+//	slog.SetDefault(slog.New(h))
+//	slog.InfoContext(logx.ContextWithTraceID(ctx, id), "handled")
+//	// {"level":"INFO","msg":"handled","func":"api.Server.Handle","pkg":"github.com/xx/api","trace_id":"..."}
+func NewHandler(next slog.Handler, opts ...Option) *Handler {
+	o := newOptions(opts)
+	h := &Handler{next: next, o: &o}
+	if o.sampleTick > 0 {
+		h.sampler = &sampler{tick: o.sampleTick, first: o.sampleN, thereafter: o.sampleM, counts: map[sampleKey]int{}}
+	}
+	return h
+}
+
+// New 以 NewHandler 包装 next 并返回 *slog.Logger
+//
+//	@param next slog.Handler
+//	@param opts ...Option
+//	@return *slog.Logger
+//	@update 2026-10-18 02:31:52
+func New(next slog.Handler, opts ...Option) *slog.Logger {
+	return slog.New(NewHandler(next, opts...))
+}
+
+// Enabled 实现 slog.Handler；此时还不知道调用方的包，按规则中的最低级别放行，在 Handle 中再精确过滤
+//
+//	@receiver h *Handler
+//	@param ctx context.Context
+//	@param level slog.Level
+//	@return bool
+//	@update 2026-10-18 02:31:52
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	if h.next.Enabled(ctx, level) {
+		return true
+	}
+	for _, r := range h.o.levels {
+		if level >= r.level.Level() {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle 实现 slog.Handler
+//
+//	@receiver h *Handler
+//	@param ctx context.Context
+//	@param r slog.Record
+//	@return error
+//	@update 2026-10-18 02:31:52
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	pkg := ""
+	if r.PC != 0 {
+		pkg = reflecting.PackageForPC(r.PC)
+	}
+	if rule, ok := h.rule(pkg); ok {
+		if r.Level < rule.Level() {
+			return nil
+		}
+	} else if !h.next.Enabled(ctx, r.Level) {
+		return nil
+	}
+	if h.sampler != nil && r.Level < h.o.sampleMax && !h.sampler.allow(r.Level, r.Message, r.Time) {
+		return nil
+	}
+
+	var attrs []slog.Attr
+	if r.PC != 0 {
+		if h.o.funcKey != "" {
+			attrs = append(attrs, slog.String(h.o.funcKey, reflecting.FuncNameForPC(r.PC)))
+		}
+		if h.o.pkgKey != "" {
+			attrs = append(attrs, slog.String(h.o.pkgKey, pkg))
+		}
+	}
+	if h.o.traceKey != "" && h.o.trace != nil {
+		if id := h.o.trace(ctx); id != "" {
+			attrs = append(attrs, slog.String(h.o.traceKey, id))
+		}
+	}
+	if len(attrs) > 0 {
+		r = r.Clone()
+		r.AddAttrs(attrs...)
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs 实现 slog.Handler，派生的 Handler 共享选项与采样状态
+//
+//	@receiver h *Handler
+//	@param attrs []slog.Attr
+//	@return slog.Handler
+//	@update 2026-10-18 02:31:52
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &Handler{next: h.next.WithAttrs(attrs), o: h.o, sampler: h.sampler}
+}
+
+// WithGroup 实现 slog.Handler，派生的 Handler 共享选项与采样状态
+//
+//	@receiver h *Handler
+//	@param name string
+//	@return slog.Handler
+//	@update 2026-10-18 02:31:52
+func (h *Handler) WithGroup(name string) slog.Handler {
+	return &Handler{next: h.next.WithGroup(name), o: h.o, sampler: h.sampler}
+}
+
+// rule 返回匹配 pkg 的最长前缀规则
+func (h *Handler) rule(pkg string) (slog.Leveler, bool) {
+	var best *levelRule
+	for i, r := range h.o.levels {
+		if strings.HasPrefix(pkg, r.prefix) && (best == nil || len(r.prefix) > len(best.prefix)) {
+			best = &h.o.levels[i]
+		}
+	}
+	if best == nil {
+		return nil, false
+	}
+	return best.level, true
+}
+
+type sampleKey struct {
+	level slog.Level
+	msg   string
+}
+
+// sampler 按 tick 周期计数的采样器
+type sampler struct {
+	tick       time.Duration
+	first      int
+	thereafter int
+
+	mu     sync.Mutex
+	window time.Time
+	counts map[sampleKey]int
+}
+
+func (s *sampler) allow(level slog.Level, msg string, now time.Time) bool {
+	if now.IsZero() {
+		now = time.Now()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if w := now.Truncate(s.tick); !w.Equal(s.window) {
+		s.window = w
+		clear(s.counts)
+	}
+	k := sampleKey{level, msg}
+	s.counts[k]++
+	n := s.counts[k]
+	if n <= s.first {
+		return true
+	}
+	return s.thereafter > 0 && (n-s.first)%s.thereafter == 0
+}
@@ -0,0 +1,117 @@
+package logx
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type options struct {
+	funcKey    string
+	pkgKey     string
+	traceKey   string
+	trace      func(ctx context.Context) string
+	levels     []levelRule
+	sampleN    int
+	sampleM    int
+	sampleTick time.Duration
+	sampleMax  slog.Level
+}
+
+// levelRule 包路径前缀对应的最低级别
+type levelRule struct {
+	prefix string
+	level  slog.Leveler
+}
+
+// Option NewHandler 的选项
+type Option func(*options)
+
+// WithKeys 修改注入的属性名，传入空字符串表示不注入该项，默认 func、pkg、trace_id
+//
+//	@param funcKey string
+//	@param pkgKey string
+//	@param traceKey string
+//	@return Option
+//	@update 2026-10-18 02:31:52
+func WithKeys(funcKey, pkgKey, traceKey string) Option {
+	return func(o *options) {
+		o.funcKey, o.pkgKey, o.traceKey = funcKey, pkgKey, traceKey
+	}
+}
+
+// WithTraceExtractor 替换从 context 中提取 trace ID 的函数，默认读取 ContextWithTraceID 写入的值；
+// 接入 OpenTelemetry 等时可返回 span context 中的 trace ID
+//
+//	@param fn func(ctx context.Context) string 返回空字符串表示没有 trace ID
+//	@return Option
+//	@update 2026-10-18 02:31:52
+func WithTraceExtractor(fn func(ctx context.Context) string) Option {
+	return func(o *options) { o.trace = fn }
+}
+
+// WithPackageLevel 为包路径以 prefix 开头的日志设置最低级别，多条规则匹配时取最长的前缀；
+// 未匹配任何规则的日志由下游 Handler 的 Enabled 决定
+//
+//	@param prefix string 包路径前缀，如 github.com/BetaGoRobot/go_utils/schedule
+//	@param level slog.Leveler 可传入 *slog.LevelVar 以便运行时调整
+//	@return Option
+//	@update 2026-10-18 02:31:52
+func WithPackageLevel(prefix string, level slog.Leveler) Option {
+	return func(o *options) {
+		o.levels = append(o.levels, levelRule{prefix: prefix, level: level})
+	}
+}
+
+// WithSampling 对级别低于 Error 的日志按 (级别, 消息) 采样：每个 tick 周期内前 first 条全部输出，
+// 之后每 thereafter 条输出一条（thereafter<=0 时丢弃其余）
+//
+//	@param tick time.Duration
+//	@param first int
+//	@param thereafter int
+//	@return Option
+//	@update 2026-10-18 02:31:52
+func WithSampling(tick time.Duration, first, thereafter int) Option {
+	return func(o *options) {
+		o.sampleTick, o.sampleN, o.sampleM = tick, first, thereafter
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		funcKey:   "func",
+		pkgKey:    "pkg",
+		traceKey:  "trace_id",
+		trace:     TraceIDFrom,
+		sampleMax: slog.LevelError,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+type traceKey struct{}
+
+// ContextWithTraceID 将 trace ID 写入 ctx，由 Handler 自动注入到日志中
+//
+//	@param ctx context.Context
+//	@param traceID string
+//	@return context.Context
+//	@update 2026-10-18 02:31:52
+func ContextWithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceKey{}, traceID)
+}
+
+// TraceIDFrom 读取 ContextWithTraceID 写入的 trace ID
+//
+//	@param ctx context.Context
+//	@return string
+//	@update 2026-10-18 02:31:52
+func TraceIDFrom(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(traceKey{}).(string)
+	return id
+}
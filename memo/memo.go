@@ -0,0 +1,171 @@
+// Package memo 函数结果的记忆化：TTL、容量上限（LRU）、错误缓存策略与并发相同调用的合并
+package memo
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/BetaGoRobot/go_utils/single"
+)
+
+// Memo 记忆化的函数，并发安全
+type Memo[K comparable, V any] struct {
+	fn func(context.Context, K) (V, error)
+	o  options
+
+	mu    sync.Mutex
+	items map[K]*list.Element
+	lru   list.List // 元素为 *entry[K, V]，队首为最近使用
+	group single.Group[K, V]
+}
+
+type entry[K comparable, V any] struct {
+	key     K
+	val     V
+	err     error
+	expires time.Time // 零值表示永不过期
+}
+
+// New 创建记忆化的函数
+//
+//	@param fn func(ctx context.Context, key K) (V, error)
+//	@param opts ...Option
+//	@return *Memo[K, V]
+//	@update 2026-10-18 06:08:40
+func New[K comparable, V any](fn func(ctx context.Context, key K) (V, error), opts ...Option) *Memo[K, V] {
+	m := &Memo[K, V]{fn: fn, items: map[K]*list.Element{}}
+	for _, opt := range opts {
+		opt(&m.o)
+	}
+	return m
+}
+
+// Func 返回 fn 的记忆化版本，等价于 New(fn, opts...).Get
+//
+//	@param fn func(ctx context.Context, key K) (V, error)
+//	@param opts ...Option
+//	@return func(ctx context.Context, key K) (V, error)
+//	@update 2026-10-18 06:08:40
+//
+// for example:
+//
+//	getUser := memo.Func(db.LoadUser, memo.WithTTL(time.Minute), memo.WithMaxEntries(10000), memo.CacheErrors(time.Second))
+//	u, err := getUser(ctx, id)
+func Func[K comparable, V any](fn func(ctx context.Context, key K) (V, error), opts ...Option) func(ctx context.Context, key K) (V, error) {
+	return New(fn, opts...).Get
+}
+
+// Get 返回 key 的缓存结果，未命中或已过期时执行 fn；相同 key 的并发未命中只执行一次 fn，语义同 single.Group.DoContext
+//
+//	fn 收到的 ctx 继承首个调用方 ctx 中的值但不会随其取消；调用方 ctx 结束时返回 ctx.Err()，fn 完成后结果仍会被缓存
+//
+//	@receiver m *Memo[K, V]
+//	@param ctx context.Context
+//	@param key K
+//	@return V
+//	@return error
+//	@update 2026-10-18 06:08:40
+func (m *Memo[K, V]) Get(ctx context.Context, key K) (V, error) {
+	if v, err, ok := m.lookup(key); ok {
+		return v, err
+	}
+	v, err, _ := m.group.DoContext(ctx, key, func(ctx context.Context) (V, error) {
+		// 等待合并期间其他调用可能已写入缓存
+		if v, err, ok := m.lookup(key); ok {
+			return v, err
+		}
+		v, err := m.fn(ctx, key)
+		m.store(key, v, err)
+		return v, err
+	})
+	return v, err
+}
+
+// Forget 删除 key 的缓存结果，正在执行的调用完成后不会被其他调用等待
+//
+//	@receiver m *Memo[K, V]
+//	@param key K
+//	@update 2026-10-18 06:08:40
+func (m *Memo[K, V]) Forget(key K) {
+	m.group.Forget(key)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if el, ok := m.items[key]; ok {
+		m.remove(el)
+	}
+}
+
+// Purge 清空所有缓存结果
+//
+//	@receiver m *Memo[K, V]
+//	@update 2026-10-18 06:08:40
+func (m *Memo[K, V]) Purge() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clear(m.items)
+	m.lru.Init()
+}
+
+// Len 返回缓存的 key 数，含已过期但尚未清理的
+//
+//	@receiver m *Memo[K, V]
+//	@return int
+//	@update 2026-10-18 06:08:40
+func (m *Memo[K, V]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.items)
+}
+
+// lookup 查找未过期的缓存结果，命中时移到队首
+func (m *Memo[K, V]) lookup(key K) (V, error, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	el, ok := m.items[key]
+	if !ok {
+		var zero V
+		return zero, nil, false
+	}
+	e := el.Value.(*entry[K, V])
+	if !e.expires.IsZero() && !time.Now().Before(e.expires) {
+		m.remove(el)
+		var zero V
+		return zero, nil, false
+	}
+	m.lru.MoveToFront(el)
+	return e.val, e.err, true
+}
+
+// store 按 TTL 与错误策略写入结果，超过容量时淘汰队尾
+func (m *Memo[K, V]) store(key K, v V, err error) {
+	ttl := m.o.ttl
+	if err != nil {
+		if m.o.errorTTL <= 0 {
+			return
+		}
+		ttl = m.o.errorTTL
+	}
+	e := &entry[K, V]{key: key, val: v, err: err}
+	if ttl > 0 {
+		e.expires = time.Now().Add(ttl)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if el, ok := m.items[key]; ok {
+		el.Value = e
+		m.lru.MoveToFront(el)
+		return
+	}
+	m.items[key] = m.lru.PushFront(e)
+	for m.o.maxEntries > 0 && len(m.items) > m.o.maxEntries {
+		m.remove(m.lru.Back())
+	}
+}
+
+func (m *Memo[K, V]) remove(el *list.Element) {
+	m.lru.Remove(el)
+	delete(m.items, el.Value.(*entry[K, V]).key)
+}
@@ -0,0 +1,40 @@
+package memo
+
+import "time"
+
+type options struct {
+	ttl        time.Duration
+	maxEntries int
+	errorTTL   time.Duration
+}
+
+// Option Func / New 的选项
+type Option func(*options)
+
+// WithTTL 结果缓存 d 时长后失效，<= 0 表示永不过期（默认）
+//
+//	@param d time.Duration
+//	@return Option
+//	@update 2026-10-18 06:08:40
+func WithTTL(d time.Duration) Option {
+	return func(o *options) { o.ttl = d }
+}
+
+// WithMaxEntries 最多缓存 n 个 key，超过时淘汰最久未使用的；<= 0 表示不限制（默认）
+//
+//	@param n int
+//	@return Option
+//	@update 2026-10-18 06:08:40
+func WithMaxEntries(n int) Option {
+	return func(o *options) { o.maxEntries = n }
+}
+
+// CacheErrors 将 fn 返回的错误缓存 d 时长，期间相同 key 直接返回该错误，避免频繁重试压垮下游；
+// 默认不缓存错误，下次调用重新执行 fn
+//
+//	@param d time.Duration
+//	@return Option
+//	@update 2026-10-18 06:08:40
+func CacheErrors(d time.Duration) Option {
+	return func(o *options) { o.errorTTL = d }
+}
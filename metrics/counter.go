@@ -0,0 +1,177 @@
+package metrics
+
+// Counter 只增不减的计数器
+type Counter struct {
+	f *family
+}
+
+// CounterValue 一组标签值对应的计数器
+type CounterValue struct {
+	s *series
+}
+
+// NewCounter 在 Default 中注册计数器，同名计数器已存在时返回同一个
+//
+//	@param name string
+//	@param help string
+//	@param labelNames ...string
+//	@return *Counter
+//	@update 2026-10-18 02:10:25
+func NewCounter(name, help string, labelNames ...string) *Counter {
+	return Default.Counter(name, help, labelNames...)
+}
+
+// Counter 注册计数器，同名计数器已存在时返回同一个；同名但类型或标签名不同时 panic
+//
+//	@receiver r *Registry
+//	@param name string
+//	@param help string
+//	@param labelNames ...string
+//	@return *Counter
+//	@update 2026-10-18 02:10:25
+//
+// for example:
+//
+//	reqs := reg.Counter("http_requests_total", "HTTP requests", "method", "code")
+//	reqs.With("GET", "200").Inc()
+func (r *Registry) Counter(name, help string, labelNames ...string) *Counter {
+	return &Counter{f: r.register(name, help, KindCounter, labelNames, nil)}
+}
+
+// With 返回标签值对应的计数器，标签值个数与注册时的标签名不一致时 panic
+//
+//	@receiver c *Counter
+//	@param labelValues ...string
+//	@return CounterValue
+//	@update 2026-10-18 02:10:25
+func (c *Counter) With(labelValues ...string) CounterValue {
+	return CounterValue{s: c.f.with(labelValues)}
+}
+
+// Inc 无标签计数器加一
+//
+//	@receiver c *Counter
+//	@update 2026-10-18 02:10:25
+func (c *Counter) Inc() { c.With().Inc() }
+
+// Add 无标签计数器加 delta，delta 为负时 panic
+//
+//	@receiver c *Counter
+//	@param delta float64
+//	@update 2026-10-18 02:10:25
+func (c *Counter) Add(delta float64) { c.With().Add(delta) }
+
+// Inc 加一
+//
+//	@receiver c CounterValue
+//	@update 2026-10-18 02:10:25
+func (c CounterValue) Inc() { c.s.value.Add(1) }
+
+// Add 加 delta，delta 为负时 panic
+//
+//	@receiver c CounterValue
+//	@param delta float64
+//	@update 2026-10-18 02:10:25
+func (c CounterValue) Add(delta float64) {
+	if delta < 0 {
+		panic("metrics: counter cannot decrease")
+	}
+	c.s.value.Add(delta)
+}
+
+// Value 返回当前值
+//
+//	@receiver c CounterValue
+//	@return float64
+//	@update 2026-10-18 02:10:25
+func (c CounterValue) Value() float64 { return c.s.value.Load() }
+
+// Gauge 可增可减的仪表
+type Gauge struct {
+	f *family
+}
+
+// GaugeValue 一组标签值对应的仪表
+type GaugeValue struct {
+	s *series
+}
+
+// NewGauge 在 Default 中注册仪表，同名仪表已存在时返回同一个
+//
+//	@param name string
+//	@param help string
+//	@param labelNames ...string
+//	@return *Gauge
+//	@update 2026-10-18 02:10:25
+func NewGauge(name, help string, labelNames ...string) *Gauge {
+	return Default.Gauge(name, help, labelNames...)
+}
+
+// Gauge 注册仪表，同名仪表已存在时返回同一个；同名但类型或标签名不同时 panic
+//
+//	@receiver r *Registry
+//	@param name string
+//	@param help string
+//	@param labelNames ...string
+//	@return *Gauge
+//	@update 2026-10-18 02:10:25
+func (r *Registry) Gauge(name, help string, labelNames ...string) *Gauge {
+	return &Gauge{f: r.register(name, help, KindGauge, labelNames, nil)}
+}
+
+// With 返回标签值对应的仪表，标签值个数与注册时的标签名不一致时 panic
+//
+//	@receiver g *Gauge
+//	@param labelValues ...string
+//	@return GaugeValue
+//	@update 2026-10-18 02:10:25
+func (g *Gauge) With(labelValues ...string) GaugeValue {
+	return GaugeValue{s: g.f.with(labelValues)}
+}
+
+// Set 设置无标签仪表的值
+//
+//	@receiver g *Gauge
+//	@param v float64
+//	@update 2026-10-18 02:10:25
+func (g *Gauge) Set(v float64) { g.With().Set(v) }
+
+// Add 无标签仪表加 delta
+//
+//	@receiver g *Gauge
+//	@param delta float64
+//	@update 2026-10-18 02:10:25
+func (g *Gauge) Add(delta float64) { g.With().Add(delta) }
+
+// Set 设置值
+//
+//	@receiver g GaugeValue
+//	@param v float64
+//	@update 2026-10-18 02:10:25
+func (g GaugeValue) Set(v float64) { g.s.value.Store(v) }
+
+// Add 加 delta，可为负
+//
+//	@receiver g GaugeValue
+//	@param delta float64
+//	@update 2026-10-18 02:10:25
+func (g GaugeValue) Add(delta float64) { g.s.value.Add(delta) }
+
+// Inc 加一
+//
+//	@receiver g GaugeValue
+//	@update 2026-10-18 02:10:25
+func (g GaugeValue) Inc() { g.s.value.Add(1) }
+
+// Dec 减一
+//
+//	@receiver g GaugeValue
+//	@update 2026-10-18 02:10:25
+func (g GaugeValue) Dec() { g.s.value.Add(-1) }
+
+// Value 返回当前值
+//
+//	@receiver g GaugeValue
+//	@return float64
+//	@update 2026-10-18 02:10:25
+func (g GaugeValue) Value() float64 { return g.s.value.Load() }
@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+const (
+	// FuncCallsName CountCall 使用的计数器名称
+	FuncCallsName = "func_calls_total"
+	// FuncDurationName TimeCall 使用的直方图名称
+	FuncDurationName = "func_duration_seconds"
+)
+
+// CountCall 在 Default 的 func_calls_total 计数器中为调用方函数加一，标签 func 取自 reflecting.GetCurrentFunc 的规则
+//
+//	@update 2026-10-18 02:10:25
+//
+// for example:
+//
+//	func (s *Service) Handle(ctx context.Context) error {
+//		metrics.CountCall() // func_calls_total{func="service.Service.Handle"} 1
+//		...
+//	}
+func CountCall() {
+	Default.Counter(FuncCallsName, "Number of calls by function.", "func").
+		With(reflecting.GetCurrentFuncDepth(2)).Inc()
+}
+
+// TimeCall 返回结束计时的函数，将调用方函数的耗时记录到 Default 的 func_duration_seconds 直方图
+//
+//	@return func()
+//	@update 2026-10-18 02:10:25
+//
+// for example:
+//
+//	func (s *Service) Handle(ctx context.Context) error {
+//		defer metrics.TimeCall()()
+//		...
+//	}
+func TimeCall() func() {
+	h := Default.Histogram(FuncDurationName, "Function call duration in seconds.", nil, "func").
+		With(reflecting.GetCurrentFuncDepth(2))
+	start := time.Now()
+	return func() { h.ObserveSince(start) }
+}
@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"slices"
+	"sort"
+	"time"
+)
+
+// DefBuckets 默认的直方图桶上界，单位为秒，适用于一般的请求耗时
+var DefBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Histogram 直方图
+type Histogram struct {
+	f *family
+}
+
+// HistogramValue 一组标签值对应的直方图
+type HistogramValue struct {
+	f *family
+	s *series
+}
+
+// NewHistogram 在 Default 中注册直方图，同名直方图已存在时返回同一个
+//
+//	@param name string
+//	@param help string
+//	@param buckets []float64 桶上界，为空时使用 DefBuckets
+//	@param labelNames ...string
+//	@return *Histogram
+//	@update 2026-10-18 02:10:25
+func NewHistogram(name, help string, buckets []float64, labelNames ...string) *Histogram {
+	return Default.Histogram(name, help, buckets, labelNames...)
+}
+
+// Histogram 注册直方图，同名直方图已存在时返回同一个（沿用首次注册的桶）；同名但类型或标签名不同时 panic
+//
+//	@receiver r *Registry
+//	@param name string
+//	@param help string
+//	@param buckets []float64 桶上界，为空时使用 DefBuckets；无需包含 +Inf
+//	@param labelNames ...string
+//	@return *Histogram
+//	@update 2026-10-18 02:10:25
+//
+// for example:
+//
+//	latency := reg.Histogram("rpc_duration_seconds", "RPC latency", nil, "method")
+//	start := time.Now()
+//	defer latency.With("Get").ObserveSince(start)
+func (r *Registry) Histogram(name, help string, buckets []float64, labelNames ...string) *Histogram {
+	if len(buckets) == 0 {
+		buckets = DefBuckets
+	}
+	buckets = slices.Clone(buckets)
+	slices.Sort(buckets)
+	return &Histogram{f: r.register(name, help, KindHistogram, labelNames, slices.Compact(buckets))}
+}
+
+// With 返回标签值对应的直方图，标签值个数与注册时的标签名不一致时 panic
+//
+//	@receiver h *Histogram
+//	@param labelValues ...string
+//	@return HistogramValue
+//	@update 2026-10-18 02:10:25
+func (h *Histogram) With(labelValues ...string) HistogramValue {
+	return HistogramValue{f: h.f, s: h.f.with(labelValues)}
+}
+
+// Observe 无标签直方图记录一次观测
+//
+//	@receiver h *Histogram
+//	@param v float64
+//	@update 2026-10-18 02:10:25
+func (h *Histogram) Observe(v float64) { h.With().Observe(v) }
+
+// Observe 记录一次观测
+//
+//	@receiver h HistogramValue
+//	@param v float64
+//	@update 2026-10-18 02:10:25
+func (h HistogramValue) Observe(v float64) {
+	i := sort.SearchFloat64s(h.f.buckets, v)
+	h.s.counts[i].Add(1)
+	h.s.sum.Add(v)
+	h.s.count.Add(1)
+}
+
+// ObserveSince 以秒为单位记录从 start 到现在的耗时
+//
+//	@receiver h HistogramValue
+//	@param start time.Time
+//	@update 2026-10-18 02:10:25
+func (h HistogramValue) ObserveSince(start time.Time) {
+	h.Observe(time.Since(start).Seconds())
+}
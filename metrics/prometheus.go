@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"maps"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// WritePrometheus 以 Prometheus 文本格式（0.0.4）写出所有指标
+//
+//	@receiver r *Registry
+//	@param w io.Writer
+//	@return error
+//	@update 2026-10-18 02:10:25
+func (r *Registry) WritePrometheus(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for _, fam := range r.Snapshot() {
+		if fam.Help != "" {
+			bw.WriteString("# HELP " + fam.Name + " " + helpReplacer.Replace(fam.Help) + "\n")
+		}
+		bw.WriteString("# TYPE " + fam.Name + " " + string(fam.Kind) + "\n")
+		for _, s := range fam.Series {
+			if fam.Kind != KindHistogram {
+				writeSample(bw, fam.Name, s.Labels, "", s.Value)
+				continue
+			}
+			for _, b := range s.Buckets {
+				writeSample(bw, fam.Name+"_bucket", s.Labels, formatFloat(b.UpperBound), float64(b.Count))
+			}
+			writeSample(bw, fam.Name+"_bucket", s.Labels, "+Inf", float64(s.Count))
+			writeSample(bw, fam.Name+"_sum", s.Labels, "", s.Sum)
+			writeSample(bw, fam.Name+"_count", s.Labels, "", float64(s.Count))
+		}
+	}
+	return bw.Flush()
+}
+
+// Handler 返回以 Prometheus 文本格式输出 r 中指标的 http.Handler
+//
+//	@receiver r *Registry
+//	@return http.Handler
+//	@update 2026-10-18 02:10:25
+//
+// for example:
+//
+//	http.Handle("/metrics", metrics.Default.Handler())
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = r.WritePrometheus(w)
+	})
+}
+
+var (
+	helpReplacer  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+// writeSample 写出一行样本，le 非空时追加 le 标签
+func writeSample(w *bufio.Writer, name string, labels map[string]string, le string, v float64) {
+	w.WriteString(name)
+	if len(labels) > 0 || le != "" {
+		w.WriteByte('{')
+		first := true
+		for _, k := range sortedKeys(labels) {
+			if !first {
+				w.WriteByte(',')
+			}
+			first = false
+			w.WriteString(k + `="` + labelReplacer.Replace(labels[k]) + `"`)
+		}
+		if le != "" {
+			if !first {
+				w.WriteByte(',')
+			}
+			w.WriteString(`le="` + le + `"`)
+		}
+		w.WriteByte('}')
+	}
+	w.WriteByte(' ')
+	w.WriteString(formatFloat(v))
+	w.WriteByte('\n')
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func sortedKeys(m map[string]string) []string {
+	return slices.Sorted(maps.Keys(m))
+}
@@ -0,0 +1,223 @@
+// Package metrics 进程内的计数器、仪表与直方图，支持标签、快照与 Prometheus 文本格式导出
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Kind 指标类型
+type Kind string
+
+const (
+	KindCounter   Kind = "counter"
+	KindGauge     Kind = "gauge"
+	KindHistogram Kind = "histogram"
+)
+
+// Registry 指标注册表，并发安全
+type Registry struct {
+	mu       sync.RWMutex
+	families map[string]*family
+}
+
+// Default 包级函数使用的默认注册表
+var Default = NewRegistry()
+
+// NewRegistry 创建空的注册表
+//
+//	@return *Registry
+//	@update 2026-10-18 02:10:25
+func NewRegistry() *Registry {
+	return &Registry{families: map[string]*family{}}
+}
+
+// family 同名指标的全部序列
+type family struct {
+	name       string
+	help       string
+	kind       Kind
+	labelNames []string
+	buckets    []float64 // 仅直方图
+
+	mu     sync.RWMutex
+	series map[string]*series
+}
+
+// series 一组标签值对应的指标值
+type series struct {
+	labelValues []string
+	value       atomicFloat     // 计数器与仪表
+	counts      []atomic.Uint64 // 直方图各桶（非累计）计数，最后一个为 +Inf
+	sum         atomicFloat
+	count       atomic.Uint64
+}
+
+// atomicFloat 以 CAS 更新的 float64
+type atomicFloat struct {
+	bits atomic.Uint64
+}
+
+func (f *atomicFloat) Load() float64 {
+	return math.Float64frombits(f.bits.Load())
+}
+
+func (f *atomicFloat) Store(v float64) {
+	f.bits.Store(math.Float64bits(v))
+}
+
+func (f *atomicFloat) Add(delta float64) {
+	for {
+		old := f.bits.Load()
+		if f.bits.CompareAndSwap(old, math.Float64bits(math.Float64frombits(old)+delta)) {
+			return
+		}
+	}
+}
+
+// register 取得或创建指标族，同名但类型或标签不一致时 panic
+func (r *Registry) register(name, help string, kind Kind, labelNames []string, buckets []float64) *family {
+	r.mu.RLock()
+	f, ok := r.families[name]
+	r.mu.RUnlock()
+	if !ok {
+		r.mu.Lock()
+		if f, ok = r.families[name]; !ok {
+			f = &family{
+				name:       name,
+				help:       help,
+				kind:       kind,
+				labelNames: slices.Clone(labelNames),
+				buckets:    buckets,
+				series:     map[string]*series{},
+			}
+			r.families[name] = f
+		}
+		r.mu.Unlock()
+	}
+	if f.kind != kind || !slices.Equal(f.labelNames, labelNames) {
+		panic(fmt.Sprintf("metrics: %s already registered as %s%v, got %s%v", name, f.kind, f.labelNames, kind, labelNames))
+	}
+	return f
+}
+
+// with 取得或创建标签值对应的序列，标签值个数与标签名不一致时 panic
+func (f *family) with(labelValues []string) *series {
+	if len(labelValues) != len(f.labelNames) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values %v, got %d", f.name, len(f.labelNames), f.labelNames, len(labelValues)))
+	}
+	key := strings.Join(labelValues, "\xff")
+	f.mu.RLock()
+	s, ok := f.series[key]
+	f.mu.RUnlock()
+	if ok {
+		return s
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if s, ok = f.series[key]; ok {
+		return s
+	}
+	s = &series{labelValues: slices.Clone(labelValues)}
+	if f.kind == KindHistogram {
+		s.counts = make([]atomic.Uint64, len(f.buckets)+1)
+	}
+	f.series[key] = s
+	return s
+}
+
+// Unregister 移除指标族
+//
+//	@receiver r *Registry
+//	@param name string
+//	@return bool 指标是否存在
+//	@update 2026-10-18 02:10:25
+func (r *Registry) Unregister(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.families[name]
+	delete(r.families, name)
+	return ok
+}
+
+// Bucket 直方图的一个累计桶
+type Bucket struct {
+	UpperBound float64 `json:"le"`
+	Count      uint64  `json:"count"` // 小于等于 UpperBound 的观测次数（累计）
+}
+
+// Series 快照中的一个序列
+type Series struct {
+	Labels  map[string]string `json:"labels,omitempty"`
+	Value   float64           `json:"value"`             // 计数器与仪表的值
+	Buckets []Bucket          `json:"buckets,omitempty"` // 仅直方图，不含 +Inf
+	Count   uint64            `json:"count,omitempty"`   // 仅直方图
+	Sum     float64           `json:"sum,omitempty"`     // 仅直方图
+}
+
+// Family 快照中的一个指标族
+type Family struct {
+	Name   string   `json:"name"`
+	Help   string   `json:"help,omitempty"`
+	Kind   Kind     `json:"kind"`
+	Series []Series `json:"series"`
+}
+
+// Snapshot 返回所有指标当前值的快照，按指标名与标签值排序
+//
+//	@receiver r *Registry
+//	@return []Family
+//	@update 2026-10-18 02:10:25
+func (r *Registry) Snapshot() []Family {
+	r.mu.RLock()
+	fams := make([]*family, 0, len(r.families))
+	for _, f := range r.families {
+		fams = append(fams, f)
+	}
+	r.mu.RUnlock()
+	slices.SortFunc(fams, func(a, b *family) int { return strings.Compare(a.name, b.name) })
+
+	res := make([]Family, 0, len(fams))
+	for _, f := range fams {
+		res = append(res, f.snapshot())
+	}
+	return res
+}
+
+func (f *family) snapshot() Family {
+	f.mu.RLock()
+	all := make([]*series, 0, len(f.series))
+	for _, s := range f.series {
+		all = append(all, s)
+	}
+	f.mu.RUnlock()
+	slices.SortFunc(all, func(a, b *series) int { return slices.Compare(a.labelValues, b.labelValues) })
+
+	fam := Family{Name: f.name, Help: f.help, Kind: f.kind, Series: make([]Series, 0, len(all))}
+	for _, s := range all {
+		out := Series{Value: s.value.Load()}
+		if len(f.labelNames) > 0 {
+			out.Labels = make(map[string]string, len(f.labelNames))
+			for i, n := range f.labelNames {
+				out.Labels[n] = s.labelValues[i]
+			}
+		}
+		if f.kind == KindHistogram {
+			out.Value = 0
+			var cum uint64
+			out.Buckets = make([]Bucket, len(f.buckets))
+			for i, ub := range f.buckets {
+				cum += s.counts[i].Load()
+				out.Buckets[i] = Bucket{UpperBound: ub, Count: cum}
+			}
+			out.Count = s.count.Load()
+			out.Sum = s.sum.Load()
+		}
+		fam.Series = append(fam.Series, out)
+	}
+	return fam
+}
@@ -0,0 +1,186 @@
+// Package netutil IP 与 CIDR 判断、host:port 解析、空闲端口探测与端口等待
+package netutil
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BetaGoRobot/go_utils/retry"
+)
+
+// parseAddr 解析 IP，IPv4-mapped IPv6 地址转为 IPv4，并去掉 zone
+func parseAddr(ip string) (netip.Addr, error) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	return addr.Unmap().WithZone(""), nil
+}
+
+// IsPrivateIP 判断 ip 是否为私有地址（RFC 1918 的 10/8、172.16/12、192.168/16 与 RFC 4193 的 fc00::/7），
+// 回环与链路本地地址不算私有；无法解析时返回 false
+//
+//	@param ip string
+//	@return bool
+//	@update 2026-10-18 04:47:19
+func IsPrivateIP(ip string) bool {
+	addr, err := parseAddr(ip)
+	return err == nil && addr.IsPrivate()
+}
+
+// IsInternalIP 判断 ip 是否不可从公网路由：私有、回环、链路本地、未指定地址以及 100.64/10（CGNAT）
+//
+//	@param ip string
+//	@return bool
+//	@update 2026-10-18 04:47:19
+func IsInternalIP(ip string) bool {
+	addr, err := parseAddr(ip)
+	if err != nil {
+		return false
+	}
+	return addr.IsPrivate() || addr.IsLoopback() || addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() || addr.IsUnspecified() || cgnat.Contains(addr)
+}
+
+var cgnat = netip.MustParsePrefix("100.64.0.0/10")
+
+// IPInCIDRs 判断 ip 是否属于 cidrs 中的任意一个网段，cidrs 中不带掩码的 IP 视为单个地址
+//
+//	@param ip string
+//	@param cidrs []string
+//	@return bool
+//	@return error ip 或某个网段无法解析
+//	@update 2026-10-18 04:47:19
+//
+// for example:
+//
+//	ok, err := netutil.IPInCIDRs(r.RemoteAddr, []string{"10.0.0.0/8", "192.168.1.10"})
+func IPInCIDRs(ip string, cidrs []string) (bool, error) {
+	addr, err := parseAddr(ip)
+	if err != nil {
+		return false, fmt.Errorf("netutil: %w", err)
+	}
+	for _, c := range cidrs {
+		prefix, err := parsePrefix(c)
+		if err != nil {
+			return false, fmt.Errorf("netutil: %w", err)
+		}
+		if prefix.Contains(addr) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func parsePrefix(s string) (netip.Prefix, error) {
+	s = strings.TrimSpace(s)
+	if !strings.Contains(s, "/") {
+		addr, err := parseAddr(s)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+	prefix, err := netip.ParsePrefix(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	if addr := prefix.Addr(); addr.Is4In6() && prefix.Bits() >= 96 {
+		return netip.PrefixFrom(addr.Unmap(), prefix.Bits()-96), nil
+	}
+	return prefix.Masked(), nil
+}
+
+// SplitHostPortDefault 拆分 host:port，未给出端口时使用 defaultPort；支持 [::1]:80、[::1] 与不带括号的 IPv6 地址
+//
+//	@param hostport string
+//	@param defaultPort string
+//	@return host string
+//	@return port string
+//	@return err error
+//	@update 2026-10-18 04:47:19
+func SplitHostPortDefault(hostport, defaultPort string) (host, port string, err error) {
+	if hostport == "" {
+		return "", defaultPort, nil
+	}
+	if strings.HasPrefix(hostport, "[") && strings.HasSuffix(hostport, "]") {
+		return hostport[1 : len(hostport)-1], defaultPort, nil
+	}
+	// 多个冒号且不带括号：视为裸 IPv6 地址
+	if strings.Count(hostport, ":") > 1 && !strings.HasPrefix(hostport, "[") {
+		if _, err := netip.ParseAddr(hostport); err == nil {
+			return hostport, defaultPort, nil
+		}
+	}
+	if !strings.Contains(hostport, ":") {
+		return hostport, defaultPort, nil
+	}
+	host, port, err = net.SplitHostPort(hostport)
+	if err != nil {
+		return "", "", err
+	}
+	if port == "" {
+		port = defaultPort
+	}
+	return host, port, nil
+}
+
+// GetFreePort 返回 127.0.0.1 上当前空闲的 TCP 端口；返回后到使用前端口可能被占用，仅适用于测试
+//
+//	@return int
+//	@return error
+//	@update 2026-10-18 04:47:19
+func GetFreePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+// WaitForPort 反复尝试以 TCP 连接 addr，直到成功或 ctx 结束，用于等待依赖服务启动
+//
+//	@param ctx context.Context
+//	@param addr string host:port
+//	@return error ctx 结束时包装最后一次连接的错误
+//	@update 2026-10-18 04:47:19
+//
+// for example:
+//
+//	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+//	defer cancel()
+//	if err := netutil.WaitForPort(ctx, "127.0.0.1:"+strconv.Itoa(port)); err != nil {
+//		t.Fatal(err)
+//	}
+func WaitForPort(ctx context.Context, addr string) error {
+	var d net.Dialer
+	return retry.Do(ctx, func(ctx context.Context) error {
+		dialCtx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		conn, err := d.DialContext(dialCtx, "tcp", addr)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	},
+		retry.Attempts(0),
+		retry.Backoff(retry.Exponential(20*time.Millisecond)),
+		retry.MaxDelay(500*time.Millisecond),
+	)
+}
+
+// JoinHostPort 同 net.JoinHostPort，port 为 int
+//
+//	@param host string
+//	@param port int
+//	@return string
+//	@update 2026-10-18 04:47:19
+func JoinHostPort(host string, port int) string {
+	return net.JoinHostPort(host, strconv.Itoa(port))
+}
@@ -0,0 +1,160 @@
+// Package numfmt 报表与告警中的数字展示格式：千分位分隔、固定小数位与英文序数词
+package numfmt
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Integer 所有整数类型
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Formatter 数字格式，零值等价于 Default
+type Formatter struct {
+	Thousands string // 千分位分隔符，默认 ","
+	Decimal   string // 小数点，默认 "."
+}
+
+// Default 使用 "," 分隔千分位、"." 作为小数点的格式
+var Default = Formatter{Thousands: ",", Decimal: "."}
+
+func (f Formatter) seps() (string, string) {
+	th, dec := f.Thousands, f.Decimal
+	if th == "" && dec == "" {
+		return Default.Thousands, Default.Decimal
+	}
+	if dec == "" {
+		dec = Default.Decimal
+	}
+	return th, dec
+}
+
+// Int 格式化整数，每三位插入千分位分隔符
+//
+//	@receiver f Formatter
+//	@param n int64
+//	@return string
+//	@update 2026-10-18 08:18:50
+func (f Formatter) Int(n int64) string {
+	th, _ := f.seps()
+	if n < 0 {
+		// -n 对 MinInt64 溢出，按无符号取绝对值
+		return "-" + group(strconv.FormatUint(uint64(-(n+1))+1, 10), th)
+	}
+	return group(strconv.FormatInt(n, 10), th)
+}
+
+// Uint 格式化无符号整数，规则同 Int
+//
+//	@receiver f Formatter
+//	@param n uint64
+//	@return string
+//	@update 2026-10-18 08:18:50
+func (f Formatter) Uint(n uint64) string {
+	th, _ := f.seps()
+	return group(strconv.FormatUint(n, 10), th)
+}
+
+// Float 格式化浮点数，保留 decimals 位小数并在整数部分插入千分位分隔符；decimals < 0 时使用能精确还原 v 的最短小数位
+//
+//	舍入按 v 的精确二进制值进行，恰好位于中间时舍入到偶数（round-half-even），如 0.125 保留两位为 0.12、2.5 保留零位为 2；
+//	NaN 与 ±Inf 输出为 NaN、+Inf、-Inf
+//
+//	@receiver f Formatter
+//	@param v float64
+//	@param decimals int
+//	@return string
+//	@update 2026-10-18 08:18:50
+func (f Formatter) Float(v float64, decimals int) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	th, dec := f.seps()
+	s := strconv.FormatFloat(v, 'f', max(decimals, -1), 64)
+	sign := ""
+	if s[0] == '-' {
+		sign, s = "-", s[1:]
+		// 舍入后为零时不保留负号，如 -0.001 保留两位为 0.00
+		if strings.Trim(s, "0.") == "" {
+			sign = ""
+		}
+	}
+	intPart, frac, hasFrac := strings.Cut(s, ".")
+	res := sign + group(intPart, th)
+	if hasFrac {
+		res += dec + frac
+	}
+	return res
+}
+
+// group 从右向左每三位插入 sep，digits 不含符号
+func group(digits, sep string) string {
+	if len(digits) <= 3 || sep == "" {
+		return digits
+	}
+	var sb strings.Builder
+	sb.Grow(len(digits) + (len(digits)-1)/3*len(sep))
+	head := len(digits) % 3
+	if head == 0 {
+		head = 3
+	}
+	sb.WriteString(digits[:head])
+	for i := head; i < len(digits); i += 3 {
+		sb.WriteString(sep)
+		sb.WriteString(digits[i : i+3])
+	}
+	return sb.String()
+}
+
+// FormatThousands 使用 Default 格式化整数，如 1234567 -> "1,234,567"
+//
+//	@param n T
+//	@return string
+//	@update 2026-10-18 08:18:50
+func FormatThousands[T Integer](n T) string {
+	if n < 0 {
+		return Default.Int(int64(n))
+	}
+	return Default.Uint(uint64(n))
+}
+
+// FormatFloat 使用 Default 格式化浮点数，规则同 Formatter.Float，如 FormatFloat(1234.5678, 2) -> "1,234.57"
+//
+//	@param v float64
+//	@param decimals int
+//	@return string
+//	@update 2026-10-18 08:18:50
+func FormatFloat(v float64, decimals int) string {
+	return Default.Float(v, decimals)
+}
+
+// Ordinal 返回英文序数词，如 1st、2nd、3rd、4th、11th、12th、13th、21st、102nd
+//
+//	@param n T
+//	@return string
+//	@update 2026-10-18 08:18:50
+func Ordinal[T Integer](n T) string {
+	var s string
+	if n < 0 {
+		s = strconv.FormatInt(int64(n), 10)
+	} else {
+		s = strconv.FormatUint(uint64(n), 10)
+	}
+	// 按末两位数字决定后缀
+	digits := strings.TrimPrefix(s, "-")
+	d, _ := strconv.Atoi(digits[max(len(digits)-2, 0):])
+	switch {
+	case d >= 11 && d <= 13:
+		return s + "th"
+	case d%10 == 1:
+		return s + "st"
+	case d%10 == 2:
+		return s + "nd"
+	case d%10 == 3:
+		return s + "rd"
+	}
+	return s + "th"
+}
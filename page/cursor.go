@@ -0,0 +1,92 @@
+package page
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/BetaGoRobot/go_utils/enc"
+)
+
+// ErrInvalidCursor 游标无法解码，通常应作为参数错误返回给调用方
+var ErrInvalidCursor = errors.New("page: invalid cursor")
+
+// EncodeCursor 将游标令牌编码为不透明的字符串（JSON 后 URL 安全的 base64，无填充），可直接放入查询参数
+//
+//	@param token T
+//	@return string
+//	@return error
+//	@update 2026-10-18 05:36:27
+//
+// for example:
+//
+//	type userCursor struct {
+//		CreatedAt time.Time `json:"t"`
+//		ID        int64     `json:"id"`
+//	}
+//	next, err := page.EncodeCursor(userCursor{last.CreatedAt, last.ID})
+func EncodeCursor[T any](token T) (string, error) {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return "", fmt.Errorf("page: encode cursor: %w", err)
+	}
+	return enc.B64URL(data), nil
+}
+
+// DecodeCursor 解码 EncodeCursor 生成的游标，空字符串返回零值（即第一页）；格式错误时返回的错误满足 errors.Is(err, ErrInvalidCursor)
+//
+//	@param cursor string
+//	@return T
+//	@return error
+//	@update 2026-10-18 05:36:27
+func DecodeCursor[T any](cursor string) (T, error) {
+	var token T
+	if cursor == "" {
+		return token, nil
+	}
+	data, err := enc.B64URLDecodeBytes(cursor)
+	if err != nil {
+		return token, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	if err := json.Unmarshal(data, &token); err != nil {
+		return token, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	return token, nil
+}
+
+// Cursor 游标分页的结果
+type Cursor[T any] struct {
+	Items   []T    `json:"items"`
+	Next    string `json:"next_cursor,omitempty"`
+	HasNext bool   `json:"has_next"`
+}
+
+// CursorPage 由多查询一条的结果构造游标分页：查询 limit+1 条，超过 limit 时截断到 limit 条，
+// 并以最后一条的 token 作为下一页游标
+//
+//	@param items []T 按游标顺序排列的查询结果，最多 limit+1 条
+//	@param limit int <= 0 时视为 DefaultSize
+//	@param token func(T) C 取一条记录对应的游标令牌
+//	@return Cursor[T]
+//	@return error
+//	@update 2026-10-18 05:36:27
+//
+// for example:
+//
+//	after, err := page.DecodeCursor[userCursor](req.Cursor)
+//	rows := db.ListUsersAfter(ctx, after, limit+1)
+//	res, err := page.CursorPage(rows, limit, func(u User) userCursor { return userCursor{u.CreatedAt, u.ID} })
+func CursorPage[T, C any](items []T, limit int, token func(T) C) (Cursor[T], error) {
+	if limit <= 0 {
+		limit = DefaultSize
+	}
+	if len(items) <= limit {
+		return Cursor[T]{Items: items}, nil
+	}
+	items = items[:limit:limit]
+	next, err := EncodeCursor(token(items[limit-1]))
+	if err != nil {
+		return Cursor[T]{}, err
+	}
+	return Cursor[T]{Items: items, Next: next, HasNext: true}, nil
+}
@@ -0,0 +1,96 @@
+// Package page 列表接口的分页工具：页码分页的切片与元信息，以及不透明的游标编解码
+package page
+
+import "math"
+
+// DefaultSize size <= 0 时使用的每页条数
+const DefaultSize = 20
+
+// Meta 页码分页的元信息，Page 从 1 开始
+type Meta struct {
+	Page       int  `json:"page"`
+	Size       int  `json:"size"`
+	Total      int  `json:"total"`
+	TotalPages int  `json:"total_pages"`
+	HasNext    bool `json:"has_next"`
+	HasPrev    bool `json:"has_prev"`
+}
+
+// NewMeta 计算分页元信息，page < 1 视为 1，size <= 0 视为 DefaultSize，total < 0 视为 0
+//
+//	page 超过 TotalPages 时保留原值，HasNext 为 false
+//
+//	@param page int
+//	@param size int
+//	@param total int
+//	@return Meta
+//	@update 2026-10-18 12:06:41
+func NewMeta(page, size, total int) Meta {
+	page, size = Normalize(page, size)
+	total = max(total, 0)
+	// 不用 (total+size-1)/size，避免 total 接近 math.MaxInt 时溢出
+	pages := total / size
+	if total%size != 0 {
+		pages++
+	}
+	return Meta{
+		Page:       page,
+		Size:       size,
+		Total:      total,
+		TotalPages: pages,
+		HasNext:    page < pages,
+		HasPrev:    page > 1,
+	}
+}
+
+// Offset 当前页第一条的下标，可直接用于 SQL 的 OFFSET；页码过大导致乘法溢出时返回 math.MaxInt
+//
+//	@receiver m Meta
+//	@return int
+//	@update 2026-10-18 12:06:41
+func (m Meta) Offset() int {
+	if m.Page <= 1 || m.Size <= 0 {
+		return 0
+	}
+	if m.Page-1 > math.MaxInt/m.Size {
+		return math.MaxInt
+	}
+	return (m.Page - 1) * m.Size
+}
+
+// Normalize 规范化页码与每页条数，规则同 NewMeta
+//
+//	@param page int
+//	@param size int
+//	@return int
+//	@return int
+//	@update 2026-10-18 05:36:27
+func Normalize(page, size int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if size <= 0 {
+		size = DefaultSize
+	}
+	return page, size
+}
+
+// Slice 返回 items 中第 page 页的子切片及分页元信息，子切片与 items 共享底层数组；页码越界时返回空切片
+//
+//	@param items []T
+//	@param page int
+//	@param size int
+//	@return []T
+//	@return Meta
+//	@update 2026-10-18 12:06:41
+//
+// for example:
+//
+//	items, meta := page.Slice(users, 2, 10)
+//	// meta.Offset() == 10, meta.HasNext == len(users) > 20
+func Slice[T any](items []T, page, size int) ([]T, Meta) {
+	m := NewMeta(page, size, len(items))
+	start := min(m.Offset(), len(items))
+	end := start + min(m.Size, len(items)-start)
+	return items[start:end:end], m
+}
@@ -0,0 +1,43 @@
+package page_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/BetaGoRobot/go_utils/page"
+	"github.com/BetaGoRobot/go_utils/testx"
+)
+
+func TestSliceLargeInputs(t *testing.T) {
+	items := make([]int, 50)
+	for i := range items {
+		items[i] = i
+	}
+	tests := []struct {
+		name       string
+		page, size int
+		want       []int
+		offset     int
+	}{
+		{name: "normal", page: 2, size: 20, want: items[20:40], offset: 20},
+		{name: "last partial", page: 3, size: 20, want: items[40:], offset: 40},
+		{name: "past end", page: 4, size: 20, want: []int{}, offset: 60},
+		{name: "max page", page: math.MaxInt, size: 20, want: []int{}, offset: math.MaxInt},
+		{name: "max size", page: 1, size: math.MaxInt, want: items, offset: 0},
+		{name: "max page and size", page: math.MaxInt, size: math.MaxInt, want: []int{}, offset: math.MaxInt},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, meta := page.Slice(items, tt.page, tt.size)
+			testx.DeepEqual(t, tt.want, got)
+			testx.Equal(t, tt.offset, meta.Offset())
+			testx.Equal(t, false, meta.HasNext && len(got) == 0)
+		})
+	}
+}
+
+func TestNewMetaLargeTotal(t *testing.T) {
+	m := page.NewMeta(1, 20, math.MaxInt)
+	testx.Equal(t, math.MaxInt/20+1, m.TotalPages)
+	testx.Equal(t, true, m.HasNext)
+}
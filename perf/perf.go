@@ -0,0 +1,166 @@
+// Package perf 以调用函数名为 key 的轻量计时工具
+package perf
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+// Lap 一次分段计时
+type Lap struct {
+	Label   string
+	Elapsed time.Duration // 距上一次 Lap（或开始）的耗时
+}
+
+// Record 一次完整计时的结果，交给 Sink 处理
+type Record struct {
+	Name    string // 计时所属的函数名，同 reflecting.GetCurrentFunc
+	Start   time.Time
+	Elapsed time.Duration
+	Laps    []Lap
+}
+
+// Stopwatch 一次计时，非并发安全
+type Stopwatch struct {
+	name  string
+	start time.Time
+	last  time.Time
+	laps  []Lap
+	done  bool
+}
+
+var sink atomic.Pointer[Sink]
+
+func init() {
+	s := Sink(LogSink(nil))
+	sink.Store(&s)
+}
+
+// SetSink 替换全局的计时结果处理器，默认以 Debug 级别写入 slog.Default()；传入 nil 时丢弃所有结果
+//
+//	@param s Sink
+//	@update 2026-10-18 01:52:40
+func SetSink(s Sink) {
+	if s == nil {
+		s = Discard
+	}
+	sink.Store(&s)
+}
+
+// NewStopwatch 以指定名称开始计时
+//
+//	@param name string
+//	@return *Stopwatch
+//	@update 2026-10-18 01:52:40
+func NewStopwatch(name string) *Stopwatch {
+	now := time.Now()
+	return &Stopwatch{name: name, start: now, last: now}
+}
+
+// Start 开始计时，名称为调用 Start 的函数名，配合 Done 使用
+//
+//	@return *Stopwatch
+//	@update 2026-10-18 01:52:40
+//
+// for example:
+//
+//	func handle() {
+//		defer perf.Done(perf.Start())
+//		...
+//	}
+func Start() *Stopwatch {
+	return NewStopwatch(reflecting.GetCurrentFuncDepth(2))
+}
+
+// Done 结束计时并将结果交给全局 Sink，s 为 nil 时忽略
+//
+//	@param s *Stopwatch
+//	@update 2026-10-18 01:52:40
+func Done(s *Stopwatch) {
+	if s != nil {
+		s.Stop()
+	}
+}
+
+// Track 开始计时并返回结束计时的函数，名称为调用 Track 的函数名
+//
+//	@return func()
+//	@update 2026-10-18 01:52:40
+//
+// for example:
+//
+//	func handle() {
+//		defer perf.Track()()
+//		...
+//	}
+func Track() func() {
+	s := NewStopwatch(reflecting.GetCurrentFuncDepth(2))
+	return func() { s.Stop() }
+}
+
+// Name 返回计时的名称
+//
+//	@receiver s *Stopwatch
+//	@return string
+//	@update 2026-10-18 01:52:40
+func (s *Stopwatch) Name() string {
+	return s.name
+}
+
+// Elapsed 返回从开始到现在的耗时
+//
+//	@receiver s *Stopwatch
+//	@return time.Duration
+//	@update 2026-10-18 01:52:40
+func (s *Stopwatch) Elapsed() time.Duration {
+	return time.Since(s.start)
+}
+
+// Lap 记录一次分段，返回距上一次分段（或开始）的耗时
+//
+//	@receiver s *Stopwatch
+//	@param label string
+//	@return time.Duration
+//	@update 2026-10-18 01:52:40
+//
+// for example:
+//
+//	sw := perf.Start()
+//	defer perf.Done(sw)
+//	load()
+//	sw.Lap("load")
+//	render()
+//	sw.Lap("render")
+func (s *Stopwatch) Lap(label string) time.Duration {
+	now := time.Now()
+	d := now.Sub(s.last)
+	s.last = now
+	s.laps = append(s.laps, Lap{Label: label, Elapsed: d})
+	return d
+}
+
+// Laps 返回已记录的分段
+//
+//	@receiver s *Stopwatch
+//	@return []Lap
+//	@update 2026-10-18 01:52:40
+func (s *Stopwatch) Laps() []Lap {
+	return s.laps
+}
+
+// Stop 结束计时并将结果交给全局 Sink，返回总耗时；重复调用只上报一次
+//
+//	@receiver s *Stopwatch
+//	@return time.Duration
+//	@update 2026-10-18 01:52:40
+func (s *Stopwatch) Stop() time.Duration {
+	elapsed := time.Since(s.start)
+	if s.done {
+		return elapsed
+	}
+	s.done = true
+	(*sink.Load()).Record(Record{Name: s.name, Start: s.start, Elapsed: elapsed, Laps: s.laps})
+	return elapsed
+}
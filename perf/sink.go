@@ -0,0 +1,172 @@
+package perf
+
+import (
+	"context"
+	"expvar"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Sink 处理计时结果，需要并发安全
+type Sink interface {
+	Record(r Record)
+}
+
+// SinkFunc 函数形式的 Sink
+type SinkFunc func(r Record)
+
+// Record 实现 Sink
+//
+//	@receiver f SinkFunc
+//	@param r Record
+//	@update 2026-10-18 01:52:40
+func (f SinkFunc) Record(r Record) { f(r) }
+
+// Discard 丢弃所有结果的 Sink
+var Discard Sink = SinkFunc(func(Record) {})
+
+// Multi 将结果依次交给多个 Sink
+//
+//	@param sinks ...Sink
+//	@return Sink
+//	@update 2026-10-18 01:52:40
+func Multi(sinks ...Sink) Sink {
+	return SinkFunc(func(r Record) {
+		for _, s := range sinks {
+			s.Record(r)
+		}
+	})
+}
+
+// LogSink 以 Debug 级别将结果写入 logger，logger 为 nil 时使用 slog.Default()
+//
+//	@param logger *slog.Logger
+//	@return Sink
+//	@update 2026-10-18 01:52:40
+func LogSink(logger *slog.Logger) Sink {
+	return LevelLogSink(logger, slog.LevelDebug)
+}
+
+// LevelLogSink 以指定级别将结果写入 logger，logger 为 nil 时使用 slog.Default()
+//
+//	@param logger *slog.Logger
+//	@param level slog.Level
+//	@return Sink
+//	@update 2026-10-18 01:52:40
+func LevelLogSink(logger *slog.Logger, level slog.Level) Sink {
+	return SinkFunc(func(r Record) {
+		l := logger
+		if l == nil {
+			l = slog.Default()
+		}
+		if !l.Enabled(context.Background(), level) {
+			return
+		}
+		attrs := []slog.Attr{slog.String("func", r.Name), slog.Duration("elapsed", r.Elapsed)}
+		if len(r.Laps) > 0 {
+			laps := make([]any, 0, len(r.Laps))
+			for _, lap := range r.Laps {
+				laps = append(laps, slog.Duration(lap.Label, lap.Elapsed))
+			}
+			attrs = append(attrs, slog.Group("laps", laps...))
+		}
+		l.LogAttrs(context.Background(), level, "perf", attrs...)
+	})
+}
+
+// Stats 单个函数的累计计时
+type Stats struct {
+	Count int64         `json:"count"`
+	Total time.Duration `json:"total_ns"`
+	Max   time.Duration `json:"max_ns"`
+}
+
+// Avg 返回平均耗时
+//
+//	@receiver s Stats
+//	@return time.Duration
+//	@update 2026-10-18 01:52:40
+func (s Stats) Avg() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Count)
+}
+
+// Aggregator 按函数名累计计时结果的 Sink
+type Aggregator struct {
+	mu    sync.Mutex
+	stats map[string]Stats
+}
+
+// NewAggregator 创建 Aggregator
+//
+//	@return *Aggregator
+//	@update 2026-10-18 01:52:40
+func NewAggregator() *Aggregator {
+	return &Aggregator{stats: map[string]Stats{}}
+}
+
+// Record 实现 Sink
+//
+//	@receiver a *Aggregator
+//	@param r Record
+//	@update 2026-10-18 01:52:40
+func (a *Aggregator) Record(r Record) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	s := a.stats[r.Name]
+	s.Count++
+	s.Total += r.Elapsed
+	s.Max = max(s.Max, r.Elapsed)
+	a.stats[r.Name] = s
+}
+
+// Snapshot 返回当前累计结果的副本
+//
+//	@receiver a *Aggregator
+//	@return map[string]Stats
+//	@update 2026-10-18 01:52:40
+func (a *Aggregator) Snapshot() map[string]Stats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	res := make(map[string]Stats, len(a.stats))
+	for k, v := range a.stats {
+		res[k] = v
+	}
+	return res
+}
+
+// Reset 清空累计结果
+//
+//	@receiver a *Aggregator
+//	@update 2026-10-18 01:52:40
+func (a *Aggregator) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	clear(a.stats)
+}
+
+var (
+	expvarMu   sync.Mutex
+	expvarAggs = map[string]*Aggregator{}
+)
+
+// ExpvarSink 返回按函数名累计计时的 Aggregator，并以 name 发布到 expvar（/debug/vars）；
+// 同一 name 多次调用返回同一个 Aggregator，name 已被其他 expvar 变量占用时 panic
+//
+//	@param name string
+//	@return *Aggregator
+//	@update 2026-10-18 01:52:40
+func ExpvarSink(name string) *Aggregator {
+	expvarMu.Lock()
+	defer expvarMu.Unlock()
+	if a, ok := expvarAggs[name]; ok {
+		return a
+	}
+	a := NewAggregator()
+	expvar.Publish(name, expvar.Func(func() any { return a.Snapshot() }))
+	expvarAggs[name] = a
+	return a
+}
@@ -0,0 +1,325 @@
+// Package pipeline 分阶段的并发处理流水线：各阶段以有界通道连接，首个错误取消整条流水线并等待所有阶段退出
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"sync"
+	"sync/atomic"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+// ErrSkip 阶段函数返回 ErrSkip 时丢弃当前元素，不视为错误
+var ErrSkip = errors.New("pipeline: skip")
+
+// Stage 流水线中的一个阶段，输出类型为 T；由 Source / FromChan 创建，经 Map、Merge、Tee 组合，最后由 Run / Collect 消费
+type Stage[T any] struct {
+	p   *pipeline
+	out <-chan T
+}
+
+// pipeline 同一条流水线上所有阶段共享的状态
+type pipeline struct {
+	parent context.Context
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	wg     sync.WaitGroup
+	once   sync.Once
+	err    atomic.Pointer[error] // Tee 的各分支在不同 goroutine 中 Run，首个错误需原子读写
+	sinks  atomic.Int64          // 需要 Run 消费的末端阶段数，最后一个 Run 返回时才释放 ctx
+}
+
+type options struct {
+	workers int
+	buffer  int
+}
+
+// Option 阶段选项
+type Option func(*options)
+
+// Workers 阶段的并发数，默认 1；大于 1 时输出顺序不再与输入一致
+//
+//	@param n int
+//	@return Option
+//	@update 2026-10-17 18:31:12
+func Workers(n int) Option {
+	return func(o *options) { o.workers = n }
+}
+
+// Buffer 阶段输出通道的容量，默认 0（无缓冲）
+//
+//	@param n int
+//	@return Option
+//	@update 2026-10-17 18:31:12
+func Buffer(n int) Option {
+	return func(o *options) { o.buffer = n }
+}
+
+func newOptions(opts []Option) options {
+	o := options{workers: 1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	o.workers, o.buffer = max(o.workers, 1), max(o.buffer, 0)
+	return o
+}
+
+// Source 以 seq 作为流水线的输入，创建一条新的流水线
+//
+//	@param ctx context.Context
+//	@param seq iter.Seq[T]
+//	@param opts ...Option 只有 Buffer 生效
+//	@return *Stage[T]
+//	@update 2026-10-17 18:31:12
+//
+// for example:
+//
+//	lines := pipeline.Source(ctx, slices.Values(urls))
+//	pages := pipeline.Map(lines, fetch, pipeline.Workers(8), pipeline.Buffer(16))
+//	docs := pipeline.Map(pages, parse, pipeline.Workers(4))
+//	err := docs.Run(func(ctx context.Context, d Doc) error { return store(ctx, d) })
+func Source[T any](ctx context.Context, seq iter.Seq[T], opts ...Option) *Stage[T] {
+	o := newOptions(opts)
+	p := &pipeline{parent: ctx}
+	p.ctx, p.cancel = context.WithCancelCause(ctx)
+	p.sinks.Store(1)
+	out := make(chan T, o.buffer)
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer close(out)
+		defer p.recover()
+		for v := range seq {
+			if !send(p.ctx, out, v) {
+				return
+			}
+		}
+	}()
+	return &Stage[T]{p: p, out: out}
+}
+
+// FromChan 以通道 ch 作为流水线的输入，ch 关闭或流水线取消时结束
+//
+//	@param ctx context.Context
+//	@param ch <-chan T
+//	@param opts ...Option 只有 Buffer 生效
+//	@return *Stage[T]
+//	@update 2026-10-17 18:31:12
+func FromChan[T any](ctx context.Context, ch <-chan T, opts ...Option) *Stage[T] {
+	return Source(ctx, func(yield func(T) bool) {
+		for {
+			select {
+			case v, ok := <-ch:
+				if !ok || !yield(v) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}, opts...)
+}
+
+// Map 追加一个阶段，对上一阶段的每个元素调用 fn
+//
+//	fn 返回 ErrSkip 时丢弃该元素；返回其他错误或 panic 时取消整条流水线，该错误由 Run / Collect 返回
+//
+//	@param in *Stage[In]
+//	@param fn func(context.Context, In) (Out, error)
+//	@param opts ...Option
+//	@return *Stage[Out]
+//	@update 2026-10-17 18:31:12
+func Map[In, Out any](in *Stage[In], fn func(context.Context, In) (Out, error), opts ...Option) *Stage[Out] {
+	o := newOptions(opts)
+	p := in.p
+	out := make(chan Out, o.buffer)
+	var workers sync.WaitGroup
+	for range o.workers {
+		workers.Add(1)
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			defer workers.Done()
+			// 取消后继续读取上游直到其关闭，保证上游能够退出
+			for v := range in.out {
+				if p.ctx.Err() != nil {
+					continue
+				}
+				res, err := call(p.ctx, fn, v)
+				if errors.Is(err, ErrSkip) {
+					continue
+				}
+				if err != nil {
+					p.fail(err)
+					continue
+				}
+				send(p.ctx, out, res)
+			}
+		}()
+	}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		workers.Wait()
+		close(out)
+	}()
+	return &Stage[Out]{p: p, out: out}
+}
+
+// Merge 将同一条流水线上的多个阶段汇合为一个阶段（扇入），输出顺序不确定
+//
+//	@param stages ...*Stage[T] 须来自同一条流水线，否则 panic
+//	@return *Stage[T]
+//	@update 2026-10-17 18:31:12
+func Merge[T any](stages ...*Stage[T]) *Stage[T] {
+	if len(stages) == 0 {
+		panic("pipeline: Merge needs at least one stage")
+	}
+	p := stages[0].p
+	p.sinks.Add(1 - int64(len(stages)))
+	out := make(chan T)
+	var inputs sync.WaitGroup
+	for _, s := range stages {
+		if s.p != p {
+			panic("pipeline: Merge of stages from different pipelines")
+		}
+		inputs.Add(1)
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			defer inputs.Done()
+			for v := range s.out {
+				if p.ctx.Err() == nil {
+					send(p.ctx, out, v)
+				}
+			}
+		}()
+	}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		inputs.Wait()
+		close(out)
+	}()
+	return &Stage[T]{p: p, out: out}
+}
+
+// Tee 将阶段的每个元素复制到 n 个分支（扇出），每个分支都必须被消费，最慢的分支决定整体速度
+//
+//	先返回的分支 Run 不会取消其他分支，每个分支都能收到全部元素
+//
+//	@param in *Stage[T]
+//	@param n int
+//	@param opts ...Option 只有 Buffer 生效，作用于每个分支
+//	@return []*Stage[T]
+//	@update 2026-10-18 12:39:17
+func Tee[T any](in *Stage[T], n int, opts ...Option) []*Stage[T] {
+	o := newOptions(opts)
+	p := in.p
+	p.sinks.Add(int64(n) - 1)
+	outs := make([]chan T, n)
+	stages := make([]*Stage[T], n)
+	for i := range outs {
+		outs[i] = make(chan T, o.buffer)
+		stages[i] = &Stage[T]{p: p, out: outs[i]}
+	}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer func() {
+			for _, out := range outs {
+				close(out)
+			}
+		}()
+		for v := range in.out {
+			for _, out := range outs {
+				if p.ctx.Err() == nil {
+					send(p.ctx, out, v)
+				}
+			}
+		}
+	}()
+	return stages
+}
+
+// Run 在当前 goroutine 中以 sink 消费最后一个阶段，并等待流水线上所有 goroutine 退出
+//
+//	返回首个阶段错误（含 sink 的错误）；没有阶段出错但 ctx 被取消时返回 ctx.Err()
+//
+//	@receiver s *Stage[T]
+//	@param sink func(context.Context, T) error
+//	@return error
+//	@update 2026-10-18 12:39:17
+func (s *Stage[T]) Run(sink func(context.Context, T) error) error {
+	p := s.p
+	for v := range s.out {
+		if p.ctx.Err() != nil {
+			continue
+		}
+		if _, err := call(p.ctx, func(ctx context.Context, v T) (struct{}, error) {
+			return struct{}{}, sink(ctx, v)
+		}, v); err != nil && !errors.Is(err, ErrSkip) {
+			p.fail(err)
+		}
+	}
+	p.wg.Wait()
+	// Tee 的其他分支可能仍在消费，提前取消会使其丢弃已收到的元素
+	if p.sinks.Add(-1) <= 0 {
+		defer p.cancel(nil)
+	}
+	if err := p.err.Load(); err != nil {
+		return *err
+	}
+	return p.parent.Err()
+}
+
+// Collect 消费最后一个阶段并返回所有元素
+//
+//	@param s *Stage[T]
+//	@return []T
+//	@return error
+//	@update 2026-10-17 18:31:12
+func Collect[T any](s *Stage[T]) ([]T, error) {
+	var res []T
+	err := s.Run(func(_ context.Context, v T) error {
+		res = append(res, v)
+		return nil
+	})
+	return res, err
+}
+
+// fail 记录首个错误并取消流水线
+func (p *pipeline) fail(err error) {
+	p.once.Do(func() {
+		p.err.Store(&err)
+		p.cancel(err)
+	})
+}
+
+// recover 将 Source 中迭代器的 panic 转为流水线错误
+func (p *pipeline) recover() {
+	if r := recover(); r != nil {
+		p.fail(reflecting.NewPanicError(r))
+	}
+}
+
+// call 调用阶段函数，panic 转为 *reflecting.PanicError
+func call[In, Out any](ctx context.Context, fn func(context.Context, In) (Out, error), v In) (res Out, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = reflecting.NewPanicError(r)
+		}
+	}()
+	return fn(ctx, v)
+}
+
+func send[T any](ctx context.Context, ch chan<- T, v T) bool {
+	select {
+	case ch <- v:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,81 @@
+package pipeline_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/BetaGoRobot/go_utils/pipeline"
+	"github.com/BetaGoRobot/go_utils/testx"
+)
+
+func values(n int) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = i
+	}
+	return s
+}
+
+// runBranches 在各自的 goroutine 中 Run 每个分支，返回各分支收到的元素与错误
+func runBranches(branches []*pipeline.Stage[int], sink func(branch, v int) error) ([][]int, []error) {
+	got := make([][]int, len(branches))
+	errs := make([]error, len(branches))
+	var wg sync.WaitGroup
+	for i, b := range branches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = b.Run(func(_ context.Context, v int) error {
+				got[i] = append(got[i], v)
+				return sink(i, v)
+			})
+		}()
+	}
+	wg.Wait()
+	return got, errs
+}
+
+// TestTeeAllBranchesReceiveAll 先结束的分支不应取消仍在消费的分支
+func TestTeeAllBranchesReceiveAll(t *testing.T) {
+	seq := values(100)
+	for range 50 {
+		branches := pipeline.Tee(pipeline.Source(context.Background(), slices.Values(seq)), 3)
+		got, errs := runBranches(branches, func(int, int) error { return nil })
+		for i := range branches {
+			testx.ErrorIs(t, errs[i], nil)
+			testx.DeepEqual(t, seq, got[i], "branch %d", i)
+		}
+	}
+}
+
+func TestTeeThenMerge(t *testing.T) {
+	seq := values(100)
+	merged := pipeline.Merge(pipeline.Tee(pipeline.Source(context.Background(), slices.Values(seq)), 2)...)
+	got, err := pipeline.Collect(merged)
+	testx.ErrorIs(t, err, nil)
+	slices.Sort(got)
+	want := slices.Sorted(slices.Values(append(slices.Clone(seq), seq...)))
+	testx.DeepEqual(t, want, got)
+}
+
+// TestTeeBranchError 一个分支在最后一个元素上出错时，其他分支的 Run 可能已在读取错误；配合 -race 检查
+func TestTeeBranchError(t *testing.T) {
+	errBoom := errors.New("boom")
+	seq := values(100)
+	for range 20 {
+		branches := pipeline.Tee(pipeline.Source(context.Background(), slices.Values(seq)), 2)
+		_, errs := runBranches(branches, func(branch, v int) error {
+			if branch == 0 && v == len(seq)-1 {
+				return errBoom
+			}
+			return nil
+		})
+		testx.ErrorIs(t, errs[0], errBoom)
+		if errs[1] != nil {
+			testx.ErrorIs(t, errs[1], errBoom)
+		}
+	}
+}
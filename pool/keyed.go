@@ -0,0 +1,174 @@
+package pool
+
+import (
+	"context"
+	"hash/maphash"
+	"sync"
+	"sync/atomic"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+// KeyedPool 按 key 哈希分配 worker 的任务池：同一 key 的任务由同一个 worker 按提交顺序串行处理，
+// 不同 key 的任务并行处理，适合消费分区消息流
+type KeyedPool[K comparable, T any] struct {
+	handler func(context.Context, K, T) error
+	onError atomic.Pointer[func(K, T, error)]
+	queues  []chan keyedTask[K, T]
+	seed    maphash.Seed
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	mu      sync.RWMutex
+	closed  bool
+	quit    chan struct{}  // Shutdown 时关闭，唤醒阻塞在队列上的 Submit
+	senders sync.WaitGroup // 进行中的 Submit，全部返回后才关闭队列
+	done    chan struct{}
+}
+
+type keyedTask[K comparable, T any] struct {
+	key  K
+	task T
+}
+
+// NewKeyed 创建并启动 workers 个 worker 的按 key 有序任务池
+//
+//	WithQueueSize 为每个 worker 的队列容量；某个 key 的队列满时，该 key 的 Submit 阻塞。
+//	handler 返回的错误与恢复的 panic（*reflecting.PanicError）交给 OnError 回调，不影响后续任务
+//
+//	@param workers int
+//	@param handler func(context.Context, K, T) error
+//	@param opts ...Option
+//	@return *KeyedPool[K, T]
+//	@update 2026-10-18 14:00:36
+//
+// for example:
+//
+//	p := pool.NewKeyed(16, handleEvent).OnError(func(orderID string, ev Event, err error) {
+//		log.Printf("handle %s: %v", orderID, err)
+//	})
+//	_ = p.Submit(ctx, ev.OrderID, ev)
+func NewKeyed[K comparable, T any](workers int, handler func(context.Context, K, T) error, opts ...Option) *KeyedPool[K, T] {
+	workers = max(workers, 1)
+	o := options{queueSize: 1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	p := &KeyedPool[K, T]{
+		handler: handler,
+		queues:  make([]chan keyedTask[K, T], workers),
+		seed:    maphash.MakeSeed(),
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	p.ctx, p.cancel = context.WithCancel(context.Background())
+
+	var wg sync.WaitGroup
+	for i := range p.queues {
+		p.queues[i] = make(chan keyedTask[K, T], max(o.queueSize, 0))
+		wg.Add(1)
+		go func(queue chan keyedTask[K, T]) {
+			defer wg.Done()
+			for t := range queue {
+				if err := p.run(t); err != nil {
+					if fn := p.onError.Load(); fn != nil {
+						(*fn)(t.key, t.task, err)
+					}
+				}
+			}
+		}(p.queues[i])
+	}
+	go func() {
+		wg.Wait()
+		p.cancel()
+		close(p.done)
+	}()
+	return p
+}
+
+// OnError 任务失败（返回错误或 panic）时在 worker 中调用 fn，fn 为 nil 时取消回调；返回 p 以便链式调用
+//
+//	应在 Submit 之前调用，调用之前已完成的任务不会回调
+//
+//	@receiver p *KeyedPool[K, T]
+//	@param fn func(key K, task T, err error)
+//	@return *KeyedPool[K, T]
+//	@update 2026-10-18 14:00:36
+func (p *KeyedPool[K, T]) OnError(fn func(key K, task T, err error)) *KeyedPool[K, T] {
+	if fn == nil {
+		p.onError.Store(nil)
+	} else {
+		p.onError.Store(&fn)
+	}
+	return p
+}
+
+// Submit 将任务提交到 key 对应的 worker，队列满时阻塞直到有空位、ctx 结束或池关闭；池关闭后返回 ErrClosed
+//
+//	@receiver p *KeyedPool[K, T]
+//	@param ctx context.Context
+//	@param key K
+//	@param task T
+//	@return error
+//	@update 2026-10-18 10:45:22
+func (p *KeyedPool[K, T]) Submit(ctx context.Context, key K, task T) error {
+	p.mu.RLock()
+	if p.closed {
+		p.mu.RUnlock()
+		return ErrClosed
+	}
+	p.senders.Add(1)
+	p.mu.RUnlock()
+	defer p.senders.Done()
+
+	select {
+	case p.queue(key) <- keyedTask[K, T]{key: key, task: task}:
+		return nil
+	case <-p.quit:
+		return ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Shutdown 停止接受新任务，等待所有队列中的任务处理完毕，语义同 Pool.Shutdown
+//
+//	@receiver p *KeyedPool[K, T]
+//	@param ctx context.Context
+//	@return error
+//	@update 2026-10-18 10:45:22
+func (p *KeyedPool[K, T]) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.quit)
+		go func() {
+			p.senders.Wait()
+			for _, q := range p.queues {
+				close(q)
+			}
+		}()
+	}
+	p.mu.Unlock()
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+		return ctx.Err()
+	}
+}
+
+func (p *KeyedPool[K, T]) queue(key K) chan keyedTask[K, T] {
+	return p.queues[maphash.Comparable(p.seed, key)%uint64(len(p.queues))]
+}
+
+func (p *KeyedPool[K, T]) run(t keyedTask[K, T]) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = reflecting.NewPanicError(r)
+		}
+	}()
+	return p.handler(p.ctx, t.key, t.task)
+}
@@ -0,0 +1,42 @@
+package pool_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/BetaGoRobot/go_utils/pool"
+	"github.com/BetaGoRobot/go_utils/reflecting"
+	"github.com/BetaGoRobot/go_utils/testx"
+)
+
+func TestKeyedPoolOrderAndOnError(t *testing.T) {
+	var mu sync.Mutex
+	order := map[string][]int{}
+	var failed []error
+	p := pool.NewKeyed(4, func(_ context.Context, key string, n int) error {
+		if n < 0 {
+			panic("negative")
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		order[key] = append(order[key], n)
+		return nil
+	}).OnError(func(_ string, _ int, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		failed = append(failed, err)
+	})
+	for i := range 20 {
+		testx.ErrorIs(t, p.Submit(context.Background(), []string{"a", "b"}[i%2], i), nil)
+	}
+	testx.ErrorIs(t, p.Submit(context.Background(), "a", -1), nil)
+	testx.ErrorIs(t, p.Shutdown(context.Background()), nil)
+
+	testx.DeepEqual(t, []int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18}, order["a"])
+	testx.DeepEqual(t, []int{1, 3, 5, 7, 9, 11, 13, 15, 17, 19}, order["b"])
+	testx.Equal(t, 1, len(failed))
+	var pe *reflecting.PanicError
+	testx.Equal(t, true, errors.As(failed[0], &pe))
+}
@@ -0,0 +1,30 @@
+package pool
+
+type options struct {
+	queueSize    int
+	resultBuffer int
+	results      bool
+}
+
+// Option New 与 NewKeyed 的选项
+type Option func(*options)
+
+// WithQueueSize 任务队列容量，队列满时 Submit 阻塞（背压）；Pool 默认与 worker 数相同，KeyedPool 为每个 worker 的队列容量，默认 1
+//
+//	@param n int
+//	@return Option
+//	@update 2026-10-17 17:48:03
+func WithQueueSize(n int) Option {
+	return func(o *options) { o.queueSize = n }
+}
+
+// WithResults 开启 Results() 结果通道，buffer 为通道容量
+//
+//	结果通道需要被持续读取，否则 worker 会阻塞在发送结果上
+//
+//	@param buffer int
+//	@return Option
+//	@update 2026-10-17 17:48:03
+func WithResults(buffer int) Option {
+	return func(o *options) { o.results, o.resultBuffer = true, buffer }
+}
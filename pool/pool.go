@@ -0,0 +1,220 @@
+// Package pool 泛型的 worker 池：有界队列、优雅关闭与逐任务的 panic 恢复
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+// ErrClosed 池已关闭，不再接受任务
+var ErrClosed = errors.New("pool: closed")
+
+// ErrQueueFull TrySubmit 时队列已满
+var ErrQueueFull = errors.New("pool: queue full")
+
+// Result 单个任务的处理结果
+type Result[T, R any] struct {
+	Task  T
+	Value R
+	Err   error // handler 返回的错误；handler panic 时为 *reflecting.PanicError
+}
+
+// Pool 固定数量 worker 的任务池，任务类型为 T、结果类型为 R
+type Pool[T, R any] struct {
+	handler  func(context.Context, T) (R, error)
+	queue    chan T
+	results  chan Result[T, R]
+	onResult atomic.Pointer[func(T, R, error)]
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	mu      sync.RWMutex
+	closed  bool
+	quit    chan struct{}  // Shutdown 时关闭，唤醒阻塞在队列上的 Submit
+	senders sync.WaitGroup // 进行中的 Submit，全部返回后才关闭队列
+	done    chan struct{}
+}
+
+// New 创建并启动 workers 个 worker 的任务池，每个任务由 handler 处理
+//
+//	handler 收到的 ctx 在 Shutdown 超时后被取消；handler 的 panic 会被恢复并作为该任务的错误
+//
+//	@param workers int
+//	@param handler func(context.Context, T) (R, error)
+//	@param opts ...Option
+//	@return *Pool[T, R]
+//	@update 2026-10-18 14:00:36
+//
+// for example:
+//
+//	p := pool.New(8, fetch, pool.WithQueueSize(100)).OnResult(func(url string, body []byte, err error) {
+//		...
+//	})
+//	for _, url := range urls {
+//		_ = p.Submit(ctx, url)
+//	}
+//	_ = p.Shutdown(ctx)
+func New[T, R any](workers int, handler func(context.Context, T) (R, error), opts ...Option) *Pool[T, R] {
+	workers = max(workers, 1)
+	o := options{queueSize: workers}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	p := &Pool[T, R]{
+		handler: handler,
+		queue:   make(chan T, max(o.queueSize, 0)),
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	if o.results {
+		p.results = make(chan Result[T, R], max(o.resultBuffer, 0))
+	}
+	p.ctx, p.cancel = context.WithCancel(context.Background())
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.work()
+		}()
+	}
+	go func() {
+		wg.Wait()
+		if p.results != nil {
+			close(p.results)
+		}
+		p.cancel()
+		close(p.done)
+	}()
+	return p
+}
+
+// OnResult 每个任务完成后在 worker 中调用 fn，fn 为 nil 时取消回调；返回 p 以便链式调用
+//
+//	应在 Submit 之前调用，调用之前已完成的任务不会回调
+//
+//	@receiver p *Pool[T, R]
+//	@param fn func(task T, value R, err error)
+//	@return *Pool[T, R]
+//	@update 2026-10-18 14:00:36
+func (p *Pool[T, R]) OnResult(fn func(task T, value R, err error)) *Pool[T, R] {
+	if fn == nil {
+		p.onResult.Store(nil)
+	} else {
+		p.onResult.Store(&fn)
+	}
+	return p
+}
+
+// Submit 提交任务，队列满时阻塞直到有空位、ctx 结束或池关闭；池关闭后返回 ErrClosed
+//
+//	@receiver p *Pool[T, R]
+//	@param ctx context.Context
+//	@param task T
+//	@return error
+//	@update 2026-10-18 10:45:22
+func (p *Pool[T, R]) Submit(ctx context.Context, task T) error {
+	p.mu.RLock()
+	if p.closed {
+		p.mu.RUnlock()
+		return ErrClosed
+	}
+	p.senders.Add(1)
+	p.mu.RUnlock()
+	defer p.senders.Done()
+
+	select {
+	case p.queue <- task:
+		return nil
+	case <-p.quit:
+		return ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// TrySubmit 提交任务，队列满时立即返回 ErrQueueFull
+//
+//	@receiver p *Pool[T, R]
+//	@param task T
+//	@return error
+//	@update 2026-10-17 17:48:03
+func (p *Pool[T, R]) TrySubmit(task T) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrClosed
+	}
+	select {
+	case p.queue <- task:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Results 返回结果通道，未通过 WithResults 开启时为 nil；所有 worker 退出后通道关闭
+//
+//	@receiver p *Pool[T, R]
+//	@return <-chan Result[T, R]
+//	@update 2026-10-17 17:48:03
+func (p *Pool[T, R]) Results() <-chan Result[T, R] {
+	return p.results
+}
+
+// Shutdown 停止接受新任务（阻塞中的 Submit 返回 ErrClosed），等待队列中的任务处理完毕
+//
+//	ctx 先结束时取消 handler 的 ctx 并返回 ctx.Err()；worker 不会丢弃任务，而是以已取消的 ctx
+//	继续处理队列中剩余的任务后退出，handler 应检查 ctx 以尽快返回；可重复调用
+//
+//	@receiver p *Pool[T, R]
+//	@param ctx context.Context
+//	@return error
+//	@update 2026-10-18 10:45:22
+func (p *Pool[T, R]) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.quit)
+		go func() {
+			p.senders.Wait()
+			close(p.queue)
+		}()
+	}
+	p.mu.Unlock()
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+		return ctx.Err()
+	}
+}
+
+func (p *Pool[T, R]) work() {
+	for task := range p.queue {
+		v, err := p.run(task)
+		if fn := p.onResult.Load(); fn != nil {
+			(*fn)(task, v, err)
+		}
+		if p.results != nil {
+			p.results <- Result[T, R]{Task: task, Value: v, Err: err}
+		}
+	}
+}
+
+// run 执行单个任务，handler 的 panic 转换为 *reflecting.PanicError
+func (p *Pool[T, R]) run(task T) (v R, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = reflecting.NewPanicError(r)
+		}
+	}()
+	return p.handler(p.ctx, task)
+}
@@ -0,0 +1,48 @@
+package pool_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/BetaGoRobot/go_utils/pool"
+	"github.com/BetaGoRobot/go_utils/testx"
+)
+
+var errOdd = errors.New("odd")
+
+func double(_ context.Context, n int) (int, error) {
+	if n%2 == 1 {
+		return 0, errOdd
+	}
+	return n * 2, nil
+}
+
+func TestPoolOnResult(t *testing.T) {
+	var mu sync.Mutex
+	results, failed := map[int]int{}, map[int]error{}
+	p := pool.New(4, double, pool.WithQueueSize(8)).OnResult(func(task, v int, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			failed[task] = err
+			return
+		}
+		results[task] = v
+	})
+	for i := range 10 {
+		testx.ErrorIs(t, p.Submit(context.Background(), i), nil)
+	}
+	testx.ErrorIs(t, p.Shutdown(context.Background()), nil)
+
+	testx.Equal(t, 5, len(results))
+	testx.Equal(t, 5, len(failed))
+	for i := range 10 {
+		if i%2 == 1 {
+			testx.ErrorIs(t, failed[i], errOdd)
+			continue
+		}
+		testx.Equal(t, i*2, results[i])
+	}
+}
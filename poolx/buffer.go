@@ -0,0 +1,146 @@
+package poolx
+
+import (
+	"bytes"
+	"math/bits"
+)
+
+const (
+	defaultMaxRetained = 64 << 10
+	minClassBits       = 6 // 最小的容量等级为 64 字节
+)
+
+// NewBuffer 创建 *bytes.Buffer 池，放回时 Reset，容量超过 MaxRetained 的缓冲被丢弃
+//
+//	@param opts ...Option 支持 MaxRetained
+//	@return *Pool[*bytes.Buffer]
+//	@update 2026-10-18 06:24:15
+func NewBuffer(opts ...Option) *Pool[*bytes.Buffer] {
+	o := options{maxRetained: defaultMaxRetained}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return New(func() *bytes.Buffer { return new(bytes.Buffer) },
+		WithReset((*bytes.Buffer).Reset),
+		WithKeep(func(b *bytes.Buffer) bool { return b.Cap() <= o.maxRetained }),
+	)
+}
+
+// Bytes 按容量分级（2 的幂，最小 64 字节）的 []byte 池，每一级一个 sync.Pool，避免小请求拿到大缓冲
+//
+//	使用 *[]byte 以免放回时为切片头分配内存
+type Bytes struct {
+	classes []*Pool[*[]byte]
+	max     int
+}
+
+// NewBytes 创建分级的 []byte 池，超过 MaxRetained 的请求直接分配且不放回
+//
+//	@param opts ...Option 支持 MaxRetained
+//	@return *Bytes
+//	@update 2026-10-18 06:24:15
+//
+// for example:
+//
+//	var bufs = poolx.NewBytes(poolx.MaxRetained(1 << 20))
+//
+//	bp := bufs.Get(n)
+//	defer bufs.Put(bp)
+//	buf := *bp // len(buf) == n
+func NewBytes(opts ...Option) *Bytes {
+	o := options{maxRetained: defaultMaxRetained}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	b := &Bytes{max: max(o.maxRetained, 1<<minClassBits)}
+	for size := 1 << minClassBits; size <= b.max; size <<= 1 {
+		b.classes = append(b.classes, New(func() *[]byte {
+			buf := make([]byte, 0, size)
+			return &buf
+		}))
+	}
+	return b
+}
+
+// Get 返回长度为 n、容量不小于 n 的缓冲，内容未清零
+//
+//	@receiver b *Bytes
+//	@param n int
+//	@return *[]byte
+//	@update 2026-10-18 06:24:15
+func (b *Bytes) Get(n int) *[]byte {
+	n = max(n, 0)
+	i := classFor(n)
+	if i >= len(b.classes) {
+		buf := make([]byte, n)
+		return &buf
+	}
+	bp := b.classes[i].Get()
+	*bp = (*bp)[:n]
+	return bp
+}
+
+// Put 将缓冲放回容量不超过其 cap 的最大一级，容量不足 64 字节或超过 MaxRetained 时丢弃
+//
+//	@receiver b *Bytes
+//	@param bp *[]byte
+//	@update 2026-10-18 06:24:15
+func (b *Bytes) Put(bp *[]byte) {
+	if bp == nil {
+		return
+	}
+	c := cap(*bp)
+	if c < 1<<minClassBits || c > b.max {
+		return
+	}
+	// 向下取整到等级，保证从该等级取出的缓冲容量足够
+	i := bits.Len(uint(c)) - 1 - minClassBits
+	if i >= len(b.classes) {
+		i = len(b.classes) - 1
+	}
+	*bp = (*bp)[:0]
+	b.classes[i].Put(bp)
+}
+
+// classFor 返回容量不小于 n 的最小等级
+func classFor(n int) int {
+	if n <= 1<<minClassBits {
+		return 0
+	}
+	return bits.Len(uint(n-1)) - minClassBits
+}
+
+var (
+	defaultBuffers = NewBuffer()
+	defaultBytes   = NewBytes()
+)
+
+// GetBuffer 从默认的 *bytes.Buffer 池取出一个空缓冲
+//
+//	@return *bytes.Buffer
+//	@update 2026-10-18 06:24:15
+//
+// for example:
+//
+//	buf := poolx.GetBuffer()
+//	defer poolx.PutBuffer(buf)
+func GetBuffer() *bytes.Buffer { return defaultBuffers.Get() }
+
+// PutBuffer 将缓冲放回默认池
+//
+//	@param b *bytes.Buffer
+//	@update 2026-10-18 06:24:15
+func PutBuffer(b *bytes.Buffer) { defaultBuffers.Put(b) }
+
+// GetBytes 从默认的分级池取出长度为 n 的缓冲
+//
+//	@param n int
+//	@return *[]byte
+//	@update 2026-10-18 06:24:15
+func GetBytes(n int) *[]byte { return defaultBytes.Get(n) }
+
+// PutBytes 将缓冲放回默认的分级池
+//
+//	@param bp *[]byte
+//	@update 2026-10-18 06:24:15
+func PutBytes(bp *[]byte) { defaultBytes.Put(bp) }
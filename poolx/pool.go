@@ -0,0 +1,96 @@
+// Package poolx 带类型的 sync.Pool 封装，以及 bytes.Buffer 与按容量分级的 []byte 池
+package poolx
+
+import "sync"
+
+type options struct {
+	maxRetained int
+	minSize     int
+}
+
+// Option 缓冲池构造函数（NewBuffer、NewBytes）的选项
+type Option func(*options)
+
+// PoolOption New 的选项，T 与 Pool 的元素类型一致，可由回调推断
+type PoolOption[T any] func(*Pool[T])
+
+// WithReset 对象放回池之前调用 fn 清理状态
+//
+//	@param fn func(T)
+//	@return PoolOption[T]
+//	@update 2026-10-18 13:43:52
+func WithReset[T any](fn func(T)) PoolOption[T] {
+	return func(p *Pool[T]) { p.reset = fn }
+}
+
+// WithKeep fn 返回 false 的对象不放回池，用于丢弃过大的对象
+//
+//	@param fn func(T) bool
+//	@return PoolOption[T]
+//	@update 2026-10-18 13:43:52
+func WithKeep[T any](fn func(T) bool) PoolOption[T] {
+	return func(p *Pool[T]) { p.keep = fn }
+}
+
+// MaxRetained 缓冲池中保留的最大容量（字节），更大的缓冲在放回时被丢弃以免长期占用内存，默认 64KB
+//
+//	@param n int
+//	@return Option
+//	@update 2026-10-18 06:24:15
+func MaxRetained(n int) Option {
+	return func(o *options) { o.maxRetained = n }
+}
+
+// Pool 带类型的对象池，零值不可用，由 New 创建
+type Pool[T any] struct {
+	p     sync.Pool
+	reset func(T)
+	keep  func(T) bool
+}
+
+// New 创建对象池，池为空时由 newFn 创建对象
+//
+//	@param newFn func() T
+//	@param opts ...PoolOption[T]
+//	@return *Pool[T]
+//	@update 2026-10-18 13:43:52
+//
+// for example:
+//
+//	var encoders = poolx.New(func() *Encoder { return NewEncoder() },
+//		poolx.WithReset(func(e *Encoder) { e.Reset() }))
+//
+//	e := encoders.Get()
+//	defer encoders.Put(e)
+func New[T any](newFn func() T, opts ...PoolOption[T]) *Pool[T] {
+	p := &Pool[T]{}
+	p.p.New = func() any { return newFn() }
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Get 从池中取出对象，池为空时新建
+//
+//	@receiver p *Pool[T]
+//	@return T
+//	@update 2026-10-18 06:24:15
+func (p *Pool[T]) Get() T {
+	return p.p.Get().(T)
+}
+
+// Put 清理后将对象放回池，WithKeep 返回 false 时丢弃；放回后调用方不得再使用 v
+//
+//	@receiver p *Pool[T]
+//	@param v T
+//	@update 2026-10-18 06:24:15
+func (p *Pool[T]) Put(v T) {
+	if p.keep != nil && !p.keep(v) {
+		return
+	}
+	if p.reset != nil {
+		p.reset(v)
+	}
+	p.p.Put(v)
+}
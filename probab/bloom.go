@@ -0,0 +1,220 @@
+// Package probab 概率数据结构：布隆过滤器与支持删除的计数布隆过滤器
+package probab
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+	"sync/atomic"
+
+	"github.com/BetaGoRobot/go_utils/hashutil"
+)
+
+var (
+	// ErrIncompatible 两个过滤器的参数（位数、哈希函数个数）不同，无法合并
+	ErrIncompatible = errors.New("probab: incompatible filters")
+	// ErrInvalidData 反序列化的数据格式错误
+	ErrInvalidData = errors.New("probab: invalid data")
+)
+
+const (
+	bloomMagic    = "BLM1"
+	countingMagic = "CBF1"
+	headerSize    = 4 + 4 + 8 // magic + k + m
+)
+
+// Bloom 布隆过滤器，MayContain 为 false 时元素一定不存在，为 true 时可能存在；并发安全
+type Bloom struct {
+	m    uint64 // 位数
+	k    uint32 // 哈希函数个数
+	bits []uint64
+}
+
+// NewBloom 按预期元素数 n 与期望误判率 fpRate 创建布隆过滤器，n < 1 视为 1，fpRate 取值 (0, 1)，非法时使用 0.01
+//
+//	@param n uint64
+//	@param fpRate float64
+//	@return *Bloom
+//	@update 2026-10-18 06:57:19
+//
+// for example:
+//
+//	seen := probab.NewBloom(1_000_000, 0.001)
+//	seen.AddString(userID)
+//	if !seen.MayContainString(id) {
+//		return ErrNotFound // 不必查库
+//	}
+func NewBloom(n uint64, fpRate float64) *Bloom {
+	m, k := optimal(n, fpRate)
+	return &Bloom{m: m, k: k, bits: make([]uint64, (m+63)/64)}
+}
+
+// optimal 计算最优位数 m = -n·ln(p)/ln(2)² 与哈希函数个数 k = m/n·ln(2)
+func optimal(n uint64, p float64) (uint64, uint32) {
+	n = max(n, 1)
+	if !(p > 0 && p < 1) {
+		p = 0.01
+	}
+	m := uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	m = max(m, 64)
+	k := uint32(math.Round(float64(m) / float64(n) * math.Ln2))
+	return m, min(max(k, 1), 32)
+}
+
+// locations 以双重哈希 h1 + i·h2 生成 k 个位置
+func locations(data []byte, k uint32, m uint64, fn func(uint64) bool) bool {
+	h1 := hashutil.Sum64(data)
+	h2 := mix(h1) | 1
+	for i := range uint64(k) {
+		if !fn((h1 + i*h2) % m) {
+			return false
+		}
+	}
+	return true
+}
+
+// mix splitmix64 的终结函数，由 h1 派生第二个哈希
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// Add 加入元素
+//
+//	@receiver b *Bloom
+//	@param data []byte
+//	@update 2026-10-18 06:57:19
+func (b *Bloom) Add(data []byte) {
+	locations(data, b.k, b.m, func(i uint64) bool {
+		atomic.OrUint64(&b.bits[i/64], 1<<(i%64))
+		return true
+	})
+}
+
+// AddString 加入字符串元素
+//
+//	@receiver b *Bloom
+//	@param s string
+//	@update 2026-10-18 06:57:19
+func (b *Bloom) AddString(s string) {
+	b.Add([]byte(s))
+}
+
+// MayContain 判断元素是否可能存在
+//
+//	@receiver b *Bloom
+//	@param data []byte
+//	@return bool
+//	@update 2026-10-18 06:57:19
+func (b *Bloom) MayContain(data []byte) bool {
+	return locations(data, b.k, b.m, func(i uint64) bool {
+		return atomic.LoadUint64(&b.bits[i/64])&(1<<(i%64)) != 0
+	})
+}
+
+// MayContainString 判断字符串元素是否可能存在
+//
+//	@receiver b *Bloom
+//	@param s string
+//	@return bool
+//	@update 2026-10-18 06:57:19
+func (b *Bloom) MayContainString(s string) bool {
+	return b.MayContain([]byte(s))
+}
+
+// Merge 将 other 中的元素并入 b，两者须由相同的 n、fpRate 创建，否则返回 ErrIncompatible
+//
+//	@receiver b *Bloom
+//	@param other *Bloom
+//	@return error
+//	@update 2026-10-18 06:57:19
+func (b *Bloom) Merge(other *Bloom) error {
+	if b.m != other.m || b.k != other.k {
+		return ErrIncompatible
+	}
+	for i := range b.bits {
+		atomic.OrUint64(&b.bits[i], atomic.LoadUint64(&other.bits[i]))
+	}
+	return nil
+}
+
+// EstimatedCount 按置位比例估算已加入的不同元素个数
+//
+//	@receiver b *Bloom
+//	@return uint64
+//	@update 2026-10-18 06:57:19
+func (b *Bloom) EstimatedCount() uint64 {
+	var set int
+	for i := range b.bits {
+		set += bits.OnesCount64(atomic.LoadUint64(&b.bits[i]))
+	}
+	if set == 0 {
+		return 0
+	}
+	ratio := float64(set) / float64(b.m)
+	if ratio >= 1 {
+		return math.MaxUint64
+	}
+	return uint64(math.Round(-float64(b.m) / float64(b.k) * math.Log(1-ratio)))
+}
+
+// MarshalBinary 实现 encoding.BinaryMarshaler，格式为 "BLM1" + k(uint32) + m(uint64) + 位数组，均为小端序
+//
+//	@receiver b *Bloom
+//	@return []byte
+//	@return error
+//	@update 2026-10-18 06:57:19
+func (b *Bloom) MarshalBinary() ([]byte, error) {
+	data := make([]byte, headerSize, headerSize+len(b.bits)*8)
+	putHeader(data, bloomMagic, b.k, b.m)
+	for i := range b.bits {
+		data = binary.LittleEndian.AppendUint64(data, atomic.LoadUint64(&b.bits[i]))
+	}
+	return data, nil
+}
+
+// UnmarshalBinary 实现 encoding.BinaryUnmarshaler，替换 b 的全部内容；不应与其他方法并发调用
+//
+//	@receiver b *Bloom
+//	@param data []byte
+//	@return error
+//	@update 2026-10-18 06:57:19
+func (b *Bloom) UnmarshalBinary(data []byte) error {
+	k, m, body, err := readHeader(data, bloomMagic)
+	if err != nil {
+		return err
+	}
+	words := (m + 63) / 64
+	if uint64(len(body)) != words*8 {
+		return ErrInvalidData
+	}
+	words64 := make([]uint64, words)
+	for i := range words64 {
+		words64[i] = binary.LittleEndian.Uint64(body[i*8:])
+	}
+	b.m, b.k, b.bits = m, k, words64
+	return nil
+}
+
+func putHeader(data []byte, magic string, k uint32, m uint64) {
+	copy(data, magic)
+	binary.LittleEndian.PutUint32(data[4:], k)
+	binary.LittleEndian.PutUint64(data[8:], m)
+}
+
+func readHeader(data []byte, magic string) (uint32, uint64, []byte, error) {
+	if len(data) < headerSize || string(data[:4]) != magic {
+		return 0, 0, nil, ErrInvalidData
+	}
+	k := binary.LittleEndian.Uint32(data[4:])
+	m := binary.LittleEndian.Uint64(data[8:])
+	if k == 0 || k > 32 || m == 0 || m > uint64(len(data))*8 {
+		return 0, 0, nil, ErrInvalidData
+	}
+	return k, m, data[headerSize:], nil
+}
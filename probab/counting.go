@@ -0,0 +1,167 @@
+package probab
+
+import (
+	"math"
+	"sync"
+)
+
+// CountingBloom 计数布隆过滤器，每个位置为 8 位计数器，支持删除；计数器达到 255 后不再增减以免误删；并发安全
+type CountingBloom struct {
+	mu       sync.RWMutex
+	m        uint64
+	k        uint32
+	counters []uint8
+}
+
+// NewCountingBloom 按预期元素数 n 与期望误判率 fpRate 创建计数布隆过滤器，参数规则同 NewBloom，内存约为 Bloom 的 8 倍
+//
+//	@param n uint64
+//	@param fpRate float64
+//	@return *CountingBloom
+//	@update 2026-10-18 06:57:19
+func NewCountingBloom(n uint64, fpRate float64) *CountingBloom {
+	m, k := optimal(n, fpRate)
+	return &CountingBloom{m: m, k: k, counters: make([]uint8, m)}
+}
+
+// Add 加入元素，同一元素可加入多次，需删除相同次数
+//
+//	@receiver c *CountingBloom
+//	@param data []byte
+//	@update 2026-10-18 06:57:19
+func (c *CountingBloom) Add(data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	locations(data, c.k, c.m, func(i uint64) bool {
+		if c.counters[i] < math.MaxUint8 {
+			c.counters[i]++
+		}
+		return true
+	})
+}
+
+// AddString 加入字符串元素
+//
+//	@receiver c *CountingBloom
+//	@param s string
+//	@update 2026-10-18 06:57:19
+func (c *CountingBloom) AddString(s string) {
+	c.Add([]byte(s))
+}
+
+// Remove 删除一次元素，元素一定不存在时返回 false 且不做修改；删除从未加入的元素会导致其他元素被误判为不存在
+//
+//	@receiver c *CountingBloom
+//	@param data []byte
+//	@return bool
+//	@update 2026-10-18 06:57:19
+func (c *CountingBloom) Remove(data []byte) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.mayContain(data) {
+		return false
+	}
+	locations(data, c.k, c.m, func(i uint64) bool {
+		if c.counters[i] < math.MaxUint8 {
+			c.counters[i]--
+		}
+		return true
+	})
+	return true
+}
+
+// RemoveString 删除一次字符串元素
+//
+//	@receiver c *CountingBloom
+//	@param s string
+//	@return bool
+//	@update 2026-10-18 06:57:19
+func (c *CountingBloom) RemoveString(s string) bool {
+	return c.Remove([]byte(s))
+}
+
+// MayContain 判断元素是否可能存在
+//
+//	@receiver c *CountingBloom
+//	@param data []byte
+//	@return bool
+//	@update 2026-10-18 06:57:19
+func (c *CountingBloom) MayContain(data []byte) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.mayContain(data)
+}
+
+// MayContainString 判断字符串元素是否可能存在
+//
+//	@receiver c *CountingBloom
+//	@param s string
+//	@return bool
+//	@update 2026-10-18 06:57:19
+func (c *CountingBloom) MayContainString(s string) bool {
+	return c.MayContain([]byte(s))
+}
+
+func (c *CountingBloom) mayContain(data []byte) bool {
+	return locations(data, c.k, c.m, func(i uint64) bool { return c.counters[i] > 0 })
+}
+
+// Merge 将 other 的计数累加到 c（饱和于 255），参数不同时返回 ErrIncompatible
+//
+//	@receiver c *CountingBloom
+//	@param other *CountingBloom
+//	@return error
+//	@update 2026-10-18 06:57:19
+func (c *CountingBloom) Merge(other *CountingBloom) error {
+	if c == other {
+		return nil
+	}
+	// 先复制 other 的计数，避免同时持有两把锁时互相合并导致死锁
+	other.mu.RLock()
+	m, k, counters := other.m, other.k, append([]uint8(nil), other.counters...)
+	other.mu.RUnlock()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.m != m || c.k != k {
+		return ErrIncompatible
+	}
+	for i, n := range counters {
+		c.counters[i] = uint8(min(int(c.counters[i])+int(n), math.MaxUint8))
+	}
+	return nil
+}
+
+// MarshalBinary 实现 encoding.BinaryMarshaler，格式为 "CBF1" + k(uint32) + m(uint64) + 计数器数组
+//
+//	@receiver c *CountingBloom
+//	@return []byte
+//	@return error
+//	@update 2026-10-18 06:57:19
+func (c *CountingBloom) MarshalBinary() ([]byte, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	data := make([]byte, headerSize, headerSize+len(c.counters))
+	putHeader(data, countingMagic, c.k, c.m)
+	return append(data, c.counters...), nil
+}
+
+// UnmarshalBinary 实现 encoding.BinaryUnmarshaler，替换 c 的全部内容
+//
+//	@receiver c *CountingBloom
+//	@param data []byte
+//	@return error
+//	@update 2026-10-18 06:57:19
+func (c *CountingBloom) UnmarshalBinary(data []byte) error {
+	k, m, body, err := readHeader(data, countingMagic)
+	if err != nil {
+		return err
+	}
+	if uint64(len(body)) != m {
+		return ErrInvalidData
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.m, c.k, c.counters = m, k, append([]uint8(nil), body...)
+	return nil
+}
@@ -0,0 +1,177 @@
+// Package randx 随机数辅助：区间取值、随机选择、加权选择、排列与随机字符串，可指定随机源以便测试复现
+package randx
+
+import (
+	"math"
+	"strings"
+)
+
+// IntBetween 返回 [min, max] 闭区间内的随机整数，min>max 时交换二者
+//
+//	@param min int
+//	@param max int
+//	@param opts ...Option
+//	@return int
+//	@update 2026-10-18 04:15:50
+func IntBetween(min, max int, opts ...Option) int {
+	if min > max {
+		min, max = max, min
+	}
+	span := uint64(max) - uint64(min)
+	if span == math.MaxUint64 {
+		return int(newRand(opts).Uint64())
+	}
+	return min + int(newRand(opts).Uint64N(span+1))
+}
+
+// Float64Between 返回 [min, max) 区间内的随机浮点数
+//
+//	@param min float64
+//	@param max float64
+//	@param opts ...Option
+//	@return float64
+//	@update 2026-10-18 04:15:50
+func Float64Between(min, max float64, opts ...Option) float64 {
+	return min + newRand(opts).Float64()*(max-min)
+}
+
+// Choice 随机返回 xs 中的一个元素，xs 为空时返回零值与 false
+//
+//	@param xs []T
+//	@param opts ...Option
+//	@return T
+//	@return bool
+//	@update 2026-10-18 04:15:50
+func Choice[T any](xs []T, opts ...Option) (T, bool) {
+	if len(xs) == 0 {
+		var zero T
+		return zero, false
+	}
+	return xs[newRand(opts).IntN(len(xs))], true
+}
+
+// WeightedChoice 按 weight 返回的权重随机选择 xs 中的一个元素，权重<=0 的元素不会被选中；
+// 没有可选元素时返回零值与 false
+//
+//	@param xs []T
+//	@param weight func(T) float64
+//	@param opts ...Option
+//	@return T
+//	@return bool
+//	@update 2026-10-18 04:15:50
+//
+// for example:
+//
+//	type backend struct {
+//		addr   string
+//		weight float64
+//	}
+//	b, ok := randx.WeightedChoice(backends, func(b backend) float64 { return b.weight })
+func WeightedChoice[T any](xs []T, weight func(T) float64, opts ...Option) (T, bool) {
+	var zero T
+	total := 0.0
+	for _, x := range xs {
+		if w := weight(x); w > 0 {
+			total += w
+		}
+	}
+	if total <= 0 {
+		return zero, false
+	}
+	target := newRand(opts).Float64() * total
+	last := -1
+	for i, x := range xs {
+		w := weight(x)
+		if w <= 0 {
+			continue
+		}
+		last = i
+		if target < w {
+			return x, true
+		}
+		target -= w
+	}
+	// 浮点误差导致未命中时返回最后一个有效元素
+	return xs[last], true
+}
+
+// Sample 不放回地随机选出 xs 中的 n 个元素，n 大于 len(xs) 时返回全部元素的随机排列；不修改 xs
+//
+//	@param xs []T
+//	@param n int
+//	@param opts ...Option
+//	@return []T
+//	@update 2026-10-18 04:15:50
+func Sample[T any](xs []T, n int, opts ...Option) []T {
+	n = min(max(n, 0), len(xs))
+	res := make([]T, len(xs))
+	copy(res, xs)
+	r := newRand(opts)
+	for i := range n {
+		j := i + r.IntN(len(res)-i)
+		res[i], res[j] = res[j], res[i]
+	}
+	return res[:n]
+}
+
+// Perm 返回 [0, n) 的随机排列
+//
+//	@param n int
+//	@param opts ...Option
+//	@return []int
+//	@update 2026-10-18 04:15:50
+func Perm(n int, opts ...Option) []int {
+	return newRand(opts).Perm(n)
+}
+
+// Shuffle 原地打乱 xs
+//
+//	@param xs []T
+//	@param opts ...Option
+//	@update 2026-10-18 04:15:50
+func Shuffle[T any](xs []T, opts ...Option) {
+	newRand(opts).Shuffle(len(xs), func(i, j int) { xs[i], xs[j] = xs[j], xs[i] })
+}
+
+// 常用字符集
+const (
+	Digits       = "0123456789"
+	Lower        = "abcdefghijklmnopqrstuvwxyz"
+	Upper        = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	Letters      = Lower + Upper
+	Alphanumeric = Digits + Letters
+	Hex          = "0123456789abcdef"
+	URLSafe      = Alphanumeric + "-_"
+	// Unambiguous 去掉了 0/O、1/l/I 等易混淆字符，适用于需要人工输入的验证码
+	Unambiguous = "23456789abcdefghijkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ"
+)
+
+// String 返回由 charset 中的字符均匀随机组成、长度为 n 的字符串，charset 按 rune 处理；charset 为空时使用 Alphanumeric
+//
+//	@param n int
+//	@param charset string
+//	@param opts ...Option
+//	@return string
+//	@update 2026-10-18 04:15:50
+func String(n int, charset string, opts ...Option) string {
+	if charset == "" {
+		charset = Alphanumeric
+	}
+	chars := []rune(charset)
+	r := newRand(opts)
+	var sb strings.Builder
+	sb.Grow(n)
+	for range n {
+		sb.WriteRune(chars[r.IntN(len(chars))])
+	}
+	return sb.String()
+}
+
+// SecureToken 返回使用 crypto/rand 生成、由 URLSafe 字符组成的长度为 n 的令牌，每个字符 6 bit 熵
+//
+//	@param n int
+//	@return string
+//	@update 2026-10-18 04:15:50
+func SecureToken(n int) string {
+	return String(n, URLSafe, Secure())
+}
@@ -0,0 +1,69 @@
+package randx
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"math/rand/v2"
+)
+
+// globalSource 使用 math/rand/v2 的全局随机源，并发安全
+type globalSource struct{}
+
+func (globalSource) Uint64() uint64 { return rand.Uint64() }
+
+// cryptoSource 基于 crypto/rand 的随机源，并发安全
+type cryptoSource struct{}
+
+func (cryptoSource) Uint64() uint64 {
+	var b [8]byte
+	_, _ = crand.Read(b[:])
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+type options struct {
+	src rand.Source
+}
+
+// Option 指定随机源，省略时使用 math/rand/v2 的全局随机源
+type Option func(*options)
+
+// WithSource 使用 src 作为随机源；同一个 src 在多次调用间持续推进，用于可复现的测试。
+// 自定义的 src 通常不是并发安全的
+//
+//	@param src rand.Source
+//	@return Option
+//	@update 2026-10-18 04:15:50
+//
+// for example:
+//
+//	src := rand.NewPCG(1, 2)
+//	a := randx.IntBetween(1, 6, randx.WithSource(src))
+//	b := randx.Choice(names, randx.WithSource(src))
+func WithSource(src rand.Source) Option {
+	return func(o *options) { o.src = src }
+}
+
+// WithSeed 使用以 seed 初始化的 PCG 随机源，每次调用都从同一状态开始，结果固定
+//
+//	@param seed uint64
+//	@return Option
+//	@update 2026-10-18 04:15:50
+func WithSeed(seed uint64) Option {
+	return func(o *options) { o.src = rand.NewPCG(seed, seed) }
+}
+
+// Secure 使用 crypto/rand 作为随机源，用于令牌、密码等安全场景
+//
+//	@return Option
+//	@update 2026-10-18 04:15:50
+func Secure() Option {
+	return func(o *options) { o.src = cryptoSource{} }
+}
+
+func newRand(opts []Option) *rand.Rand {
+	o := options{src: globalSource{}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return rand.New(o.src)
+}
@@ -0,0 +1,152 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const defaultIdleTimeout = 10 * time.Minute
+
+// KeyedLimiter 为每个 key（如租户）维护独立的令牌桶，长时间未使用的桶会被淘汰
+type KeyedLimiter[K comparable] struct {
+	mu        sync.Mutex
+	rate      float64
+	burst     int
+	idle      time.Duration
+	buckets   map[K]*keyedBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type keyedBucket struct {
+	*Limiter
+	lastUsed time.Time
+}
+
+// KeyedOption NewKeyedLimiter 的选项
+type KeyedOption func(*keyedOptions)
+
+type keyedOptions struct {
+	idle time.Duration
+}
+
+// WithIdleTimeout 桶超过 d 未被使用且已补满时淘汰，默认 10 分钟
+//
+//	@param d time.Duration
+//	@return KeyedOption
+//	@update 2026-10-17 17:25:40
+func WithIdleTimeout(d time.Duration) KeyedOption {
+	return func(o *keyedOptions) { o.idle = d }
+}
+
+// NewKeyedLimiter 创建按 key 限流的限流器，每个 key 的桶每秒补充 rate 个令牌、容量为 burst
+//
+//	@param rate float64
+//	@param burst int
+//	@param opts ...KeyedOption
+//	@return *KeyedLimiter[K]
+//	@update 2026-10-17 17:25:40
+//
+// for example:
+//
+//	limiter := ratelimit.NewKeyedLimiter[string](100, 20, ratelimit.WithIdleTimeout(time.Minute))
+//	if !limiter.Allow(tenantID) {
+//		return ErrTooManyRequests
+//	}
+func NewKeyedLimiter[K comparable](rate float64, burst int, opts ...KeyedOption) *KeyedLimiter[K] {
+	o := keyedOptions{idle: defaultIdleTimeout}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &KeyedLimiter[K]{
+		rate:      rate,
+		burst:     burst,
+		idle:      o.idle,
+		buckets:   map[K]*keyedBucket{},
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+// Allow 消耗 key 的 1 个令牌，没有可用令牌时返回 false
+//
+//	@receiver k *KeyedLimiter[K]
+//	@param key K
+//	@return bool
+//	@update 2026-10-17 17:25:40
+func (k *KeyedLimiter[K]) Allow(key K) bool {
+	return k.bucket(key).AllowN(1)
+}
+
+// AllowN 消耗 key 的 n 个令牌，令牌不足时不消耗并返回 false
+//
+//	@receiver k *KeyedLimiter[K]
+//	@param key K
+//	@param n int
+//	@return bool
+//	@update 2026-10-17 17:25:40
+func (k *KeyedLimiter[K]) AllowN(key K, n int) bool {
+	return k.bucket(key).AllowN(n)
+}
+
+// Wait 等待直到 key 有 1 个令牌可用并消耗它，语义同 Limiter.WaitN
+//
+//	@receiver k *KeyedLimiter[K]
+//	@param ctx context.Context
+//	@param key K
+//	@return error
+//	@update 2026-10-17 17:25:40
+func (k *KeyedLimiter[K]) Wait(ctx context.Context, key K) error {
+	return k.bucket(key).WaitN(ctx, 1)
+}
+
+// WaitN 等待直到 key 有 n 个令牌可用并消耗它们，语义同 Limiter.WaitN
+//
+//	@receiver k *KeyedLimiter[K]
+//	@param ctx context.Context
+//	@param key K
+//	@param n int
+//	@return error
+//	@update 2026-10-17 17:25:40
+func (k *KeyedLimiter[K]) WaitN(ctx context.Context, key K, n int) error {
+	return k.bucket(key).WaitN(ctx, n)
+}
+
+// Len 返回当前维护的桶数量
+//
+//	@receiver k *KeyedLimiter[K]
+//	@return int
+//	@update 2026-10-17 17:25:40
+func (k *KeyedLimiter[K]) Len() int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return len(k.buckets)
+}
+
+// bucket 返回 key 的桶，不存在时新建；每隔一个空闲周期顺带清理一次空闲的桶
+func (k *KeyedLimiter[K]) bucket(key K) *Limiter {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	now := k.now()
+	if k.idle > 0 && now.Sub(k.lastSweep) >= k.idle {
+		k.sweep(now)
+	}
+	b, ok := k.buckets[key]
+	if !ok {
+		b = &keyedBucket{Limiter: newLimiter(k.rate, k.burst, k.now)}
+		k.buckets[key] = b
+	}
+	b.lastUsed = now
+	return b.Limiter
+}
+
+// sweep 淘汰空闲超时且令牌已补满的桶，未补满的桶淘汰后会让 key 重新获得满桶，因此保留
+func (k *KeyedLimiter[K]) sweep(now time.Time) {
+	for key, b := range k.buckets {
+		if now.Sub(b.lastUsed) >= k.idle && b.full(now) {
+			delete(k.buckets, key)
+		}
+	}
+	k.lastSweep = now
+}
@@ -0,0 +1,158 @@
+// Package ratelimit 令牌桶限流，支持按 key 维护独立的令牌桶
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+// ErrExceedsBurst 单次申请的令牌数超过桶容量，永远无法满足
+var ErrExceedsBurst = errors.New("ratelimit: n exceeds burst")
+
+// ErrWouldExceedDeadline 等待令牌所需的时长超过了 ctx 的截止时间
+var ErrWouldExceedDeadline = errors.New("ratelimit: wait would exceed context deadline")
+
+// Limiter 令牌桶限流器：以每秒 rate 个的速度补充令牌，最多积累 burst 个，可并发使用
+type Limiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// NewLimiter 创建每秒补充 rate 个令牌、容量为 burst 的限流器，初始时桶是满的
+//
+//	@param rate float64
+//	@param burst int
+//	@return *Limiter
+//	@update 2026-10-17 17:25:40
+func NewLimiter(rate float64, burst int) *Limiter {
+	return newLimiter(rate, burst, time.Now)
+}
+
+func newLimiter(rate float64, burst int, now func() time.Time) *Limiter {
+	return &Limiter{rate: rate, burst: float64(burst), tokens: float64(burst), last: now(), now: now}
+}
+
+// Allow 当前有 1 个令牌时消耗它并返回 true，否则返回 false，不等待
+//
+//	@receiver l *Limiter
+//	@return bool
+//	@update 2026-10-17 17:25:40
+func (l *Limiter) Allow() bool {
+	return l.AllowN(1)
+}
+
+// AllowN 当前有 n 个令牌时消耗它们并返回 true，否则不消耗并返回 false
+//
+//	@receiver l *Limiter
+//	@param n int
+//	@return bool
+//	@update 2026-10-17 17:25:40
+func (l *Limiter) AllowN(n int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(l.now())
+	if l.tokens < float64(n) {
+		return false
+	}
+	l.tokens -= float64(n)
+	return true
+}
+
+// Wait 等待直到有 1 个令牌可用并消耗它
+//
+//	@receiver l *Limiter
+//	@param ctx context.Context
+//	@return error
+//	@update 2026-10-17 17:25:40
+func (l *Limiter) Wait(ctx context.Context) error {
+	return l.WaitN(ctx, 1)
+}
+
+// WaitN 等待直到有 n 个令牌可用并消耗它们
+//
+//	n 超过容量时返回 ErrExceedsBurst；预计等待超过 ctx 截止时间时立即返回 ErrWouldExceedDeadline，
+//	均不消耗令牌。等待期间 ctx 结束时归还预占的令牌并返回 ctx.Err()
+//
+//	@receiver l *Limiter
+//	@param ctx context.Context
+//	@param n int
+//	@return error
+//	@update 2026-10-17 17:25:40
+func (l *Limiter) WaitN(ctx context.Context, n int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	wait, err := l.reserve(ctx, n)
+	if err != nil || wait <= 0 {
+		return err
+	}
+	t := time.NewTimer(wait)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens = math.Min(l.tokens+float64(n), l.burst)
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// reserve 预占 n 个令牌（令牌数可暂时为负），返回需要等待的时长
+func (l *Limiter) reserve(ctx context.Context, n int) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if float64(n) > l.burst {
+		return 0, ErrExceedsBurst
+	}
+	now := l.now()
+	l.refill(now)
+	var wait time.Duration
+	if lack := float64(n) - l.tokens; lack > 0 {
+		if l.rate <= 0 {
+			return 0, ErrWouldExceedDeadline
+		}
+		wait = time.Duration(lack / l.rate * float64(time.Second))
+	}
+	if deadline, ok := ctx.Deadline(); ok && now.Add(wait).After(deadline) {
+		return 0, ErrWouldExceedDeadline
+	}
+	l.tokens -= float64(n)
+	return wait, nil
+}
+
+// Tokens 返回当前可用的令牌数（有等待中的 WaitN 时可能为负）
+//
+//	@receiver l *Limiter
+//	@return float64
+//	@update 2026-10-17 17:25:40
+func (l *Limiter) Tokens() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(l.now())
+	return l.tokens
+}
+
+// refill 按距上次补充经过的时间补充令牌，调用方需持有锁
+func (l *Limiter) refill(now time.Time) {
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = math.Min(l.tokens+elapsed.Seconds()*l.rate, l.burst)
+		l.last = now
+	}
+}
+
+// full 桶是否已满，满桶与新建的桶等价，可以安全淘汰
+func (l *Limiter) full(now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(now)
+	return l.tokens >= l.burst
+}
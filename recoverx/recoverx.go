@@ -0,0 +1,97 @@
+// Package recoverx 将 panic 转换为携带调用栈的错误，避免裸 goroutine 的 panic 使进程崩溃
+package recoverx
+
+import (
+	"log/slog"
+	"sync/atomic"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+// selfPackage 本包路径，过滤调用栈时去掉本包的帧
+var selfPackage = reflecting.GetCallerPackage(0)
+
+var hook atomic.Pointer[func(err *reflecting.PanicError)]
+
+func init() {
+	SetPanicHook(defaultHook)
+}
+
+func defaultHook(err *reflecting.PanicError) {
+	slog.Error("recoverx: goroutine panicked", "err", err, "stack", err.StackTrace())
+}
+
+// SetPanicHook 设置 Go 启动的 goroutine 发生 panic 时的全局回调，用于上报；
+// 默认以 slog.Error 输出，传入 nil 恢复默认
+//
+//	@param fn func(err *reflecting.PanicError)
+//	@update 2026-10-17 22:27:54
+//
+// for example:
+//
+//	recoverx.SetPanicHook(func(err *reflecting.PanicError) {
+//		sentry.CaptureException(err)
+//	})
+func SetPanicHook(fn func(err *reflecting.PanicError)) {
+	if fn == nil {
+		fn = defaultHook
+	}
+	hook.Store(&fn)
+}
+
+// Go 在新的 goroutine 中运行 fn，panic 会被恢复并交给全局回调
+//
+//	@param fn func()
+//	@update 2026-10-17 22:27:54
+func Go(fn func()) {
+	go func() {
+		if err := Safe(fn); err != nil {
+			(*hook.Load())(err.(*reflecting.PanicError))
+		}
+	}()
+}
+
+// Safe 运行 fn，fn panic 时返回 *reflecting.PanicError，调用栈已过滤 runtime 与本包的帧
+//
+//	@param fn func()
+//	@return err error
+//	@update 2026-10-17 22:27:54
+func Safe(fn func()) (err error) {
+	defer Recover(&err)
+	fn()
+	return nil
+}
+
+// SafeErr 运行 fn 并返回其错误，fn panic 时返回 *reflecting.PanicError
+//
+//	@param fn func() error
+//	@return err error
+//	@update 2026-10-17 22:27:54
+func SafeErr(fn func() error) (err error) {
+	defer Recover(&err)
+	return fn()
+}
+
+// Recover 须直接 defer 调用：发生 panic 时将其转换为 *reflecting.PanicError 写入 *errp
+//
+//	@param errp *error
+//	@update 2026-10-17 22:27:54
+//
+// for example:
+//
+//	func handle() (err error) {
+//		defer recoverx.Recover(&err)
+//		...
+//	}
+func Recover(errp *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	pe := reflecting.NewPanicError(r)
+	pe.Stack = reflecting.FilterFrames(pe.Stack, reflecting.StackFormatOptions{
+		SkipRuntime:  true,
+		SkipPrefixes: []string{selfPackage + "."},
+	})
+	*errp = pe
+}
@@ -0,0 +1,224 @@
+package reflecting
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// CacheInfo 函数名缓存的统计信息
+type CacheInfo struct {
+	Entries   int    // 当前缓存条目数
+	Limit     int    // 条目上限，0 表示不限制
+	Hits      uint64 // 命中次数
+	Misses    uint64 // 未命中次数
+	Evictions uint64 // 因超出上限被淘汰的条目数
+}
+
+var (
+	cacheLimit   atomic.Int64
+	cacheEntries atomic.Int64  // 所有缓存的条目总数，上限按总数计算
+	cacheClock   atomic.Uint64 // 所有缓存共用的访问时钟，使不同缓存的条目可以比较新旧
+	evictMu      sync.Mutex
+)
+
+type managedCache interface {
+	aged(fn func(tick uint64, remove func()))
+	reset()
+	stats() CacheInfo
+}
+
+// caches 所有受 SetCacheLimit / CacheStats / ResetCache 管理的函数名缓存
+var caches = []managedCache{pcCache, optCache, namedCache, pkgCache, callerPCCache}
+
+type cacheEntry struct {
+	name       string
+	lastAccess atomic.Uint64
+}
+
+// nameCache 函数名缓存，读路径无锁；设置上限后所有缓存按近似 LRU（最近访问时间）统一淘汰
+type nameCache[K comparable] struct {
+	m         sync.Map
+	size      atomic.Int64
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
+}
+
+func (c *nameCache[K]) Load(key K) (string, bool) {
+	name, ok := c.peek(key)
+	if !ok {
+		c.misses.Add(1)
+		return "", false
+	}
+	c.hits.Add(1)
+	return name, true
+}
+
+// peek 与 Load 相同但不计入命中/未命中统计
+func (c *nameCache[K]) peek(key K) (string, bool) {
+	v, ok := c.m.Load(key)
+	if !ok {
+		return "", false
+	}
+	e := v.(*cacheEntry)
+	if cacheLimit.Load() > 0 {
+		e.lastAccess.Store(cacheClock.Add(1))
+	}
+	return e.name, true
+}
+
+func (c *nameCache[K]) Store(key K, name string) {
+	c.LoadOrStore(key, name)
+}
+
+func (c *nameCache[K]) LoadOrStore(key K, name string) string {
+	e := &cacheEntry{name: name}
+	e.lastAccess.Store(cacheClock.Add(1))
+	actual, loaded := c.m.LoadOrStore(key, e)
+	if loaded {
+		return actual.(*cacheEntry).name
+	}
+	c.size.Add(1)
+	if limit := cacheLimit.Load(); cacheEntries.Add(1) > limit && limit > 0 {
+		evictCaches(limit)
+	}
+	return name
+}
+
+// delete 删除 key，返回是否确实删除了条目
+func (c *nameCache[K]) delete(key K) bool {
+	if _, ok := c.m.LoadAndDelete(key); !ok {
+		return false
+	}
+	c.size.Add(-1)
+	cacheEntries.Add(-1)
+	return true
+}
+
+// aged 对每个条目调用 fn，remove 将该条目作为淘汰删除
+func (c *nameCache[K]) aged(fn func(tick uint64, remove func())) {
+	c.m.Range(func(k, v any) bool {
+		fn(v.(*cacheEntry).lastAccess.Load(), func() {
+			if c.delete(k.(K)) {
+				c.evictions.Add(1)
+			}
+		})
+		return true
+	})
+}
+
+// evictCaches 在所有缓存中淘汰最久未访问的条目，直到条目总数降到上限的 90%
+func evictCaches(limit int64) {
+	evictMu.Lock()
+	defer evictMu.Unlock()
+	if cacheEntries.Load() <= limit {
+		return
+	}
+
+	type aged struct {
+		tick   uint64
+		remove func()
+	}
+	var all []aged
+	for _, c := range caches {
+		c.aged(func(tick uint64, remove func()) {
+			all = append(all, aged{tick: tick, remove: remove})
+		})
+	}
+	slices.SortFunc(all, func(a, b aged) int {
+		switch {
+		case a.tick < b.tick:
+			return -1
+		case a.tick > b.tick:
+			return 1
+		}
+		return 0
+	})
+
+	target := limit * 9 / 10
+	for _, a := range all {
+		if cacheEntries.Load() <= target {
+			break
+		}
+		a.remove()
+	}
+}
+
+func (c *nameCache[K]) reset() {
+	c.m.Range(func(k, _ any) bool {
+		c.delete(k.(K))
+		return true
+	})
+	c.hits.Store(0)
+	c.misses.Store(0)
+	c.evictions.Store(0)
+}
+
+func (c *nameCache[K]) stats() CacheInfo {
+	return CacheInfo{
+		Entries:   int(c.size.Load()),
+		Limit:     int(cacheLimit.Load()),
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+	}
+}
+
+// WarmFuncs 预先解析并缓存 fs 中各函数的名称，供 warmup 生成代码在启动时调用
+//
+//	预热的条目与 GetFunctionName 及这些函数内部的 GetCurrentFunc 共用（函数被内联时除外）；
+//	非函数类型的参数会被忽略
+//
+//	@param fs ...any
+//	@update 2026-10-18 14:49:27
+func WarmFuncs(fs ...any) {
+	for _, f := range fs {
+		if rv := reflect.ValueOf(f); rv.Kind() == reflect.Func && !rv.IsNil() {
+			GetFunctionName(f)
+		}
+	}
+}
+
+// SetCacheLimit 设置函数名缓存的条目上限，超出时淘汰最久未访问的条目；n<=0 表示不限制
+//
+//	上限作用于所有函数名缓存（GetCurrentFunc、GetFunctionName、GetCurrentFuncOpt 等）的条目总数，
+//	与 CacheStats().Entries 对应；适用于大量加载插件、函数数量不可控的进程
+//
+//	@param n int
+//	@update 2026-10-18 13:27:30
+func SetCacheLimit(n int) {
+	if n < 0 {
+		n = 0
+	}
+	cacheLimit.Store(int64(n))
+	if n > 0 {
+		evictCaches(int64(n))
+	}
+}
+
+// CacheStats 返回函数名缓存的统计信息（GetCurrentFunc、GetFunctionName、GetCurrentFuncOpt 等共用）
+//
+//	@return CacheInfo
+//	@update 2026-10-17 14:58:40
+func CacheStats() CacheInfo {
+	s := CacheInfo{Limit: int(cacheLimit.Load())}
+	for _, c := range caches {
+		cs := c.stats()
+		s.Entries += cs.Entries
+		s.Hits += cs.Hits
+		s.Misses += cs.Misses
+		s.Evictions += cs.Evictions
+	}
+	return s
+}
+
+// ResetCache 清空函数名缓存及统计信息
+//
+//	@update 2026-10-17 14:58:40
+func ResetCache() {
+	for _, c := range caches {
+		c.reset()
+	}
+}
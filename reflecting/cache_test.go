@@ -0,0 +1,55 @@
+package reflecting_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+	"github.com/BetaGoRobot/go_utils/testx"
+)
+
+// TestCacheLimitIsGlobal 上限作用于所有函数名缓存的条目总数，而不是每个缓存各自计算
+func TestCacheLimitIsGlobal(t *testing.T) {
+	reflecting.ResetCache()
+	reflecting.SetCacheLimit(4)
+	t.Cleanup(func() {
+		reflecting.SetCacheLimit(0)
+		reflecting.ResetCache()
+	})
+
+	funcs := []any{strings.ToUpper, strings.ToLower, strings.TrimSpace, strings.Fields, strings.Clone, strings.Repeat}
+	for _, f := range funcs {
+		reflecting.GetFunctionName(f)
+		reflecting.GetCurrentFuncOpt(reflecting.WithFullPackagePath())
+		reflecting.GetCallerPackage(0)
+		testx.Equal(t, true, reflecting.CacheStats().Entries <= 4, "entries %d", reflecting.CacheStats().Entries)
+	}
+	s := reflecting.CacheStats()
+	testx.Equal(t, 4, s.Limit)
+	testx.Equal(t, true, s.Evictions > 0)
+
+	// 最近使用的条目保留
+	reflecting.GetFunctionName(strings.Repeat)
+	testx.Equal(t, s.Misses, reflecting.CacheStats().Misses)
+}
+
+//go:noinline
+func warmedFunc() string {
+	return reflecting.GetCurrentFunc()
+}
+
+// TestWarmFuncsCurrentFunc WarmFuncs 预热后，函数内首次调用 GetCurrentFunc 直接命中缓存
+func TestWarmFuncsCurrentFunc(t *testing.T) {
+	reflecting.ResetCache()
+	t.Cleanup(reflecting.ResetCache)
+
+	reflecting.WarmFuncs(warmedFunc)
+	before := reflecting.CacheStats()
+	name := warmedFunc()
+	after := reflecting.CacheStats()
+
+	testx.Equal(t, reflecting.GetFunctionName(warmedFunc), name)
+	testx.Equal(t, before.Hits+1, after.Hits)
+	testx.Equal(t, before.Misses, after.Misses)
+	testx.Equal(t, before.Entries, after.Entries)
+}
@@ -0,0 +1,120 @@
+package reflecting
+
+import (
+	"path"
+	"runtime"
+	"strings"
+)
+
+// GetCallerPackage 返回调用栈上的包路径
+//
+//	skip=0 表示调用 GetCallerPackage 的函数所在的包，skip=1 为其调用方，依此类推
+//
+//	@param skip int
+//	@return string
+//	@update 2026-10-17 10:58:03
+func GetCallerPackage(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return ""
+	}
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return ""
+	}
+	return packageOfFunc(fn.Name())
+}
+
+// IsCalledFrom 检查调用 IsCalledFrom 的函数的调用方（最多向上 maxDepth 层）中是否存在匹配 pattern 的帧
+//
+//	pattern 含有 '*'、'?'、'[' 时按 path.Match 通配匹配完整函数名或包路径，否则按前缀匹配
+//	maxDepth<=0 表示检查整个调用栈
+//
+//	@param pattern string
+//	@param maxDepth int
+//	@return bool
+//	@update 2026-10-17 10:58:03
+func IsCalledFrom(pattern string, maxDepth int) bool {
+	// skip: IsCalledFrom 以及调用 IsCalledFrom 的函数本身
+	for _, f := range CaptureStack(2, maxDepth) {
+		if matchFrame(f, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchFrame(f Frame, pattern string) bool {
+	if !strings.ContainsAny(pattern, "*?[") {
+		return strings.HasPrefix(f.Function, pattern) || strings.HasPrefix(f.Package, pattern)
+	}
+	if ok, _ := path.Match(pattern, f.Function); ok {
+		return true
+	}
+	ok, _ := path.Match(pattern, f.Package)
+	return ok
+}
+
+// GetCallerInfo 返回调用栈上某一帧的函数、包、文件与行号
+//
+//	skip=0 表示调用 GetCallerInfo 的函数本身，skip=1 为其调用方，依此类推
+//
+//	@param skip int
+//	@return Frame
+//	@return bool 调用栈不够深时返回 false
+//	@update 2026-10-17 21:51:19
+func GetCallerInfo(skip int) (Frame, bool) {
+	frames := CaptureStack(skip+1, 1)
+	if len(frames) == 0 {
+		return Frame{}, false
+	}
+	return frames[0], true
+}
+
+var (
+	pkgCache      = &nameCache[uintptr]{}
+	callerPCCache = &nameCache[optCacheKey]{}
+)
+
+// FuncNameForPC 返回 runtime.Callers 得到的程序计数器 pc（如 slog.Record.PC）所在函数的名称，
+// 格式规则同 GetCurrentFuncOpt，结果会被缓存
+//
+//	按 runtime.CallersFrames 解析，pc 位于被内联的调用中时返回调用方而非被内联的函数
+//
+//	@param pc uintptr
+//	@param opts ...Option
+//	@return string
+//	@update 2026-10-18 02:31:52
+func FuncNameForPC(pc uintptr, opts ...Option) string {
+	key := optCacheKey{pc: pc, opts: newFuncNameOptions(opts)}
+	if cached, found := callerPCCache.Load(key); found {
+		return cached
+	}
+	raw := rawFuncForPC(pc)
+	if raw == "" {
+		return ""
+	}
+	return callerPCCache.LoadOrStore(key, formatFuncName(raw, key.opts))
+}
+
+// PackageForPC 返回 runtime.Callers 得到的程序计数器 pc 所在函数的包路径，结果会被缓存
+//
+//	@param pc uintptr
+//	@return string
+//	@update 2026-10-18 02:31:52
+func PackageForPC(pc uintptr) string {
+	if pkg, ok := pkgCache.Load(pc); ok {
+		return pkg
+	}
+	raw := rawFuncForPC(pc)
+	if raw == "" {
+		return ""
+	}
+	return pkgCache.LoadOrStore(pc, packageOfFunc(raw))
+}
+
+// rawFuncForPC 返回 pc 对应帧的完整函数名
+func rawFuncForPC(pc uintptr) string {
+	f, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	return f.Function
+}
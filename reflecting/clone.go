@@ -0,0 +1,243 @@
+package reflecting
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"unsafe"
+)
+
+// UnexportedPolicy Clone 处理未导出字段的策略
+type UnexportedPolicy int
+
+const (
+	// UnexportedShallow 未导出字段浅拷贝（与结构体赋值行为一致），默认策略
+	UnexportedShallow UnexportedPolicy = iota
+	// UnexportedSkip 未导出字段置为零值
+	UnexportedSkip
+	// UnexportedDeep 未导出字段同样深拷贝（借助 unsafe 访问）
+	UnexportedDeep
+)
+
+// Cloner 实现了该接口的类型在 Clone 过程中使用自身的 Clone 方法完成拷贝
+//
+//	Clone 方法的返回类型需与接收者类型一致，例如 func (c *Config) Clone() *Config
+type Cloner[T any] interface {
+	Clone() T
+}
+
+type cloneOptions struct {
+	unexported UnexportedPolicy
+}
+
+// CloneOption Clone 的选项
+type CloneOption func(*cloneOptions)
+
+// WithUnexportedPolicy 设置未导出字段的拷贝策略
+//
+//	@param p UnexportedPolicy
+//	@return CloneOption
+//	@update 2026-10-17 12:48:31
+func WithUnexportedPolicy(p UnexportedPolicy) CloneOption {
+	return func(o *cloneOptions) { o.unexported = p }
+}
+
+// Clone 通过反射对 v 进行深拷贝，指针、切片、map、接口与结构体都会被递归复制，循环引用会被保留
+//
+//	chan、func 与 unsafe.Pointer 保持浅拷贝；实现了 Cloner 的类型调用其 Clone 方法。
+//
+// for example:
+//
+//	cfg := &Config{Tags: []string{"a"}}
+//	cp := Clone(cfg)
+//	cp.Tags[0] = "b"
+//	// cfg.Tags[0]: "a"
+//
+//	@param v T
+//	@param opts ...CloneOption
+//	@return T
+//	@update 2026-10-17 12:48:31
+func Clone[T any](v T, opts ...CloneOption) T {
+	switch x := any(v).(type) {
+	case string, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr,
+		float32, float64, complex64, complex128:
+		return v
+	case []byte:
+		return any(bytesClone(x)).(T)
+	case []string:
+		return any(slices.Clone(x)).(T)
+	case []int:
+		return any(slices.Clone(x)).(T)
+	case map[string]string:
+		return any(mapsClone(x)).(T)
+	case map[string]int:
+		return any(mapsClone(x)).(T)
+	}
+
+	o := cloneOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	rv := reflect.ValueOf(&v).Elem()
+	// 顶层值不调用 Cloner，避免 Clone 方法内部调用 reflecting.Clone 导致无限递归
+	c := &cloner{opts: o, visited: map[visitKey]reflect.Value{}, skipCloner: true}
+	return c.clone(rv).Interface().(T)
+}
+
+func bytesClone(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	return append([]byte{}, b...)
+}
+
+func mapsClone[M ~map[K]V, K comparable, V any](m M) M {
+	if m == nil {
+		return nil
+	}
+	return maps.Clone(m)
+}
+
+type visitKey struct {
+	ptr unsafe.Pointer
+	typ reflect.Type
+}
+
+type cloner struct {
+	opts       cloneOptions
+	visited    map[visitKey]reflect.Value
+	skipCloner bool
+}
+
+// clone 返回与 src 类型相同、可寻址的深拷贝结果
+func (c *cloner) clone(src reflect.Value) reflect.Value {
+	t := src.Type()
+	dst := reflect.New(t).Elem()
+	if c.skipCloner {
+		c.skipCloner = false
+	} else if res, ok := c.callCloner(src); ok {
+		dst.Set(res)
+		return dst
+	}
+
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			return dst
+		}
+		key := visitKey{ptr: src.UnsafePointer(), typ: t}
+		if cloned, ok := c.visited[key]; ok {
+			dst.Set(cloned)
+			return dst
+		}
+		ptr := reflect.New(t.Elem())
+		c.visited[key] = ptr
+		ptr.Elem().Set(c.clone(src.Elem()))
+		dst.Set(ptr)
+	case reflect.Interface:
+		if src.IsNil() {
+			return dst
+		}
+		dst.Set(c.clone(src.Elem()))
+	case reflect.Slice:
+		if src.IsNil() {
+			return dst
+		}
+		key := visitKey{ptr: src.UnsafePointer(), typ: t}
+		if cloned, ok := c.visited[key]; ok && cloned.Len() == src.Len() {
+			dst.Set(cloned)
+			return dst
+		}
+		s := reflect.MakeSlice(t, src.Len(), src.Cap())
+		c.visited[key] = s
+		if isShallowKind(t.Elem().Kind()) {
+			reflect.Copy(s, src)
+		} else {
+			for i := range src.Len() {
+				s.Index(i).Set(c.clone(src.Index(i)))
+			}
+		}
+		dst.Set(s)
+	case reflect.Array:
+		if isShallowKind(t.Elem().Kind()) {
+			dst.Set(src)
+			return dst
+		}
+		for i := range src.Len() {
+			dst.Index(i).Set(c.clone(src.Index(i)))
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return dst
+		}
+		key := visitKey{ptr: src.UnsafePointer(), typ: t}
+		if cloned, ok := c.visited[key]; ok {
+			dst.Set(cloned)
+			return dst
+		}
+		m := reflect.MakeMapWithSize(t, src.Len())
+		c.visited[key] = m
+		iter := src.MapRange()
+		for iter.Next() {
+			m.SetMapIndex(c.clone(iter.Key()), c.clone(iter.Value()))
+		}
+		dst.Set(m)
+	case reflect.Struct:
+		c.cloneStruct(src, dst)
+	default:
+		// 基础类型、chan、func、unsafe.Pointer
+		dst.Set(src)
+	}
+	return dst
+}
+
+func (c *cloner) cloneStruct(src, dst reflect.Value) {
+	t := src.Type()
+	if c.opts.unexported == UnexportedShallow {
+		dst.Set(src)
+	}
+	if c.opts.unexported == UnexportedDeep && !src.CanAddr() {
+		tmp := reflect.New(t).Elem()
+		tmp.Set(src)
+		src = tmp
+	}
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		sv, dv := src.Field(i), dst.Field(i)
+		if !sf.IsExported() {
+			if c.opts.unexported != UnexportedDeep {
+				continue
+			}
+			sv = reflect.NewAt(sf.Type, unsafe.Pointer(sv.UnsafeAddr())).Elem()
+			dv = reflect.NewAt(sf.Type, unsafe.Pointer(dv.UnsafeAddr())).Elem()
+		}
+		dv.Set(c.clone(sv))
+	}
+}
+
+// callCloner 值实现了 Clone() T（T 与自身类型相同）时调用之
+func (c *cloner) callCloner(src reflect.Value) (reflect.Value, bool) {
+	if src.Kind() == reflect.Pointer && src.IsNil() || src.Kind() == reflect.Interface || !src.CanInterface() {
+		return reflect.Value{}, false
+	}
+	m := src.MethodByName("Clone")
+	if !m.IsValid() {
+		return reflect.Value{}, false
+	}
+	mt := m.Type()
+	if mt.NumIn() != 0 || mt.NumOut() != 1 || mt.Out(0) != src.Type() {
+		return reflect.Value{}, false
+	}
+	return m.Call(nil)[0], true
+}
+
+func isShallowKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128, reflect.String:
+		return true
+	}
+	return false
+}
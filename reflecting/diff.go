@@ -0,0 +1,219 @@
+package reflecting
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unsafe"
+)
+
+// FieldDiff 一处不相等的字段
+type FieldDiff struct {
+	Path     string // 如 Spec.Containers[0].Name、Labels["app"]，顶层为空
+	Expected any    // a 中的值；字段不存在时为 nil
+	Actual   any    // b 中的值；字段不存在时为 nil
+	Reason   string // 不相等的原因，如 "value mismatch"、"length 2 != 3"、"missing key"
+}
+
+// String 返回 "path: reason, expected X, actual Y" 形式的描述
+//
+//	@receiver d FieldDiff
+//	@return string
+//	@update 2026-10-17 13:20:44
+func (d FieldDiff) String() string {
+	path := d.Path
+	if path == "" {
+		path = "<root>"
+	}
+	return fmt.Sprintf("%s: %s, expected %#v, actual %#v", path, d.Reason, d.Expected, d.Actual)
+}
+
+// DeepEqualDiff 与 reflect.DeepEqual 语义一致地比较 a 与 b，并给出每一处不相等的路径与值
+//
+// for example:
+//
+//	eq, diffs := DeepEqualDiff(User{Name: "a", Tags: []string{"x"}}, User{Name: "b", Tags: []string{"y"}})
+//	// eq: false
+//	// diffs[0].String(): `Name: value mismatch, expected "a", actual "b"`
+//	// diffs[1].String(): `Tags[0]: value mismatch, expected "x", actual "y"`
+//
+//	@param a any 期望值
+//	@param b any 实际值
+//	@return equal bool
+//	@return diffs []FieldDiff
+//	@update 2026-10-17 13:20:44
+func DeepEqualDiff(a, b any) (equal bool, diffs []FieldDiff) {
+	d := &differ{visited: map[visitPair]bool{}}
+	d.diff("", addressable(a), addressable(b))
+	return len(d.diffs) == 0, d.diffs
+}
+
+// FormatDiffs 将 diffs 格式化为多行文本，每行一处差异
+//
+//	@param diffs []FieldDiff
+//	@return string
+//	@update 2026-10-17 13:20:44
+func FormatDiffs(diffs []FieldDiff) string {
+	var sb strings.Builder
+	for _, d := range diffs {
+		sb.WriteString(d.String())
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+type visitPair struct {
+	a, b unsafe.Pointer
+	typ  reflect.Type
+}
+
+type differ struct {
+	visited map[visitPair]bool
+	diffs   []FieldDiff
+}
+
+func (d *differ) report(path string, a, b reflect.Value, reason string) {
+	d.diffs = append(d.diffs, FieldDiff{Path: path, Expected: valueOf(a), Actual: valueOf(b), Reason: reason})
+}
+
+func (d *differ) diff(path string, a, b reflect.Value) {
+	if !a.IsValid() || !b.IsValid() {
+		if a.IsValid() != b.IsValid() {
+			d.report(path, a, b, "nil mismatch")
+		}
+		return
+	}
+	if a.Type() != b.Type() {
+		d.report(path, a, b, fmt.Sprintf("type %s != %s", a.Type(), b.Type()))
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice:
+		if a.IsNil() != b.IsNil() {
+			d.report(path, a, b, "nil mismatch")
+			return
+		}
+		if a.IsNil() || a.UnsafePointer() == b.UnsafePointer() && (a.Kind() != reflect.Slice || a.Len() == b.Len()) {
+			return
+		}
+		key := visitPair{a: a.UnsafePointer(), b: b.UnsafePointer(), typ: a.Type()}
+		if d.visited[key] {
+			return
+		}
+		d.visited[key] = true
+	}
+
+	switch a.Kind() {
+	case reflect.Pointer:
+		d.diff(path, a.Elem(), b.Elem())
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				d.report(path, a, b, "nil mismatch")
+			}
+			return
+		}
+		d.diff(path, a.Elem(), b.Elem())
+	case reflect.Slice, reflect.Array:
+		if a.Len() != b.Len() {
+			d.report(path, a, b, fmt.Sprintf("length %d != %d", a.Len(), b.Len()))
+			return
+		}
+		for i := range a.Len() {
+			d.diff(fmt.Sprintf("%s[%d]", path, i), a.Index(i), b.Index(i))
+		}
+	case reflect.Map:
+		for _, k := range sortedKeys(a, b) {
+			kp := fmt.Sprintf("%s[%#v]", path, valueOf(k))
+			av, bv := a.MapIndex(k), b.MapIndex(k)
+			switch {
+			case !bv.IsValid():
+				d.report(kp, av, bv, "missing key in actual")
+			case !av.IsValid():
+				d.report(kp, av, bv, "unexpected key in actual")
+			default:
+				d.diff(kp, av, bv)
+			}
+		}
+	case reflect.Struct:
+		t := a.Type()
+		for i := range t.NumField() {
+			d.diff(joinPath(path, t.Field(i).Name), a.Field(i), b.Field(i))
+		}
+	case reflect.Func:
+		if !a.IsNil() || !b.IsNil() {
+			// 与 reflect.DeepEqual 一致：非 nil 的 func 永不相等
+			d.report(path, a, b, "func values are not comparable")
+		}
+	default:
+		if !scalarEqual(a, b) {
+			d.report(path, a, b, "value mismatch")
+		}
+	}
+}
+
+func scalarEqual(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() == b.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return a.Complex() == b.Complex()
+	case reflect.String:
+		return a.String() == b.String()
+	case reflect.Chan, reflect.UnsafePointer:
+		return a.Pointer() == b.Pointer()
+	}
+	return false
+}
+
+func sortedKeys(a, b reflect.Value) []reflect.Value {
+	seen := map[any]bool{}
+	var keys []reflect.Value
+	for _, m := range []reflect.Value{a, b} {
+		for _, k := range m.MapKeys() {
+			ki := valueOf(k)
+			if seen[ki] {
+				continue
+			}
+			seen[ki] = true
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(valueOf(keys[i])) < fmt.Sprint(valueOf(keys[j]))
+	})
+	return keys
+}
+
+// valueOf 取出 v 的值，未导出字段通过 unsafe 读取
+func valueOf(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+	if v.CanInterface() {
+		return v.Interface()
+	}
+	if v.CanAddr() {
+		return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem().Interface()
+	}
+	// 不可寻址的未导出值（如经由未导出字段取得的 map 元素）只能借助 fmt 输出
+	return fmt.Sprint(v)
+}
+
+// addressable 返回 x 的可寻址副本，以便通过 unsafe 读取其中的未导出字段
+func addressable(x any) reflect.Value {
+	if x == nil {
+		return reflect.Value{}
+	}
+	v := reflect.New(reflect.TypeOf(x)).Elem()
+	v.Set(reflect.ValueOf(x))
+	return v
+}
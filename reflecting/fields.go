@@ -0,0 +1,281 @@
+package reflecting
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// FieldInfo 结构体字段的描述信息
+type FieldInfo struct {
+	Name      string       // Go 字段名
+	TagName   string       // tag 中的名称部分，如 `json:"id,omitempty"` 中的 id；未设置时为空
+	TagOpts   []string     // tag 中的选项部分，如 omitempty
+	HasTag    bool         // 字段是否声明了该 tag
+	Kind      reflect.Kind // 字段类型的 Kind
+	Type      reflect.Type
+	Index     []int // 从顶层结构体出发的字段下标路径，可用于 reflect.Value.FieldByIndex
+	Depth     int   // 嵌入层级，顶层字段为 0
+	Embedded  bool  // 字段本身是否为匿名嵌入字段
+	StructTag reflect.StructTag
+}
+
+// Key 返回字段对外的名称：有 TagName 时为 TagName，否则为 Go 字段名
+//
+//	@receiver f FieldInfo
+//	@return string
+//	@update 2026-10-17 11:42:50
+func (f FieldInfo) Key() string {
+	if f.TagName != "" {
+		return f.TagName
+	}
+	return f.Name
+}
+
+// HasOption 判断 tag 选项中是否包含 opt
+//
+//	@receiver f FieldInfo
+//	@param opt string
+//	@return bool
+//	@update 2026-10-17 11:42:50
+func (f FieldInfo) HasOption(opt string) bool {
+	return slices.Contains(f.TagOpts, opt)
+}
+
+// Option 返回形如 key=value 的 tag 选项的值
+//
+//	@receiver f FieldInfo
+//	@param key string
+//	@return string
+//	@return bool
+//	@update 2026-10-17 11:42:50
+func (f FieldInfo) Option(key string) (string, bool) {
+	for _, opt := range f.TagOpts {
+		if k, v, ok := strings.Cut(opt, "="); ok && k == key {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// Value 从结构体值 sv 中取出该字段；路径上遇到 nil 的嵌入指针时返回无效的 reflect.Value
+//
+//	@receiver f FieldInfo
+//	@param sv reflect.Value 结构体或结构体指针
+//	@return reflect.Value
+//	@update 2026-10-17 11:42:50
+func (f FieldInfo) Value(sv reflect.Value) reflect.Value {
+	sv = reflect.Indirect(sv)
+	v, err := sv.FieldByIndexErr(f.Index)
+	if err != nil {
+		return reflect.Value{}
+	}
+	return v
+}
+
+// ValueAlloc 从可寻址的结构体值 sv 中取出该字段，路径上 nil 的嵌入指针会被自动分配，适用于赋值场景
+//
+//	@receiver f FieldInfo
+//	@param sv reflect.Value 可寻址的结构体或结构体指针
+//	@return reflect.Value
+//	@update 2026-10-17 11:42:50
+func (f FieldInfo) ValueAlloc(sv reflect.Value) reflect.Value {
+	v := reflect.Indirect(sv)
+	for i, idx := range f.Index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(idx)
+	}
+	return v
+}
+
+// Get 从结构体 v 中取出该字段的值，无法取得时返回 nil
+//
+//	@receiver f FieldInfo
+//	@param v any 结构体或结构体指针
+//	@return any
+//	@update 2026-10-17 11:42:50
+func (f FieldInfo) Get(v any) any {
+	fv := f.Value(reflect.ValueOf(v))
+	if !fv.IsValid() || !fv.CanInterface() {
+		return nil
+	}
+	return fv.Interface()
+}
+
+type fieldsCacheKey struct {
+	t   reflect.Type
+	tag string
+}
+
+var fieldsCache = &sync.Map{}
+
+// FieldsOf 返回结构体（或结构体指针、reflect.Type）的字段列表，匿名嵌入且未命名的结构体会被展开
+//
+//	规则与 encoding/json 类似：tag 为 "-" 的字段与未导出的字段被忽略，同名字段以嵌入层级浅者为准，
+//	同层级时以唯一在 tag 中命名的字段为准，无法区分时该名称的字段全部忽略。
+//	结果按类型缓存，调用方不应修改返回的切片。
+//
+// for example:
+//
+//	type User struct {
+//		ID   int    `json:"id"`
+//		Name string `json:"name,omitempty"`
+//	}
+//	fields := FieldsOf(User{}, "json")
+//	// fields[1].TagName: "name", fields[1].TagOpts: []string{"omitempty"}
+//
+//	@param v any
+//	@param tag string
+//	@return []FieldInfo
+//	@update 2026-10-18 13:11:44
+func FieldsOf(v any, tag string) []FieldInfo {
+	t, ok := v.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(v)
+	}
+	return FieldsOfType(t, tag)
+}
+
+// FieldsOfType 同 FieldsOf，直接接受 reflect.Type
+//
+//	@param t reflect.Type
+//	@param tag string
+//	@return []FieldInfo
+//	@update 2026-10-17 11:42:50
+func FieldsOfType(t reflect.Type, tag string) []FieldInfo {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	key := fieldsCacheKey{t: t, tag: tag}
+	if cached, ok := fieldsCache.Load(key); ok {
+		return cached.([]FieldInfo)
+	}
+	fields := dominantFields(collectFields(t, tag, nil, 0, map[reflect.Type]bool{}))
+	cached, _ := fieldsCache.LoadOrStore(key, fields)
+	return cached.([]FieldInfo)
+}
+
+// ParseTag 将 tag 值拆分为名称与选项，如 "id,omitempty" -> "id", ["omitempty"]
+//
+//	@param tagValue string
+//	@return name string
+//	@return opts []string
+//	@update 2026-10-17 11:42:50
+func ParseTag(tagValue string) (name string, opts []string) {
+	name, rest, found := strings.Cut(tagValue, ",")
+	if !found {
+		return name, nil
+	}
+	for _, opt := range strings.Split(rest, ",") {
+		if opt = strings.TrimSpace(opt); opt != "" {
+			opts = append(opts, opt)
+		}
+	}
+	return name, opts
+}
+
+func collectFields(t reflect.Type, tag string, parentIndex []int, depth int, visiting map[reflect.Type]bool) []FieldInfo {
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	var res []FieldInfo
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		tagValue, hasTag := sf.Tag.Lookup(tag)
+		if tagValue == "-" {
+			continue
+		}
+		name, opts := ParseTag(tagValue)
+		index := append(slices.Clone(parentIndex), i)
+
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				if !sf.IsExported() {
+					// 未导出的嵌入指针无法在赋值时自动分配，与 encoding/json 一致直接忽略
+					continue
+				}
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if !visiting[ft] {
+					res = append(res, collectFields(ft, tag, index, depth+1, visiting)...)
+				}
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		res = append(res, FieldInfo{
+			Name:      sf.Name,
+			TagName:   name,
+			TagOpts:   opts,
+			HasTag:    hasTag,
+			Kind:      sf.Type.Kind(),
+			Type:      sf.Type,
+			Index:     index,
+			Depth:     depth,
+			Embedded:  sf.Anonymous,
+			StructTag: sf.Tag,
+		})
+	}
+	return res
+}
+
+// dominantFields 按 encoding/json 的规则处理同名字段：嵌入层级最浅者胜出，同层级时唯一在 tag 中命名者胜出，
+// 否则视为冲突，该名称的所有字段都被丢弃
+func dominantFields(fields []FieldInfo) []FieldInfo {
+	byKey := map[string][]int{}
+	for i, f := range fields {
+		byKey[f.Key()] = append(byKey[f.Key()], i)
+	}
+	res := make([]FieldInfo, 0, len(byKey))
+	for i, f := range fields {
+		candidates := byKey[f.Key()]
+		if candidates[0] != i {
+			continue
+		}
+		if j, ok := dominantField(fields, candidates); ok {
+			res = append(res, fields[j])
+		}
+	}
+	return res
+}
+
+func dominantField(fields []FieldInfo, candidates []int) (int, bool) {
+	if len(candidates) == 1 {
+		return candidates[0], true
+	}
+	minDepth := fields[candidates[0]].Depth
+	for _, i := range candidates[1:] {
+		minDepth = min(minDepth, fields[i].Depth)
+	}
+	var shallow, tagged []int
+	for _, i := range candidates {
+		if fields[i].Depth != minDepth {
+			continue
+		}
+		shallow = append(shallow, i)
+		if fields[i].TagName != "" {
+			tagged = append(tagged, i)
+		}
+	}
+	switch {
+	case len(tagged) == 1:
+		return tagged[0], true
+	case len(tagged) == 0 && len(shallow) == 1:
+		return shallow[0], true
+	}
+	return 0, false
+}
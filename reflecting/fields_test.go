@@ -0,0 +1,72 @@
+package reflecting_test
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+	"github.com/BetaGoRobot/go_utils/testx"
+)
+
+type embedA struct {
+	Name string
+	ID   int `json:"id"`
+}
+
+type embedB struct {
+	Name string
+	Note string
+}
+
+type embedTagged struct {
+	Label string `json:"Name"`
+}
+
+type ambiguous struct {
+	embedA
+	embedB
+}
+
+type taggedWins struct {
+	embedA
+	embedTagged
+}
+
+type shallowWins struct {
+	embedA
+	Name string
+}
+
+type nested struct {
+	embedA
+	shallowWins
+}
+
+func TestFieldsOfMatchesJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+	}{
+		{name: "same depth untagged conflicts are dropped", v: ambiguous{}},
+		{name: "single tagged field wins at same depth", v: taggedWins{}},
+		{name: "shallower field wins", v: shallowWins{}},
+		{name: "nested embedding", v: nested{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.v)
+			testx.ErrorIs(t, err, nil)
+			var m map[string]any
+			testx.ErrorIs(t, json.Unmarshal(b, &m), nil)
+
+			var keys []string
+			for _, f := range reflecting.FieldsOf(tt.v, "json") {
+				keys = append(keys, f.Key())
+			}
+			slices.Sort(keys)
+			testx.DeepEqual(t, slices.Sorted(maps.Keys(m)), keys)
+		})
+	}
+}
@@ -4,53 +4,59 @@ import (
 	"reflect"
 	"runtime"
 	"strings"
-	"sync"
 
 	commonutils "github.com/BetaGoRobot/go_utils/common_utils"
 )
 
-var pcCache = &sync.Map{}
+var pcCache = &nameCache[uintptr]{}
 
 // GetCurrentFunc 返回调用此函数的上一级函数名（经过合法化处理）
 //
+//	非内联的函数以入口地址作为缓存键，与 GetFunctionName 共用缓存，因此 WarmFuncs 预热过的函数直接命中
+//
 //	@return string
-//	@update 2025-04-10 17:45:37
+//	@update 2026-10-18 14:49:27
 func GetCurrentFunc() string {
 	pc, _, _, ok := runtime.Caller(1)
 	if !ok {
 		return ""
 	}
+	return cachedFuncName(pc)
+}
 
-	// 尝试从缓存中获取函数名
-	if cached, found := pcCache.Load(pc); found {
-		return cached.(string)
-	}
-
-	// 获取函数对象
+// cachedFuncName 返回 pc 所在函数的名称并缓存
+//
+//	内联函数的 Entry 是外层函数的入口地址，不能作为键，仍以 pc 为键；
+//	非内联时 FuncForPC 返回固定的 *Func，可据此区分
+func cachedFuncName(pc uintptr) string {
 	fn := runtime.FuncForPC(pc)
 	if fn == nil {
 		return ""
 	}
-
-	// 解析并处理函数名
-	rawName := fn.Name()
-	funcName := legalize(getLastPathElement(rawName))
-
-	// 缓存函数名
-	pcCache.Store(pc, funcName)
-
-	return funcName
+	key := pc
+	if entry := fn.Entry(); runtime.FuncForPC(entry) == fn {
+		key = entry
+	}
+	if name, ok := pcCache.Load(key); ok {
+		return name
+	}
+	return pcCache.LoadOrStore(key, legalize(getLastPathElement(fn.Name())))
 }
 
 func getLastPathElement(s string) string {
-	parts := strings.Split(s, "/")
-	if len(parts) > 0 {
-		return parts[len(parts)-1]
+	// 泛型实参中可能包含带 '/' 的包路径，如 pkg.Do[github.com/x/y.T]，只在方括号外切分
+	head := s
+	if i := strings.IndexByte(head, '['); i >= 0 {
+		head = head[:i]
+	}
+	if i := strings.LastIndexByte(head, '/'); i >= 0 {
+		return s[i+1:]
 	}
 	return s
 }
 
 func legalize(s string) string {
+	s = normalizeGeneric(s, currentGenericMode())
 	return commonutils.RemoveFromStringRune(s, '*', '(', ')')
 }
 
@@ -58,19 +64,13 @@ func legalize(s string) string {
 //
 //	@param depth int
 //	@return string
-//	@update 2025-03-14 13:55:44
+//	@update 2026-10-18 14:49:27
 func GetCurrentFuncDepth(depth int) string {
-	pc, _, _, _ := runtime.Caller(depth)
-	if name, ok := pcCache.Load(pc); ok {
-		return name.(string)
+	pc, _, _, ok := runtime.Caller(depth)
+	if !ok {
+		return ""
 	}
-
-	name, _ := pcCache.LoadOrStore(pc,
-		legalize(
-			getLastPathElement(runtime.FuncForPC(pc).Name()),
-		),
-	)
-	return name.(string)
+	return cachedFuncName(pc)
 }
 
 // GetFunctionName to be filled
@@ -91,7 +91,7 @@ func GetFunctionName(f any) string {
 
 	// 尝试从缓存获取
 	if name, ok := pcCache.Load(pc); ok {
-		return name.(string)
+		return name
 	}
 
 	// 缓存中不存在，则处理并存储
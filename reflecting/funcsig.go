@@ -0,0 +1,185 @@
+package reflecting
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+var errorType = reflect.TypeFor[error]()
+
+// FuncSig 函数或方法的签名描述
+type FuncSig struct {
+	Name     string   // 函数名，如 pkg.Func、pkg.(*T).Method；MethodsOf 中为方法名
+	Receiver string   // 方法接收者类型，如 *pkg.T；普通函数为空
+	Params   []string // 参数类型（不含接收者），可变参数的最后一项形如 ...string
+	Results  []string // 返回值类型
+	Variadic bool
+	Type     reflect.Type // 函数类型；方法表达式与 MethodsOf 中包含接收者参数
+}
+
+// ReturnsError 最后一个返回值是否为 error
+//
+//	@receiver s FuncSig
+//	@return bool
+//	@update 2026-10-17 14:33:27
+func (s FuncSig) ReturnsError() bool {
+	return len(s.Results) > 0 && s.Results[len(s.Results)-1] == errorType.String()
+}
+
+// String 返回形如 func(ctx context.Context, ...string) (int, error) 的签名
+//
+//	@receiver s FuncSig
+//	@return string
+//	@update 2026-10-17 14:33:27
+func (s FuncSig) String() string {
+	var sb strings.Builder
+	sb.WriteString("func")
+	name := s.Name
+	if s.Receiver != "" {
+		sb.WriteString(" (" + s.Receiver + ")")
+		name = name[strings.LastIndexByte(name, '.')+1:]
+	}
+	if name != "" {
+		sb.WriteString(" " + name)
+	}
+	sb.WriteString("(" + strings.Join(s.Params, ", ") + ")")
+	switch len(s.Results) {
+	case 0:
+	case 1:
+		sb.WriteString(" " + s.Results[0])
+	default:
+		sb.WriteString(" (" + strings.Join(s.Results, ", ") + ")")
+	}
+	return sb.String()
+}
+
+// DescribeFunc 描述函数 f 的签名；f 为方法表达式（如 (*T).Method）时会识别出接收者并从参数中剔除
+//
+//	f 不是函数时返回零值
+//
+// for example:
+//
+//	sig := DescribeFunc(strconv.Atoi)
+//	// sig.Name: "strconv.Atoi", sig.Params: []string{"string"}, sig.Results: []string{"int", "error"}
+//
+//	@param f any
+//	@return FuncSig
+//	@update 2026-10-17 14:33:27
+func DescribeFunc(f any) FuncSig {
+	rv := reflect.ValueOf(f)
+	if rv.Kind() != reflect.Func {
+		return FuncSig{}
+	}
+	t := rv.Type()
+	sig := FuncSig{Type: t, Variadic: t.IsVariadic()}
+	isMethodValue := false
+	if !rv.IsNil() {
+		if fn := runtime.FuncForPC(rv.Pointer()); fn != nil {
+			name := getLastPathElement(fn.Name())
+			sig.Name, isMethodValue = strings.CutSuffix(name, "-fm")
+		}
+	}
+
+	params := describeParams(t, 0)
+	recv := receiverOf(sig.Name)
+	switch {
+	case recv == "":
+	case isMethodValue:
+		// 方法值已绑定接收者，参数中不包含接收者
+		sig.Receiver = recv
+	case len(params) > 0 && stripTypeArgs(params[0]) == recv:
+		// 方法表达式的第一个参数即接收者
+		sig.Receiver = params[0]
+		params = params[1:]
+	}
+	sig.Params = params
+	sig.Results = describeResults(t)
+	return sig
+}
+
+// MethodsOf 列出 v 的类型（按其方法集）的所有导出方法
+//
+//	传入值类型时只包含值接收者的方法，传入指针时包含全部方法
+//
+//	@param v any
+//	@return []FuncSig
+//	@update 2026-10-17 14:33:27
+func MethodsOf(v any) []FuncSig {
+	t, ok := v.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(v)
+	}
+	if t == nil {
+		return nil
+	}
+	isInterface := t.Kind() == reflect.Interface
+	res := make([]FuncSig, 0, t.NumMethod())
+	for i := range t.NumMethod() {
+		m := t.Method(i)
+		skip := 1
+		if isInterface {
+			// 接口的方法类型不包含接收者
+			skip = 0
+		}
+		res = append(res, FuncSig{
+			Name:     m.Name,
+			Receiver: t.String(),
+			Params:   describeParams(m.Type, skip),
+			Results:  describeResults(m.Type),
+			Variadic: m.Type.IsVariadic(),
+			Type:     m.Type,
+		})
+	}
+	return res
+}
+
+func describeParams(t reflect.Type, skip int) []string {
+	params := make([]string, 0, t.NumIn())
+	for i := skip; i < t.NumIn(); i++ {
+		in := t.In(i)
+		if t.IsVariadic() && i == t.NumIn()-1 {
+			params = append(params, "..."+in.Elem().String())
+			continue
+		}
+		params = append(params, in.String())
+	}
+	return params
+}
+
+func describeResults(t reflect.Type) []string {
+	results := make([]string, 0, t.NumOut())
+	for i := range t.NumOut() {
+		results = append(results, t.Out(i).String())
+	}
+	return results
+}
+
+// receiverOf 从 pkg.(*T).M / pkg.T.M 形式的函数名中取出接收者类型，如 *pkg.T / pkg.T
+func receiverOf(name string) string {
+	parts := strings.Split(stripTypeArgs(name), ".")
+	if len(parts) != 3 || isAnonymousSegment(parts[2]) {
+		return ""
+	}
+	if recv, ok := strings.CutPrefix(parts[1], "(*"); ok {
+		return "*" + parts[0] + "." + strings.TrimSuffix(recv, ")")
+	}
+	return parts[0] + "." + parts[1]
+}
+
+// stripTypeArgs 去掉名称中的泛型实参部分，如 pkg.T[int].M -> pkg.T.M
+func stripTypeArgs(name string) string {
+	var sb strings.Builder
+	depth := 0
+	for _, r := range name {
+		switch {
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+		case depth == 0:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,66 @@
+package reflecting
+
+import (
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+// GenericMode 泛型函数名中类型实参部分的处理方式
+//
+//	运行时泛型函数名形如 pkg.Do[...] 或（旧版本 Go）pkg.Do[go.shape.int_0]，
+//	不做处理时会导致指标名称的基数膨胀
+type GenericMode int32
+
+const (
+	// GenericKeep 保持运行时返回的原始名称，默认值
+	GenericKeep GenericMode = iota
+	// GenericStrip 去掉类型实参部分，pkg.Do[go.shape.int_0] -> pkg.Do
+	GenericStrip
+	// GenericShape 将 shape 类型还原为用户可读的类型名，pkg.Do[go.shape.int_0] -> pkg.Do[int]；
+	// 运行时无法得知类型实参（[...]）时保持原样
+	GenericShape
+)
+
+var (
+	genericMode = atomic.Int32{}
+
+	shapePattern = regexp.MustCompile(`go\.shape\.([^,\]\[]+?)(_\d+)?([,\]])`)
+)
+
+// SetGenericNameMode 设置 GetCurrentFunc / GetFunctionName / GetCurrentFuncDepth 对泛型函数名的处理方式，
+// 同时作为 GetCurrentFuncOpt 等函数的默认值；修改后会清空函数名缓存
+//
+//	@param mode GenericMode
+//	@update 2026-10-17 15:50:33
+func SetGenericNameMode(mode GenericMode) {
+	if GenericMode(genericMode.Swap(int32(mode))) != mode {
+		ResetCache()
+	}
+}
+
+// WithGenericMode GetCurrentFuncOpt 等函数对泛型函数名的处理方式，默认取 SetGenericNameMode 的设置
+//
+//	@param mode GenericMode
+//	@return Option
+//	@update 2026-10-17 15:50:33
+func WithGenericMode(mode GenericMode) Option {
+	return func(o *funcNameOptions) { o.genericMode = mode }
+}
+
+func currentGenericMode() GenericMode {
+	return GenericMode(genericMode.Load())
+}
+
+func normalizeGeneric(name string, mode GenericMode) string {
+	if mode == GenericKeep || !strings.Contains(name, "[") {
+		return name
+	}
+	switch mode {
+	case GenericStrip:
+		return stripTypeArgs(name)
+	case GenericShape:
+		return shapePattern.ReplaceAllString(name, "$1$3")
+	}
+	return name
+}
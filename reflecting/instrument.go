@@ -0,0 +1,108 @@
+package reflecting
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// Hooks Instrument 在被包装函数调用前后触发的回调，均可为 nil
+type Hooks struct {
+	// Before 调用前触发，name 为被包装函数的名称（同 GetFunctionName）
+	Before func(name string)
+	// After 调用结束后触发（包括 panic 时），err 为被包装函数最后一个 error 类型的返回值
+	After func(name string, elapsed time.Duration, err error)
+}
+
+func (h Hooks) before(name string) time.Time {
+	if h.Before != nil {
+		h.Before(name)
+	}
+	return time.Now()
+}
+
+func (h Hooks) after(name string, start time.Time, err error) {
+	if h.After != nil {
+		h.After(name, time.Since(start), err)
+	}
+}
+
+// Instrument 包装函数 fn，在每次调用前后以 fn 的函数名触发 hooks
+//
+//	对 func()、func() error、func(context.Context)、func(context.Context) error 走快速路径，
+//	其余函数类型通过 reflect.MakeFunc 包装；fn 不是函数或为 nil 时原样返回
+//
+// for example:
+//
+//	handle := Instrument(svc.Handle, Hooks{
+//		After: func(name string, elapsed time.Duration, err error) {
+//			log.Printf("%s took %s, err=%v", name, elapsed, err)
+//		},
+//	})
+//	err := handle(ctx, req)
+//
+//	@param fn T
+//	@param hooks Hooks
+//	@return T
+//	@update 2026-10-17 16:12:48
+func Instrument[T any](fn T, hooks Hooks) T {
+	rv := reflect.ValueOf(fn)
+	if rv.Kind() != reflect.Func || rv.IsNil() {
+		return fn
+	}
+	name := GetFunctionName(fn)
+
+	var wrapped any
+	switch f := any(fn).(type) {
+	case func():
+		wrapped = func() {
+			start := hooks.before(name)
+			defer func() { hooks.after(name, start, nil) }()
+			f()
+		}
+	case func() error:
+		wrapped = func() (err error) {
+			start := hooks.before(name)
+			defer func() { hooks.after(name, start, err) }()
+			return f()
+		}
+	case func(context.Context):
+		wrapped = func(ctx context.Context) {
+			start := hooks.before(name)
+			defer func() { hooks.after(name, start, nil) }()
+			f(ctx)
+		}
+	case func(context.Context) error:
+		wrapped = func(ctx context.Context) (err error) {
+			start := hooks.before(name)
+			defer func() { hooks.after(name, start, err) }()
+			return f(ctx)
+		}
+	default:
+		wrapped = makeInstrumented(rv, name, hooks).Interface()
+	}
+	// 快速路径仅匹配未命名的函数类型，这里的断言总能成功
+	return wrapped.(T)
+}
+
+func makeInstrumented(rv reflect.Value, name string, hooks Hooks) reflect.Value {
+	t := rv.Type()
+	errIdx := -1
+	if t.NumOut() > 0 && t.Out(t.NumOut()-1) == errorType {
+		errIdx = t.NumOut() - 1
+	}
+	return reflect.MakeFunc(t, func(args []reflect.Value) (results []reflect.Value) {
+		start := hooks.before(name)
+		defer func() {
+			var err error
+			if errIdx >= 0 && results != nil {
+				err, _ = results[errIdx].Interface().(error)
+			}
+			hooks.after(name, start, err)
+		}()
+		if t.IsVariadic() {
+			return rv.CallSlice(args)
+		}
+		return rv.Call(args)
+	})
+}
@@ -0,0 +1,141 @@
+package reflecting
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// FieldLayout 单个字段的内存布局
+type FieldLayout struct {
+	Name    string
+	Type    string
+	Offset  uintptr
+	Size    uintptr
+	Align   uintptr
+	Padding uintptr // 该字段之后（到下一个字段或结构体末尾）的填充字节数
+}
+
+// LayoutReport 结构体的内存布局报告
+type LayoutReport struct {
+	Type           string
+	Size           uintptr
+	Align          uintptr
+	Padding        uintptr // 当前字段顺序下的填充字节总数
+	Fields         []FieldLayout
+	SuggestedOrder []string // 按对齐与大小降序排列后的字段顺序
+	SuggestedSize  uintptr  // 按建议顺序排列后的结构体大小
+}
+
+// LayoutOf 分析结构体（或结构体指针、reflect.Type）的字段偏移、大小、对齐与填充，并给出填充更少的字段顺序
+//
+//	v 不是结构体时返回零值
+//
+// for example:
+//
+//	type T struct {
+//		A bool
+//		B int64
+//		C bool
+//	}
+//	r := LayoutOf(T{})
+//	// r.Size: 24, r.Padding: 14, r.SuggestedOrder: []string{"B", "A", "C"}, r.SuggestedSize: 16
+//
+//	@param v any
+//	@return LayoutReport
+//	@update 2026-10-17 16:35:52
+func LayoutOf(v any) LayoutReport {
+	t, ok := v.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(v)
+	}
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return LayoutReport{}
+	}
+
+	report := LayoutReport{Type: t.String(), Size: t.Size(), Align: uintptr(t.Align())}
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		fl := FieldLayout{
+			Name:   sf.Name,
+			Type:   sf.Type.String(),
+			Offset: sf.Offset,
+			Size:   sf.Type.Size(),
+			Align:  uintptr(sf.Type.Align()),
+		}
+		end := t.Size()
+		if i+1 < t.NumField() {
+			end = t.Field(i + 1).Offset
+		}
+		fl.Padding = end - fl.Offset - fl.Size
+		report.Padding += fl.Padding
+		report.Fields = append(report.Fields, fl)
+	}
+	if len(report.Fields) > 0 && report.Fields[0].Offset > 0 {
+		report.Padding += report.Fields[0].Offset
+	}
+
+	sorted := slices.Clone(report.Fields)
+	// 零大小字段放在最前，避免放在末尾时编译器额外填充；其余按对齐、大小降序
+	slices.SortStableFunc(sorted, func(a, b FieldLayout) int {
+		switch {
+		case (a.Size == 0) != (b.Size == 0):
+			if a.Size == 0 {
+				return -1
+			}
+			return 1
+		case a.Align != b.Align:
+			return int(b.Align) - int(a.Align)
+		case a.Size != b.Size:
+			if a.Size > b.Size {
+				return -1
+			}
+			return 1
+		}
+		return 0
+	})
+	var offset uintptr
+	for _, f := range sorted {
+		offset = alignUp(offset, f.Align) + f.Size
+		report.SuggestedOrder = append(report.SuggestedOrder, f.Name)
+	}
+	report.SuggestedSize = alignUp(offset, report.Align)
+	if report.SuggestedSize > report.Size {
+		// 建议顺序不优于当前顺序时保持原样
+		report.SuggestedSize = report.Size
+		report.SuggestedOrder = report.SuggestedOrder[:0]
+		for _, f := range report.Fields {
+			report.SuggestedOrder = append(report.SuggestedOrder, f.Name)
+		}
+	}
+	return report
+}
+
+// String 以表格形式输出布局报告
+//
+//	@receiver r LayoutReport
+//	@return string
+//	@update 2026-10-17 16:35:52
+func (r LayoutReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s: size=%d align=%d padding=%d\n", r.Type, r.Size, r.Align, r.Padding)
+	fmt.Fprintf(&sb, "%-6s %-6s %-5s %-7s %s\n", "offset", "size", "align", "padding", "field")
+	for _, f := range r.Fields {
+		fmt.Fprintf(&sb, "%-6d %-6d %-5d %-7d %s %s\n", f.Offset, f.Size, f.Align, f.Padding, f.Name, f.Type)
+	}
+	if r.SuggestedSize < r.Size {
+		fmt.Fprintf(&sb, "suggested order (size %d, saves %d bytes): %s\n", r.SuggestedSize, r.Size-r.SuggestedSize, strings.Join(r.SuggestedOrder, ", "))
+	}
+	return sb.String()
+}
+
+func alignUp(n, align uintptr) uintptr {
+	if align == 0 {
+		return n
+	}
+	return (n + align - 1) / align * align
+}
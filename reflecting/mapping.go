@@ -0,0 +1,523 @@
+package reflecting
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type mapOptions struct {
+	tag           string
+	ignoreEmpty   bool
+	keepNested    bool
+	caseSensitive bool
+}
+
+// MapOption StructToMap / MapToStruct 的选项
+type MapOption func(*mapOptions)
+
+// WithTag 使用指定 tag 作为 key，默认 json
+//
+//	@param tag string
+//	@return MapOption
+//	@update 2026-10-17 12:10:05
+func WithTag(tag string) MapOption {
+	return func(o *mapOptions) { o.tag = tag }
+}
+
+// WithOmitAllEmpty 忽略所有零值字段，而不仅是声明了 omitempty 的字段
+//
+//	@return MapOption
+//	@update 2026-10-17 12:10:05
+func WithOmitAllEmpty() MapOption {
+	return func(o *mapOptions) { o.ignoreEmpty = true }
+}
+
+// WithKeepNested 嵌套结构体保持原值，不递归转换为 map
+//
+//	@return MapOption
+//	@update 2026-10-17 12:10:05
+func WithKeepNested() MapOption {
+	return func(o *mapOptions) { o.keepNested = true }
+}
+
+// WithCaseSensitive MapToStruct 匹配 key 时区分大小写，默认先精确匹配再忽略大小写匹配
+//
+//	@return MapOption
+//	@update 2026-10-17 12:10:05
+func WithCaseSensitive() MapOption {
+	return func(o *mapOptions) { o.caseSensitive = true }
+}
+
+func newMapOptions(opts []MapOption) mapOptions {
+	o := mapOptions{tag: "json"}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+var (
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	jsonMarshalerType   = reflect.TypeFor[json.Marshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	timeType            = reflect.TypeFor[time.Time]()
+	durationType        = reflect.TypeFor[time.Duration]()
+)
+
+// StructToMap 将结构体转换为 map[string]any，key 取自 tag（默认 json），支持 omitempty 与嵌套结构体
+//
+//	实现了 encoding.TextMarshaler / json.Marshaler 的类型（如 time.Time）视为叶子值，不展开。
+//	v 不是结构体（或结构体指针）时返回 nil。
+//
+// for example:
+//
+//	type User struct {
+//		ID   int    `json:"id"`
+//		Name string `json:"name,omitempty"`
+//	}
+//	m := StructToMap(User{ID: 1})
+//	// m: map[string]any{"id": 1}
+//
+//	@param v any
+//	@param opts ...MapOption
+//	@return map[string]any
+//	@update 2026-10-17 12:10:05
+func StructToMap(v any, opts ...MapOption) map[string]any {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	return structToMap(rv, newMapOptions(opts))
+}
+
+func structToMap(rv reflect.Value, o mapOptions) map[string]any {
+	res := make(map[string]any)
+	for _, f := range FieldsOfType(rv.Type(), o.tag) {
+		fv := f.Value(rv)
+		if !fv.IsValid() {
+			continue
+		}
+		if (o.ignoreEmpty || f.HasOption("omitempty")) && isEmptyValue(fv) {
+			continue
+		}
+		res[f.Key()] = toMapValue(fv, o)
+	}
+	return res
+}
+
+func toMapValue(v reflect.Value, o mapOptions) any {
+	if !v.IsValid() {
+		return nil
+	}
+	if o.keepNested || isLeafType(v.Type()) {
+		return v.Interface()
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		if isLeafType(v.Elem().Type()) {
+			return v.Interface()
+		}
+		return toMapValue(v.Elem(), o)
+	case reflect.Struct:
+		return structToMap(v, o)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		if !containsStruct(v.Type().Elem()) {
+			return v.Interface()
+		}
+		res := make([]any, v.Len())
+		for i := range v.Len() {
+			res[i] = toMapValue(v.Index(i), o)
+		}
+		return res
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		if !containsStruct(v.Type().Elem()) {
+			return v.Interface()
+		}
+		res := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			res[fmt.Sprint(iter.Key().Interface())] = toMapValue(iter.Value(), o)
+		}
+		return res
+	default:
+		return v.Interface()
+	}
+}
+
+func isLeafType(t reflect.Type) bool {
+	return t.Implements(textMarshalerType) || t.Implements(jsonMarshalerType)
+}
+
+func containsStruct(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	return (t.Kind() == reflect.Struct || t.Kind() == reflect.Interface) && !isLeafType(t)
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+	return false
+}
+
+// MapToStruct 将 map 中的值按 tag（默认 json）写入 dst 指向的结构体，会进行类型转换
+//
+//	支持数字与字符串互转、字符串转 bool、time.Duration / time.Time、嵌套结构体、切片与 map，
+//	以及实现了 encoding.TextUnmarshaler 的类型；m 中不存在的字段（含嵌套结构体中的字段）保持原值。
+//	整数字符串按十进制解析（"08" 为 8），只有 0x、0o、0b 前缀才按对应进制解析。
+//
+// for example:
+//
+//	var u User
+//	err := MapToStruct(map[string]any{"id": "1", "name": "foo"}, &u)
+//	// u: User{ID: 1, Name: "foo"}, err: nil
+//
+//	@param m map[string]any
+//	@param dst any 结构体指针
+//	@param opts ...MapOption
+//	@return error
+//	@update 2026-10-18 14:16:48
+func MapToStruct(m map[string]any, dst any, opts ...MapOption) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return errors.New("reflecting: MapToStruct dst must be a non-nil pointer")
+	}
+	rv = rv.Elem()
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("reflecting: MapToStruct dst must point to a struct, got %s", rv.Type())
+	}
+	return mapToStruct(m, rv, newMapOptions(opts), "")
+}
+
+func mapToStruct(m map[string]any, rv reflect.Value, o mapOptions, path string) error {
+	for _, f := range FieldsOfType(rv.Type(), o.tag) {
+		src, ok := lookupKey(m, f.Key(), o.caseSensitive)
+		if !ok {
+			continue
+		}
+		if err := decodeInto(f.ValueAlloc(rv), src, o, joinPath(path, f.Key())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeInto 将 src 写入 dst；src 为 map 且 dst 为结构体（或结构体指针）时就地解码，
+// 保留 map 中不存在的嵌套字段，与 encoding/json 一致
+func decodeInto(dst reflect.Value, src any, o mapOptions, path string) error {
+	if m, ok := src.(map[string]any); ok {
+		switch {
+		case dst.Kind() == reflect.Struct:
+			return mapToStruct(m, dst, o, path)
+		case dst.Kind() == reflect.Pointer && dst.Type().Elem().Kind() == reflect.Struct:
+			if dst.IsNil() {
+				dst.Set(reflect.New(dst.Type().Elem()))
+			}
+			return mapToStruct(m, dst.Elem(), o, path)
+		}
+	}
+	converted, err := convertTo(src, dst.Type(), o, path)
+	if err != nil {
+		return err
+	}
+	dst.Set(converted)
+	return nil
+}
+
+func lookupKey(m map[string]any, key string, caseSensitive bool) (any, bool) {
+	if v, ok := m[key]; ok {
+		return v, true
+	}
+	if caseSensitive {
+		return nil, false
+	}
+	for k, v := range m {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+func joinPath(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
+
+// ConvertTo 将 src 按 MapToStruct 的转换规则转换为 t 类型的值
+//
+//	@param src any
+//	@param t reflect.Type
+//	@return reflect.Value
+//	@return error
+//	@update 2026-10-18 14:16:48
+func ConvertTo(src any, t reflect.Type) (reflect.Value, error) {
+	return convertTo(src, t, newMapOptions(nil), "")
+}
+
+func convertTo(src any, t reflect.Type, o mapOptions, path string) (reflect.Value, error) {
+	if src == nil {
+		return reflect.Zero(t), nil
+	}
+	sv := reflect.ValueOf(src)
+	if sv.Type().AssignableTo(t) {
+		return sv, nil
+	}
+	fail := func(cause error) (reflect.Value, error) {
+		if cause != nil {
+			return reflect.Value{}, fmt.Errorf("reflecting: cannot convert %s %T to %s: %w", pathOrValue(path), src, t, cause)
+		}
+		return reflect.Value{}, fmt.Errorf("reflecting: cannot convert %s %T to %s", pathOrValue(path), src, t)
+	}
+
+	if t.Kind() == reflect.Pointer {
+		elem, err := convertTo(src, t.Elem(), o, path)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		ptr := reflect.New(t.Elem())
+		ptr.Elem().Set(elem)
+		return ptr, nil
+	}
+	if sv.Kind() == reflect.Pointer {
+		if sv.IsNil() {
+			return reflect.Zero(t), nil
+		}
+		return convertTo(sv.Elem().Interface(), t, o, path)
+	}
+
+	if s, ok := src.(string); ok && reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		ptr := reflect.New(t)
+		if err := ptr.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+			return fail(err)
+		}
+		return ptr.Elem(), nil
+	}
+
+	switch t {
+	case durationType:
+		switch x := src.(type) {
+		case string:
+			d, err := time.ParseDuration(x)
+			if err != nil {
+				return fail(err)
+			}
+			return reflect.ValueOf(d), nil
+		}
+	case timeType:
+		if isNumberKind(sv.Kind()) {
+			n, _ := strconv.ParseFloat(fmt.Sprint(src), 64)
+			return reflect.ValueOf(time.Unix(int64(n), 0)), nil
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		switch {
+		case isNumberKind(sv.Kind()), sv.Kind() == reflect.Bool:
+			return reflect.ValueOf(fmt.Sprint(src)).Convert(t), nil
+		case sv.Kind() == reflect.String:
+			return sv.Convert(t), nil
+		case sv.Type() == reflect.TypeFor[[]byte]():
+			return reflect.ValueOf(string(src.([]byte))).Convert(t), nil
+		}
+	case reflect.Bool:
+		switch {
+		case sv.Kind() == reflect.String:
+			b, err := strconv.ParseBool(sv.String())
+			if err != nil {
+				return fail(err)
+			}
+			return reflect.ValueOf(b).Convert(t), nil
+		case isNumberKind(sv.Kind()):
+			return reflect.ValueOf(!sv.IsZero()).Convert(t), nil
+		case sv.Kind() == reflect.Bool:
+			return sv.Convert(t), nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return convertNumber(sv, t, fail)
+	case reflect.Struct:
+		if m, ok := src.(map[string]any); ok {
+			res := reflect.New(t).Elem()
+			if err := mapToStruct(m, res, o, path); err != nil {
+				return reflect.Value{}, err
+			}
+			return res, nil
+		}
+	case reflect.Slice, reflect.Array:
+		if sv.Kind() != reflect.Slice && sv.Kind() != reflect.Array {
+			break
+		}
+		var res reflect.Value
+		if t.Kind() == reflect.Slice {
+			res = reflect.MakeSlice(t, sv.Len(), sv.Len())
+		} else {
+			if sv.Len() > t.Len() {
+				return fail(fmt.Errorf("length %d exceeds array length %d", sv.Len(), t.Len()))
+			}
+			res = reflect.New(t).Elem()
+		}
+		for i := range sv.Len() {
+			elem, err := convertTo(sv.Index(i).Interface(), t.Elem(), o, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			res.Index(i).Set(elem)
+		}
+		return res, nil
+	case reflect.Map:
+		if sv.Kind() != reflect.Map {
+			break
+		}
+		res := reflect.MakeMapWithSize(t, sv.Len())
+		iter := sv.MapRange()
+		for iter.Next() {
+			key, err := convertTo(iter.Key().Interface(), t.Key(), o, path)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			val, err := convertTo(iter.Value().Interface(), t.Elem(), o, joinPath(path, fmt.Sprint(iter.Key().Interface())))
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			res.SetMapIndex(key, val)
+		}
+		return res, nil
+	case reflect.Interface:
+		if sv.Type().Implements(t) {
+			return sv, nil
+		}
+	}
+	if sv.Type().ConvertibleTo(t) && sv.Kind() == t.Kind() {
+		return sv.Convert(t), nil
+	}
+	return fail(nil)
+}
+
+func convertNumber(sv reflect.Value, t reflect.Type, fail func(error) (reflect.Value, error)) (reflect.Value, error) {
+	res := reflect.New(t).Elem()
+	switch {
+	case sv.Kind() == reflect.String:
+		s := strings.TrimSpace(sv.String())
+		switch {
+		case isIntKind(t.Kind()):
+			n, err := strconv.ParseInt(s, intBase(s), t.Bits())
+			if err != nil {
+				return fail(err)
+			}
+			res.SetInt(n)
+		case isUintKind(t.Kind()):
+			n, err := strconv.ParseUint(s, intBase(s), t.Bits())
+			if err != nil {
+				return fail(err)
+			}
+			res.SetUint(n)
+		default:
+			n, err := strconv.ParseFloat(s, t.Bits())
+			if err != nil {
+				return fail(err)
+			}
+			res.SetFloat(n)
+		}
+		return res, nil
+	case sv.Kind() == reflect.Bool:
+		if sv.Bool() {
+			return reflect.ValueOf(1).Convert(t), nil
+		}
+		return res, nil
+	case isNumberKind(sv.Kind()):
+		if isFloatKind(sv.Kind()) && !isFloatKind(t.Kind()) && sv.Float() != float64(int64(sv.Float())) {
+			return fail(errors.New("value is not integral"))
+		}
+		converted := sv.Convert(t)
+		if !isFloatKind(t.Kind()) && !sameNumber(sv, converted) {
+			return fail(errors.New("value overflows"))
+		}
+		return converted, nil
+	}
+	return fail(nil)
+}
+
+// intBase 只有显式的 0x、0o、0b 前缀才按对应进制解析，其余按十进制，
+// 避免 "08"、"010" 这类带前导零的配置值被当作八进制
+func intBase(s string) int {
+	s = strings.TrimLeft(s, "+-")
+	if len(s) > 2 && s[0] == '0' && strings.ContainsRune("xXoObB", rune(s[1])) {
+		return 0
+	}
+	return 10
+}
+
+func sameNumber(a, b reflect.Value) bool {
+	toF := func(v reflect.Value) float64 {
+		switch {
+		case isIntKind(v.Kind()):
+			return float64(v.Int())
+		case isUintKind(v.Kind()):
+			return float64(v.Uint())
+		default:
+			return v.Float()
+		}
+	}
+	if isIntKind(a.Kind()) && isUintKind(b.Kind()) && a.Int() < 0 {
+		return false
+	}
+	return toF(a) == toF(b)
+}
+
+func pathOrValue(path string) string {
+	if path == "" {
+		return "value"
+	}
+	return "field " + strconv.Quote(path)
+}
+
+func isIntKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUintKind(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+func isFloatKind(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	return isIntKind(k) || isUintKind(k) || isFloatKind(k)
+}
@@ -0,0 +1,94 @@
+package reflecting_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+	"github.com/BetaGoRobot/go_utils/testx"
+)
+
+type mapInner struct {
+	A int    `json:"a"`
+	B string `json:"b"`
+}
+
+type mapOuter struct {
+	Name  string     `json:"name"`
+	In    mapInner   `json:"in"`
+	Ptr   *mapInner  `json:"ptr"`
+	Items []mapInner `json:"items"`
+}
+
+func TestMapToStructKeepsAbsentNestedFields(t *testing.T) {
+	tests := []struct {
+		name string
+		dst  mapOuter
+		m    map[string]any
+		want mapOuter
+	}{
+		{
+			name: "nested struct",
+			dst:  mapOuter{Name: "n", In: mapInner{A: 1, B: "keep"}},
+			m:    map[string]any{"in": map[string]any{"a": "5"}},
+			want: mapOuter{Name: "n", In: mapInner{A: 5, B: "keep"}},
+		},
+		{
+			name: "existing pointer",
+			dst:  mapOuter{Ptr: &mapInner{A: 1, B: "keep"}},
+			m:    map[string]any{"ptr": map[string]any{"a": 2}},
+			want: mapOuter{Ptr: &mapInner{A: 2, B: "keep"}},
+		},
+		{
+			name: "nil pointer is allocated",
+			m:    map[string]any{"ptr": map[string]any{"b": "new"}},
+			want: mapOuter{Ptr: &mapInner{B: "new"}},
+		},
+		{
+			name: "slice elements are replaced",
+			dst:  mapOuter{Items: []mapInner{{A: 1, B: "old"}}},
+			m:    map[string]any{"items": []any{map[string]any{"a": 2}}},
+			want: mapOuter{Items: []mapInner{{A: 2}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := tt.dst
+			testx.ErrorIs(t, reflecting.MapToStruct(tt.m, &dst), nil)
+			testx.DeepEqual(t, tt.want, dst)
+		})
+	}
+}
+
+func TestConvertToIntegerBase(t *testing.T) {
+	tests := []struct {
+		src     string
+		want    int64
+		wantErr bool
+	}{
+		{src: "08", want: 8},
+		{src: "010", want: 10},
+		{src: "-010", want: -10},
+		{src: " 42 ", want: 42},
+		{src: "0x1f", want: 31},
+		{src: "0o17", want: 15},
+		{src: "0b101", want: 5},
+		{src: "0", want: 0},
+		{src: "1_000", wantErr: true},
+		{src: "abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			v, err := reflecting.ConvertTo(tt.src, reflect.TypeFor[int]())
+			testx.Equal(t, tt.wantErr, err != nil, "err: %v", err)
+			if err == nil {
+				testx.Equal(t, tt.want, v.Int())
+			}
+			u, err := reflecting.ConvertTo(strings.TrimLeft(tt.src, "-"), reflect.TypeFor[uint16]())
+			if err == nil && !tt.wantErr {
+				testx.Equal(t, uint64(max(tt.want, -tt.want)), u.Uint())
+			}
+		})
+	}
+}
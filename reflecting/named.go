@@ -0,0 +1,98 @@
+package reflecting
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+const maxNamedDepth = 32
+
+type namedCacheKey struct {
+	pcs  string // 从调用方到最近的具名函数之间所有帧的 pc
+	opts funcNameOptions
+}
+
+var namedCache = &nameCache[namedCacheKey]{}
+
+// GetCurrentNamedFunc 返回调用方所在的最近一个具名函数的名称
+//
+//	在闭包中调用时沿调用栈向上跳过匿名函数帧（funcN、gowrapN 等），直到遇到具名函数，
+//	避免 pkg.Handler.func1 之类的名称导致指标维度碎片化；
+//	在 goroutine 中运行的闭包若栈上没有具名的调用方，则退化为按名称折叠（pkg.Handler.func1 -> pkg.Handler）。
+//	多帧解析的结果按整条 pc 链缓存，opts 的含义与 GetCurrentFuncOpt 相同。
+//
+// for example:
+//
+//	func Handler() {
+//		func() {
+//			name := GetCurrentNamedFunc() // "pkg.Handler"
+//		}()
+//	}
+//
+//	@param opts ...Option
+//	@return string
+//	@update 2026-10-17 15:27:09
+func GetCurrentNamedFunc(opts ...Option) string {
+	var buf [maxNamedDepth]uintptr
+	// skip: runtime.Callers、GetCurrentNamedFunc
+	n := runtime.Callers(2, buf[:])
+	if n == 0 {
+		return ""
+	}
+	o := newFuncNameOptions(opts)
+
+	// 依次尝试以 1..n 个 pc 为键查找缓存，常见情况（调用方本身就是具名函数）只需一次查找
+	for k := 1; k <= n; k++ {
+		if name, ok := namedCache.peek(namedCacheKey{pcs: pcsKey(buf[:k]), opts: o}); ok {
+			namedCache.hits.Add(1)
+			return name
+		}
+	}
+	namedCache.misses.Add(1)
+
+	frames := runtime.CallersFrames(buf[:n])
+	var (
+		first    string
+		consumed int
+	)
+	for {
+		f, more := frames.Next()
+		consumed = indexOfPC(buf[:n], f.PC, consumed)
+		if first == "" {
+			first = f.Function
+		}
+		if f.Function != "" && !isRuntimePackage(packageOfFunc(f.Function)) && !isAnonymousFunc(f.Function) {
+			return namedCache.LoadOrStore(namedCacheKey{pcs: pcsKey(buf[:consumed+1]), opts: o}, formatFuncName(f.Function, o))
+		}
+		if !more {
+			break
+		}
+	}
+
+	// 栈上没有具名调用方，按名称折叠
+	o.collapseAnonymous = true
+	return namedCache.LoadOrStore(namedCacheKey{pcs: pcsKey(buf[:n]), opts: o}, formatFuncName(first, o))
+}
+
+func isAnonymousFunc(fn string) bool {
+	name := getLastPathElement(fn)
+	return collapseAnonymous(name) != name
+}
+
+// indexOfPC 返回 frame pc 在 pcs 中对应的下标；内联帧共享同一个 pc，找不到时保持 from 不变
+func indexOfPC(pcs []uintptr, pc uintptr, from int) int {
+	for i := from; i < len(pcs); i++ {
+		// runtime.Callers 返回的是返回地址，Frame.PC 为返回地址减一
+		if pcs[i]-1 == pc || pcs[i] == pc {
+			return i
+		}
+	}
+	return from
+}
+
+func pcsKey(pcs []uintptr) string {
+	if len(pcs) == 0 {
+		return ""
+	}
+	return string(unsafe.Slice((*byte)(unsafe.Pointer(&pcs[0])), len(pcs)*int(unsafe.Sizeof(pcs[0]))))
+}
@@ -0,0 +1,168 @@
+package reflecting
+
+import (
+	"runtime"
+	"strings"
+)
+
+const defaultLegalizeChars = "*()"
+
+// funcNameOptions 函数名格式化配置，需保持 comparable 以便作为缓存键
+type funcNameOptions struct {
+	fullPackagePath    bool
+	keepReceiverParens bool
+	collapseAnonymous  bool
+	legalizeChars      string
+	replacement        rune
+	genericMode        GenericMode
+}
+
+type optCacheKey struct {
+	pc   uintptr
+	opts funcNameOptions
+}
+
+var optCache = &nameCache[optCacheKey]{}
+
+// Option GetCurrentFuncOpt 的格式化选项
+type Option func(*funcNameOptions)
+
+// WithFullPackagePath 保留完整包路径，如 github.com/xx/pkg.Func
+//
+//	@return Option
+//	@update 2026-10-17 10:31:40
+func WithFullPackagePath() Option {
+	return func(o *funcNameOptions) { o.fullPackagePath = true }
+}
+
+// WithReceiverParens 保留方法接收者的括号与星号，如 pkg.(*T).Method
+//
+//	@return Option
+//	@update 2026-10-17 10:31:40
+func WithReceiverParens() Option {
+	return func(o *funcNameOptions) { o.keepReceiverParens = true }
+}
+
+// WithCollapseAnonymous 将匿名函数帧折叠为其外层函数名，如 pkg.Handler.func1 -> pkg.Handler
+//
+//	@return Option
+//	@update 2026-10-17 10:31:40
+func WithCollapseAnonymous() Option {
+	return func(o *funcNameOptions) { o.collapseAnonymous = true }
+}
+
+// WithLegalizeChars 自定义需要清理的字符集合，替换默认的 "*()"
+//
+//	@param chars string
+//	@return Option
+//	@update 2026-10-17 10:31:40
+func WithLegalizeChars(chars string) Option {
+	return func(o *funcNameOptions) { o.legalizeChars = chars }
+}
+
+// WithReplacement 被清理的字符替换为 repl，默认直接删除
+//
+//	@param repl rune
+//	@return Option
+//	@update 2026-10-17 10:31:40
+func WithReplacement(repl rune) Option {
+	return func(o *funcNameOptions) { o.replacement = repl }
+}
+
+// GetCurrentFuncOpt 返回调用此函数的上一级函数名，按 opts 进行格式化
+//
+//	不传 opts 时与 GetCurrentFunc 结果一致
+//
+//	@param opts ...Option
+//	@return string
+//	@update 2026-10-17 10:31:40
+func GetCurrentFuncOpt(opts ...Option) string {
+	pc, _, _, ok := runtime.Caller(1)
+	if !ok {
+		return ""
+	}
+	return funcNameForPC(pc, newFuncNameOptions(opts))
+}
+
+func newFuncNameOptions(opts []Option) funcNameOptions {
+	o := funcNameOptions{legalizeChars: defaultLegalizeChars, genericMode: currentGenericMode()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+func funcNameForPC(pc uintptr, o funcNameOptions) string {
+	key := optCacheKey{pc: pc, opts: o}
+	if cached, found := optCache.Load(key); found {
+		return cached
+	}
+
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return ""
+	}
+	name := formatFuncName(fn.Name(), o)
+	optCache.Store(key, name)
+	return name
+}
+
+func formatFuncName(rawName string, o funcNameOptions) string {
+	name := rawName
+	if !o.fullPackagePath {
+		name = getLastPathElement(name)
+	}
+	if o.collapseAnonymous {
+		name = collapseAnonymous(name)
+	}
+	name = normalizeGeneric(name, o.genericMode)
+
+	chars := o.legalizeChars
+	if o.keepReceiverParens {
+		chars = strings.Map(func(r rune) rune {
+			if r == '*' || r == '(' || r == ')' {
+				return -1
+			}
+			return r
+		}, chars)
+	}
+	if chars == "" {
+		return name
+	}
+	return strings.Map(func(r rune) rune {
+		if !strings.ContainsRune(chars, r) {
+			return r
+		}
+		if o.replacement == 0 {
+			return -1
+		}
+		return o.replacement
+	}, name)
+}
+
+// collapseAnonymous 去掉末尾的匿名函数段，如 funcN、N、gowrapN、deferwrapN
+func collapseAnonymous(name string) string {
+	for {
+		idx := strings.LastIndexByte(name, '.')
+		if idx < 0 {
+			return name
+		}
+		if !isAnonymousSegment(name[idx+1:]) {
+			return name
+		}
+		name = strings.TrimRight(name[:idx], ".")
+	}
+}
+
+func isAnonymousSegment(seg string) bool {
+	for _, prefix := range []string{"func", "gowrap", "deferwrap", ""} {
+		rest, ok := strings.CutPrefix(seg, prefix)
+		if !ok || rest == "" {
+			continue
+		}
+		if strings.Trim(rest, "0123456789") == "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package reflecting
+
+import "fmt"
+
+// PanicError 由 recover 得到的 panic 转换而来的错误，附带 panic 发生处的调用栈
+type PanicError struct {
+	Value any     // recover() 的返回值
+	Stack []Frame // 从 panic 发生处开始的调用栈
+}
+
+// NewPanicError 将 recover() 的返回值 v 转换为 *PanicError，需在 defer 的函数中调用
+//
+//	调用栈从 panic 发生处开始，runtime.gopanic 及之前（recover 所在的 defer）的帧会被去掉
+//
+//	@param v any
+//	@return *PanicError
+//	@update 2026-10-17 17:48:03
+//
+// for example:
+//
+//	defer func() {
+//		if r := recover(); r != nil {
+//			err = reflecting.NewPanicError(r)
+//		}
+//	}()
+func NewPanicError(v any) *PanicError {
+	stack := CaptureStack(1, 0)
+	for i, f := range stack {
+		if f.Function == "runtime.gopanic" {
+			stack = stack[i+1:]
+			break
+		}
+	}
+	return &PanicError{Value: v, Stack: stack}
+}
+
+// Error 返回 "panic: <value>"，调用栈见 Stack / StackTrace
+//
+//	@receiver e *PanicError
+//	@return string
+//	@update 2026-10-17 17:48:03
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap panic 的值本身是 error 时返回它，便于 errors.Is / errors.As
+//
+//	@receiver e *PanicError
+//	@return error
+//	@update 2026-10-17 17:48:03
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// StackTrace 返回格式化后的调用栈，过滤 runtime 的帧
+//
+//	@receiver e *PanicError
+//	@return string
+//	@update 2026-10-17 17:48:03
+func (e *PanicError) StackTrace() string {
+	return FormatStack(e.Stack, StackFormatOptions{SkipRuntime: true})
+}
@@ -0,0 +1,285 @@
+package reflecting
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrPathNotFound 路径上的字段、key 或下标不存在
+	ErrPathNotFound = errors.New("reflecting: path not found")
+	// ErrInvalidPath 路径语法错误或与值的结构不匹配
+	ErrInvalidPath = errors.New("reflecting: invalid path")
+)
+
+type pathSegment struct {
+	name    string
+	index   int
+	isIndex bool
+}
+
+func (s pathSegment) String() string {
+	if s.isIndex {
+		return "[" + strconv.Itoa(s.index) + "]"
+	}
+	return s.name
+}
+
+// parsePath 解析形如 spec.containers[0].name、labels["app.kubernetes.io/name"] 的路径
+func parsePath(path string) ([]pathSegment, error) {
+	var segs []pathSegment
+	i := 0
+	for i < len(path) {
+		switch path[i] {
+		case '.':
+			i++
+		case '[':
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unclosed '[' in %q", ErrInvalidPath, path)
+			}
+			inner := path[i+1 : i+end]
+			if len(inner) >= 1 && (inner[0] == '"' || inner[0] == '\'') {
+				// 带引号的 key 允许包含 ']'，重新寻找结束的引号
+				quote := inner[0]
+				closeQuote := strings.IndexByte(path[i+2:], quote)
+				if closeQuote < 0 || i+2+closeQuote+1 >= len(path) || path[i+2+closeQuote+1] != ']' {
+					return nil, fmt.Errorf("%w: bad quoted key in %q", ErrInvalidPath, path)
+				}
+				segs = append(segs, pathSegment{name: path[i+2 : i+2+closeQuote]})
+				i += 2 + closeQuote + 2
+				continue
+			}
+			n, err := strconv.Atoi(inner)
+			if err != nil {
+				segs = append(segs, pathSegment{name: inner})
+			} else {
+				segs = append(segs, pathSegment{name: inner, index: n, isIndex: true})
+			}
+			i += end + 1
+		default:
+			end := strings.IndexAny(path[i:], ".[")
+			if end < 0 {
+				end = len(path) - i
+			}
+			segs = append(segs, pathSegment{name: path[i : i+end]})
+			i += end
+		}
+	}
+	if len(segs) == 0 {
+		return nil, fmt.Errorf("%w: empty path", ErrInvalidPath)
+	}
+	return segs, nil
+}
+
+// GetPath 按点分路径读取 v 中的值，支持嵌套结构体、map、切片下标与指针
+//
+//	结构体字段依次按 json tag、Go 字段名、忽略大小写匹配
+//
+// for example:
+//
+//	name, err := GetPath(pod, "spec.containers[0].name")
+//	app, err := GetPath(pod, `metadata.labels["app"]`)
+//
+//	@param v any
+//	@param path string
+//	@return any
+//	@return error
+//	@update 2026-10-17 13:52:19
+func GetPath(v any, path string) (any, error) {
+	segs, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	cur := reflect.ValueOf(v)
+	for i, seg := range segs {
+		cur = indirectValue(cur)
+		if !cur.IsValid() {
+			return nil, fmt.Errorf("%w: nil value at %q", ErrPathNotFound, joinSegments(segs[:i]))
+		}
+		next, err := child(cur, seg)
+		if err != nil {
+			return nil, fmt.Errorf("%w at %q", err, joinSegments(segs[:i+1]))
+		}
+		cur = next
+	}
+	if !cur.IsValid() {
+		return nil, nil
+	}
+	return valueOf(cur), nil
+}
+
+// SetPath 按点分路径将 value 写入 ptr 指向的值，路径上的 nil 指针与 nil map 会被自动分配
+//
+//	value 会按 MapToStruct 的规则转换为目标字段的类型；切片下标等于长度时追加元素
+//
+// for example:
+//
+//	err := SetPath(&pod, "spec.containers[0].image", "nginx:1.25")
+//	err := SetPath(&pod, "spec.replicas", "3") // 自动分配 *int32 并转换
+//
+//	@param ptr any
+//	@param path string
+//	@param value any
+//	@return error
+//	@update 2026-10-17 13:52:19
+func SetPath(ptr any, path string, value any) error {
+	segs, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("%w: SetPath requires a non-nil pointer", ErrInvalidPath)
+	}
+	return setPath(rv.Elem(), segs, 0, value)
+}
+
+func setPath(cur reflect.Value, segs []pathSegment, i int, value any) error {
+	if i == len(segs) {
+		converted, err := ConvertTo(value, cur.Type())
+		if err != nil {
+			return err
+		}
+		cur.Set(converted)
+		return nil
+	}
+
+	for cur.Kind() == reflect.Pointer {
+		if cur.IsNil() {
+			cur.Set(reflect.New(cur.Type().Elem()))
+		}
+		cur = cur.Elem()
+	}
+	if cur.Kind() == reflect.Interface && cur.IsNil() && cur.NumMethod() == 0 {
+		// 空接口自动创建 map[string]any，便于对 map[string]any 结构逐级写入
+		cur.Set(reflect.ValueOf(map[string]any{}))
+	}
+	if cur.Kind() == reflect.Interface && !cur.IsNil() {
+		// 接口中的值不可寻址，复制后修改再写回
+		elem := reflect.New(cur.Elem().Type()).Elem()
+		elem.Set(cur.Elem())
+		if err := setPath(elem, segs, i, value); err != nil {
+			return err
+		}
+		cur.Set(elem)
+		return nil
+	}
+
+	seg := segs[i]
+	wrap := func(err error) error {
+		return fmt.Errorf("%w at %q", err, joinSegments(segs[:i+1]))
+	}
+	switch cur.Kind() {
+	case reflect.Struct:
+		f, ok := lookupField(cur.Type(), seg)
+		if !ok {
+			return wrap(ErrPathNotFound)
+		}
+		return setPath(f.ValueAlloc(cur), segs, i+1, value)
+	case reflect.Map:
+		key, err := ConvertTo(seg.name, cur.Type().Key())
+		if err != nil {
+			return wrap(fmt.Errorf("%w: %v", ErrInvalidPath, err))
+		}
+		if cur.IsNil() {
+			cur.Set(reflect.MakeMap(cur.Type()))
+		}
+		elem := reflect.New(cur.Type().Elem()).Elem()
+		if old := cur.MapIndex(key); old.IsValid() {
+			elem.Set(old)
+		}
+		if err := setPath(elem, segs, i+1, value); err != nil {
+			return err
+		}
+		cur.SetMapIndex(key, elem)
+		return nil
+	case reflect.Slice, reflect.Array:
+		if !seg.isIndex || seg.index < 0 {
+			return wrap(fmt.Errorf("%w: expect index for %s", ErrInvalidPath, cur.Type()))
+		}
+		if cur.Kind() == reflect.Slice && seg.index == cur.Len() {
+			cur.Set(reflect.Append(cur, reflect.Zero(cur.Type().Elem())))
+		}
+		if seg.index >= cur.Len() {
+			return wrap(fmt.Errorf("%w: index %d out of range %d", ErrPathNotFound, seg.index, cur.Len()))
+		}
+		return setPath(cur.Index(seg.index), segs, i+1, value)
+	default:
+		return wrap(fmt.Errorf("%w: cannot descend into %s", ErrInvalidPath, cur.Type()))
+	}
+}
+
+func child(cur reflect.Value, seg pathSegment) (reflect.Value, error) {
+	switch cur.Kind() {
+	case reflect.Struct:
+		f, ok := lookupField(cur.Type(), seg)
+		if !ok {
+			return reflect.Value{}, ErrPathNotFound
+		}
+		v := f.Value(cur)
+		if !v.IsValid() {
+			return reflect.Value{}, ErrPathNotFound
+		}
+		return v, nil
+	case reflect.Map:
+		key, err := ConvertTo(seg.name, cur.Type().Key())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("%w: %v", ErrInvalidPath, err)
+		}
+		v := cur.MapIndex(key)
+		if !v.IsValid() {
+			return reflect.Value{}, ErrPathNotFound
+		}
+		return v, nil
+	case reflect.Slice, reflect.Array:
+		if !seg.isIndex {
+			return reflect.Value{}, fmt.Errorf("%w: expect index for %s", ErrInvalidPath, cur.Type())
+		}
+		if seg.index < 0 || seg.index >= cur.Len() {
+			return reflect.Value{}, fmt.Errorf("%w: index %d out of range %d", ErrPathNotFound, seg.index, cur.Len())
+		}
+		return cur.Index(seg.index), nil
+	default:
+		return reflect.Value{}, fmt.Errorf("%w: cannot descend into %s", ErrInvalidPath, cur.Type())
+	}
+}
+
+func lookupField(t reflect.Type, seg pathSegment) (FieldInfo, bool) {
+	fields := FieldsOfType(t, "json")
+	for _, f := range fields {
+		if f.Key() == seg.name || f.Name == seg.name {
+			return f, true
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.Key(), seg.name) || strings.EqualFold(f.Name, seg.name) {
+			return f, true
+		}
+	}
+	return FieldInfo{}, false
+}
+
+func indirectValue(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func joinSegments(segs []pathSegment) string {
+	var sb strings.Builder
+	for i, s := range segs {
+		if i > 0 && !s.isIndex {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(s.String())
+	}
+	return sb.String()
+}
@@ -0,0 +1,206 @@
+package reflecting
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// Frame 调用栈中的一帧
+type Frame struct {
+	Function string // 完整函数名，如 github.com/xx/pkg.(*T).Method
+	Package  string // 函数所在包路径，如 github.com/xx/pkg
+	File     string
+	Line     int
+	PC       uintptr
+}
+
+// ShortFunction 返回不带包路径的函数名，如 pkg.(*T).Method
+//
+//	@receiver f Frame
+//	@return string
+//	@update 2026-10-17 10:02:11
+func (f Frame) ShortFunction() string {
+	return getLastPathElement(f.Function)
+}
+
+// String 返回 "pkg.Func file:line" 形式的描述
+//
+//	@receiver f Frame
+//	@return string
+//	@update 2026-10-17 10:02:11
+func (f Frame) String() string {
+	return fmt.Sprintf("%s %s:%d", f.ShortFunction(), f.File, f.Line)
+}
+
+// StackFormatOptions FormatStack / FilterFrames 的过滤与输出选项
+type StackFormatOptions struct {
+	SkipRuntime      bool     // 过滤 runtime 包以及 runtime/xxx 子包的帧
+	SkipStdlib       bool     // 过滤标准库的帧（包路径首段不含 '.' 的包）
+	SkipPrefixes     []string // 过滤包路径或函数名以这些前缀开头的帧
+	KeepPrefixes     []string // 非空时，仅保留包路径或函数名以这些前缀开头的帧
+	ShortFile        bool     // 只输出文件名而非完整路径
+	ShortFunc        bool     // 函数名去掉包路径
+	Indent           string   // 每一行的前缀
+	MaxFrames        int      // 过滤后最多输出的帧数，<=0 表示不限制
+	ElideFilteredCnt bool     // 在末尾追加被过滤掉的帧数
+}
+
+// CaptureStack 捕获当前 goroutine 的调用栈
+//
+//	skip=0 表示从调用 CaptureStack 的函数开始; max<=0 表示不限制帧数
+//
+//	@param skip int
+//	@param max int
+//	@return []Frame
+//	@update 2026-10-17 10:02:11
+func CaptureStack(skip, max int) []Frame {
+	size := max
+	if size <= 0 {
+		size = 64
+	}
+	for {
+		pcs := make([]uintptr, size)
+		// +2: runtime.Callers 本身以及 CaptureStack
+		n := runtime.Callers(skip+2, pcs)
+		if n == size && max <= 0 {
+			size *= 2
+			continue
+		}
+		return FramesFromPCs(pcs[:n])
+	}
+}
+
+// FramesFromPCs 将 runtime.Callers 得到的 pc 列表解析为 Frame（会展开内联帧）
+//
+//	@param pcs []uintptr
+//	@return []Frame
+//	@update 2026-10-17 10:02:11
+func FramesFromPCs(pcs []uintptr) []Frame {
+	if len(pcs) == 0 {
+		return nil
+	}
+	frames := make([]Frame, 0, len(pcs))
+	iter := runtime.CallersFrames(pcs)
+	for {
+		f, more := iter.Next()
+		if f.Function != "" || f.File != "" {
+			frames = append(frames, Frame{
+				Function: f.Function,
+				Package:  packageOfFunc(f.Function),
+				File:     f.File,
+				Line:     f.Line,
+				PC:       f.PC,
+			})
+		}
+		if !more {
+			break
+		}
+	}
+	return frames
+}
+
+// FilterFrames 按 opts 中的过滤条件筛选帧
+//
+//	@param frames []Frame
+//	@param opts StackFormatOptions
+//	@return []Frame
+//	@update 2026-10-17 10:02:11
+func FilterFrames(frames []Frame, opts StackFormatOptions) []Frame {
+	res := make([]Frame, 0, len(frames))
+	for _, f := range frames {
+		if opts.SkipRuntime && isRuntimePackage(f.Package) {
+			continue
+		}
+		if opts.SkipStdlib && isStdlibPackage(f.Package) {
+			continue
+		}
+		if hasAnyPrefix(f, opts.SkipPrefixes) {
+			continue
+		}
+		if len(opts.KeepPrefixes) > 0 && !hasAnyPrefix(f, opts.KeepPrefixes) {
+			continue
+		}
+		res = append(res, f)
+	}
+	return res
+}
+
+// FormatStack 将调用栈格式化为多行文本，每帧两行：函数名 + 缩进的 file:line
+//
+//	@param frames []Frame
+//	@param opts StackFormatOptions
+//	@return string
+//	@update 2026-10-17 10:02:11
+func FormatStack(frames []Frame, opts StackFormatOptions) string {
+	filtered := FilterFrames(frames, opts)
+	elided := len(frames) - len(filtered)
+	if opts.MaxFrames > 0 && len(filtered) > opts.MaxFrames {
+		elided += len(filtered) - opts.MaxFrames
+		filtered = filtered[:opts.MaxFrames]
+	}
+
+	var sb strings.Builder
+	for _, f := range filtered {
+		fn, file := f.Function, f.File
+		if opts.ShortFunc {
+			fn = f.ShortFunction()
+		}
+		if opts.ShortFile {
+			file = getLastPathElement(file)
+		}
+		fmt.Fprintf(&sb, "%s%s\n%s\t%s:%d\n", opts.Indent, fn, opts.Indent, file, f.Line)
+	}
+	if opts.ElideFilteredCnt && elided > 0 {
+		fmt.Fprintf(&sb, "%s... %d frames elided\n", opts.Indent, elided)
+	}
+	return sb.String()
+}
+
+// PackageOfFunc 从完整函数名中解析包路径，如 github.com/a/b.(*T).M 返回 github.com/a/b，
+// 可用于为 runtime.Stack 等文本中解析出的函数名补全 Frame.Package
+//
+//	@param fn string
+//	@return string
+//	@update 2026-10-18 09:08:36
+func PackageOfFunc(fn string) string {
+	return packageOfFunc(fn)
+}
+
+// packageOfFunc 从完整函数名中解析包路径
+//
+//	github.com/a/b.(*T).M -> github.com/a/b
+//	main.main.func1       -> main
+func packageOfFunc(fn string) string {
+	head := fn
+	if i := strings.IndexByte(head, '['); i >= 0 {
+		head = head[:i]
+	}
+	lastSlash := strings.LastIndexByte(head, '/')
+	dot := strings.IndexByte(fn[lastSlash+1:], '.')
+	if dot < 0 {
+		return fn
+	}
+	return fn[:lastSlash+1+dot]
+}
+
+func isRuntimePackage(pkg string) bool {
+	return pkg == "runtime" || strings.HasPrefix(pkg, "runtime/")
+}
+
+func isStdlibPackage(pkg string) bool {
+	if pkg == "" || pkg == "main" {
+		return false
+	}
+	first, _, _ := strings.Cut(pkg, "/")
+	return !strings.Contains(first, ".")
+}
+
+func hasAnyPrefix(f Frame, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(f.Package, p) || strings.HasPrefix(f.Function, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+package reflecting
+
+import (
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+type typeNameOptions struct {
+	fullPath      bool
+	unwrapPointer bool
+	unwrapSlice   bool
+	unwrapMap     bool
+}
+
+type typeNameCacheKey struct {
+	t    reflect.Type
+	opts typeNameOptions
+}
+
+var typeNameCache = &sync.Map{}
+
+// TypeNameOption 类型名格式化选项
+type TypeNameOption func(*typeNameOptions)
+
+// WithUnwrapPointer 去掉所有层级的指针，*T / **T -> T
+//
+//	@return TypeNameOption
+//	@update 2026-10-17 11:20:16
+func WithUnwrapPointer() TypeNameOption {
+	return func(o *typeNameOptions) { o.unwrapPointer = true }
+}
+
+// WithUnwrapSlice 使用切片/数组的元素类型，[]T / [N]T -> T
+//
+//	@return TypeNameOption
+//	@update 2026-10-17 11:20:16
+func WithUnwrapSlice() TypeNameOption {
+	return func(o *typeNameOptions) { o.unwrapSlice = true }
+}
+
+// WithUnwrapMap 使用 map 的 value 类型，map[K]V -> V
+//
+//	@return TypeNameOption
+//	@update 2026-10-17 11:20:16
+func WithUnwrapMap() TypeNameOption {
+	return func(o *typeNameOptions) { o.unwrapMap = true }
+}
+
+// WithUnwrapAll 同时开启指针、切片、map 的展开
+//
+//	@return TypeNameOption
+//	@update 2026-10-17 11:20:16
+func WithUnwrapAll() TypeNameOption {
+	return func(o *typeNameOptions) {
+		o.unwrapPointer, o.unwrapSlice, o.unwrapMap = true, true, true
+	}
+}
+
+// TypeName 返回类型 T 带完整包路径的名称
+// for example:
+//
+//	TypeName[*bytes.Buffer]()                     // "*bytes.Buffer"
+//	TypeName[[]*pkg.User](WithUnwrapAll())        // "github.com/xx/pkg.User"
+//
+//	@param opts ...TypeNameOption
+//	@return string
+//	@update 2026-10-17 11:20:16
+func TypeName[T any](opts ...TypeNameOption) string {
+	return typeName(reflect.TypeFor[T](), true, opts)
+}
+
+// ShortTypeName 返回类型 T 不带包路径的名称（仅保留包名），如 pkg.User
+//
+//	@param opts ...TypeNameOption
+//	@return string
+//	@update 2026-10-17 11:20:16
+func ShortTypeName[T any](opts ...TypeNameOption) string {
+	return typeName(reflect.TypeFor[T](), false, opts)
+}
+
+// ValueTypeName 返回 v 的动态类型带完整包路径的名称，v 为 nil 时返回 "nil"
+//
+//	@param v any
+//	@param opts ...TypeNameOption
+//	@return string
+//	@update 2026-10-17 11:20:16
+func ValueTypeName(v any, opts ...TypeNameOption) string {
+	return typeName(reflect.TypeOf(v), true, opts)
+}
+
+// ShortValueTypeName 返回 v 的动态类型不带包路径的名称，v 为 nil 时返回 "nil"
+//
+//	@param v any
+//	@param opts ...TypeNameOption
+//	@return string
+//	@update 2026-10-17 11:20:16
+func ShortValueTypeName(v any, opts ...TypeNameOption) string {
+	return typeName(reflect.TypeOf(v), false, opts)
+}
+
+func typeName(t reflect.Type, fullPath bool, opts []TypeNameOption) string {
+	if t == nil {
+		return "nil"
+	}
+	o := typeNameOptions{fullPath: fullPath}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	key := typeNameCacheKey{t: t, opts: o}
+	if cached, ok := typeNameCache.Load(key); ok {
+		return cached.(string)
+	}
+	name := formatTypeName(unwrapType(t, o), o.fullPath)
+	typeNameCache.Store(key, name)
+	return name
+}
+
+func unwrapType(t reflect.Type, o typeNameOptions) reflect.Type {
+	for {
+		switch {
+		case o.unwrapPointer && t.Kind() == reflect.Pointer,
+			o.unwrapSlice && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array),
+			o.unwrapMap && t.Kind() == reflect.Map:
+			t = t.Elem()
+		default:
+			return t
+		}
+	}
+}
+
+func formatTypeName(t reflect.Type, fullPath bool) string {
+	if !fullPath {
+		return t.String()
+	}
+	if t.Name() != "" {
+		if t.PkgPath() == "" {
+			return t.Name()
+		}
+		return t.PkgPath() + "." + t.Name()
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return "*" + formatTypeName(t.Elem(), fullPath)
+	case reflect.Slice:
+		return "[]" + formatTypeName(t.Elem(), fullPath)
+	case reflect.Array:
+		return "[" + strconv.Itoa(t.Len()) + "]" + formatTypeName(t.Elem(), fullPath)
+	case reflect.Map:
+		return "map[" + formatTypeName(t.Key(), fullPath) + "]" + formatTypeName(t.Elem(), fullPath)
+	case reflect.Chan:
+		switch t.ChanDir() {
+		case reflect.RecvDir:
+			return "<-chan " + formatTypeName(t.Elem(), fullPath)
+		case reflect.SendDir:
+			return "chan<- " + formatTypeName(t.Elem(), fullPath)
+		}
+		return "chan " + formatTypeName(t.Elem(), fullPath)
+	default:
+		return t.String()
+	}
+}
@@ -0,0 +1,44 @@
+package reflecting
+
+import "reflect"
+
+// IsNilSafe 判断 v 是否为 nil，包括装在接口中的 typed nil（如 (*T)(nil)、nil map/slice/func/chan），不会 panic
+// for example:
+//
+//	var p *User
+//	var v any = p
+//	// v == nil: false
+//	// IsNilSafe(v): true
+//
+//	@param v any
+//	@return bool
+//	@update 2026-10-17 14:15:02
+func IsNilSafe(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface, reflect.UnsafePointer:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// IsZero 判断 v 是否为其类型的零值，nil 与 typed nil 均视为零值，不会 panic
+// for example:
+//
+//	IsZero(nil)            // true
+//	IsZero((*User)(nil))   // true
+//	IsZero(User{})         // true
+//	IsZero([]int{})        // false，非 nil 的空切片不是零值
+//
+//	@param v any
+//	@return bool
+//	@update 2026-10-17 14:15:02
+func IsZero(v any) bool {
+	if v == nil {
+		return true
+	}
+	return reflect.ValueOf(v).IsZero()
+}
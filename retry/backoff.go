@@ -0,0 +1,47 @@
+package retry
+
+import (
+	"math"
+	"time"
+)
+
+// BackoffFunc 返回第 attempt 次（从 1 开始）失败后、下一次尝试前的等待时长
+type BackoffFunc func(attempt int) time.Duration
+
+// Constant 每次等待固定时长 d
+//
+//	@param d time.Duration
+//	@return BackoffFunc
+//	@update 2026-10-17 17:02:15
+func Constant(d time.Duration) BackoffFunc {
+	return func(int) time.Duration { return d }
+}
+
+// Linear 等待时长随次数线性增长：d、2d、3d...
+//
+//	@param d time.Duration
+//	@return BackoffFunc
+//	@update 2026-10-17 17:02:15
+func Linear(d time.Duration) BackoffFunc {
+	return func(attempt int) time.Duration {
+		return saturate(float64(d) * float64(attempt))
+	}
+}
+
+// Exponential 等待时长按 2 的幂增长：base、2base、4base...，溢出时取最大时长
+//
+//	@param base time.Duration
+//	@return BackoffFunc
+//	@update 2026-10-17 17:02:15
+func Exponential(base time.Duration) BackoffFunc {
+	return func(attempt int) time.Duration {
+		return saturate(float64(base) * math.Pow(2, float64(attempt-1)))
+	}
+}
+
+func saturate(d float64) time.Duration {
+	if d >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(d)
+}
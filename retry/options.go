@@ -0,0 +1,83 @@
+package retry
+
+import "time"
+
+const (
+	defaultAttempts = 3
+	defaultBase     = 100 * time.Millisecond
+)
+
+type options struct {
+	attempts int
+	backoff  BackoffFunc
+	jitter   float64
+	maxDelay time.Duration
+	retryIf  func(error) bool
+	onRetry  []func(attempt int, err error, delay time.Duration)
+}
+
+// Option Do / DoValue 的重试选项
+type Option func(*options)
+
+// Attempts 最多尝试 n 次（含第一次），n <= 0 表示不限次数，直到成功或 ctx 结束；默认 3 次
+//
+//	@param n int
+//	@return Option
+//	@update 2026-10-17 17:02:15
+func Attempts(n int) Option {
+	return func(o *options) { o.attempts = n }
+}
+
+// Backoff 设置两次尝试之间的等待策略，默认 Exponential(100ms)
+//
+//	@param b BackoffFunc
+//	@return Option
+//	@update 2026-10-17 17:02:15
+func Backoff(b BackoffFunc) Option {
+	return func(o *options) { o.backoff = b }
+}
+
+// Jitter 在等待时长上叠加 ±factor 比例的随机抖动，如 0.2 表示 [0.8d, 1.2d]，factor 取值 [0, 1]
+//
+//	@param factor float64
+//	@return Option
+//	@update 2026-10-17 17:02:15
+func Jitter(factor float64) Option {
+	return func(o *options) { o.jitter = min(max(factor, 0), 1) }
+}
+
+// MaxDelay 单次等待时长的上限（抖动之后），<= 0 表示不限制
+//
+//	@param d time.Duration
+//	@return Option
+//	@update 2026-10-17 17:02:15
+func MaxDelay(d time.Duration) Option {
+	return func(o *options) { o.maxDelay = d }
+}
+
+// RetryIf 只在 fn(err) 为 true 时重试，否则立即返回该错误；默认所有错误都重试
+//
+//	@param fn func(error) bool
+//	@return Option
+//	@update 2026-10-17 17:02:15
+func RetryIf(fn func(error) bool) Option {
+	return func(o *options) { o.retryIf = fn }
+}
+
+// OnRetry 每次失败且即将重试时调用 fn，attempt 为刚失败的次数（从 1 开始），delay 为接下来的等待时长；
+// 可多次设置，按顺序调用
+//
+//	@param fn func(attempt int, err error, delay time.Duration)
+//	@return Option
+//	@update 2026-10-17 17:02:15
+func OnRetry(fn func(attempt int, err error, delay time.Duration)) Option {
+	return func(o *options) { o.onRetry = append(o.onRetry, fn) }
+}
+
+func newOptions(opts []Option) options {
+	o := options{attempts: defaultAttempts, backoff: Exponential(defaultBase)}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,96 @@
+// Package retry 带可插拔退避策略的重试
+package retry
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// Do 执行 fn，失败时按 opts 等待后重试，直到成功、次数用尽、错误不可重试或 ctx 结束
+//
+//	次数用尽或错误不可重试时返回最后一次的错误；等待期间 ctx 结束时返回的错误同时包装 ctx.Err() 与最后一次的错误
+//
+//	@param ctx context.Context
+//	@param fn func(context.Context) error
+//	@param opts ...Option
+//	@return error
+//	@update 2026-10-17 17:02:15
+//
+// for example:
+//
+//	err := retry.Do(ctx, call,
+//		retry.Attempts(5),
+//		retry.Backoff(retry.Exponential(100*time.Millisecond)),
+//		retry.Jitter(0.2),
+//		retry.RetryIf(isTransient),
+//	)
+func Do(ctx context.Context, fn func(context.Context) error, opts ...Option) error {
+	_, err := DoValue(ctx, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	}, opts...)
+	return err
+}
+
+// DoValue 与 Do 相同，成功时返回 fn 的结果
+//
+//	@param ctx context.Context
+//	@param fn func(context.Context) (T, error)
+//	@param opts ...Option
+//	@return T
+//	@return error
+//	@update 2026-10-17 17:02:15
+func DoValue[T any](ctx context.Context, fn func(context.Context) (T, error), opts ...Option) (T, error) {
+	o := newOptions(opts)
+	var zero T
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return zero, err
+		}
+		v, err := fn(ctx)
+		if err == nil {
+			return v, nil
+		}
+		if o.retryIf != nil && !o.retryIf(err) {
+			return zero, err
+		}
+		if o.attempts > 0 && attempt >= o.attempts {
+			return zero, err
+		}
+
+		delay := o.delay(attempt)
+		for _, hook := range o.onRetry {
+			hook(attempt, err, delay)
+		}
+		if ctxErr := sleep(ctx, delay); ctxErr != nil {
+			return zero, fmt.Errorf("%w: last error: %w", ctxErr, err)
+		}
+	}
+}
+
+// delay 计算第 attempt 次失败后的等待时长：退避、抖动后再按上限截断
+func (o *options) delay(attempt int) time.Duration {
+	d := o.backoff(attempt)
+	if o.jitter > 0 && d > 0 {
+		d = saturate(float64(d) * (1 + o.jitter*(2*rand.Float64()-1)))
+	}
+	if o.maxDelay > 0 && d > o.maxDelay {
+		d = o.maxDelay
+	}
+	return max(d, 0)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,225 @@
+package schedule
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule 计算下一次执行时间
+type Schedule interface {
+	// Next 返回严格晚于 t 的下一次执行时间，不会再执行时返回零值
+	Next(t time.Time) time.Time
+}
+
+// cronSchedule 5 段 cron 表达式，每段用位图表示允许的取值
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+}
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var monthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var dowNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+// ParseCron 解析标准 5 段 cron 表达式（分 时 日 月 周），支持 *、列表、范围、步长、
+// 月份与星期的英文缩写，以及 @hourly、@daily、@weekly、@monthly、@yearly 与 @every <duration>
+//
+//	日与周都不是 * 时，两者满足其一即执行（与 vixie cron 一致）
+//
+//	@param expr string
+//	@return Schedule
+//	@return error
+//	@update 2026-10-17 21:12:05
+//
+// for example:
+//
+//	ParseCron("*/15 9-18 * * MON-FRI") // 工作日 9 点到 18 点每 15 分钟
+//	ParseCron("@every 90s")
+func ParseCron(expr string) (Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if rest, ok := strings.CutPrefix(expr, "@every "); ok {
+		d, err := time.ParseDuration(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, fmt.Errorf("schedule: invalid @every duration %q: %w", rest, err)
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("schedule: @every duration must be positive, got %s", d)
+		}
+		return Every(d), nil
+	}
+	if spec, ok := descriptors[strings.ToLower(expr)]; ok {
+		expr = spec
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("schedule: cron expression %q must have 5 fields, got %d", expr, len(fields))
+	}
+
+	var (
+		c   cronSchedule
+		err error
+	)
+	if c.minute, err = parseField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("schedule: minute: %w", err)
+	}
+	if c.hour, err = parseField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("schedule: hour: %w", err)
+	}
+	if c.dom, err = parseField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("schedule: day of month: %w", err)
+	}
+	if c.month, err = parseField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("schedule: month: %w", err)
+	}
+	if c.dow, err = parseField(fields[4], 0, 7, dowNames); err != nil {
+		return nil, fmt.Errorf("schedule: day of week: %w", err)
+	}
+	// 7 与 0 都表示周日
+	if c.dow&(1<<7) != 0 {
+		c.dow = c.dow&^(1<<7) | 1
+	}
+	c.domStar = fields[2] == "*" || fields[2] == "?"
+	c.dowStar = fields[4] == "*" || fields[4] == "?"
+	return &c, nil
+}
+
+// parseField 解析一段 cron 字段为位图
+func parseField(field string, lo, hi int, names map[string]int) (uint64, error) {
+	var set uint64
+	for part := range strings.SplitSeq(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q", part)
+			}
+			step = n
+		}
+
+		var start, end int
+		switch {
+		case rng == "*" || rng == "?":
+			start, end = lo, hi
+		case strings.Contains(rng, "-"):
+			a, b, _ := strings.Cut(rng, "-")
+			var err error
+			if start, err = parseValue(a, names); err != nil {
+				return 0, err
+			}
+			if end, err = parseValue(b, names); err != nil {
+				return 0, err
+			}
+		default:
+			var err error
+			if start, err = parseValue(rng, names); err != nil {
+				return 0, err
+			}
+			end = start
+			// a/n 表示从 a 开始到最大值，每 n 个取一个
+			if hasStep {
+				end = hi
+			}
+		}
+		if start < lo || end > hi || start > end {
+			return 0, fmt.Errorf("value %q out of range [%d, %d]", part, lo, hi)
+		}
+		for v := start; v <= end; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+func parseValue(s string, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	return v, nil
+}
+
+// Next 按 t 所在的时区计算下一次执行时间，最多向后查找 5 年
+//
+//	@receiver c *cronSchedule
+//	@param t time.Time
+//	@return time.Time
+//	@update 2026-10-17 21:12:05
+func (c *cronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	limit := t.Year() + 5
+	for t.Year() <= limit {
+		switch {
+		case c.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			// 跳到下一个允许的分钟，本小时内没有时进入下一小时
+			rest := c.minute >> uint(t.Minute())
+			if rest == 0 {
+				t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			} else {
+				t = t.Add(time.Duration(bits.TrailingZeros64(rest)) * time.Minute)
+			}
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (c *cronSchedule) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domStar || c.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// intervalSchedule 固定间隔
+type intervalSchedule time.Duration
+
+// Every 返回固定间隔 d 的 Schedule，d 须大于 0
+//
+//	@param d time.Duration
+//	@return Schedule
+//	@update 2026-10-17 21:12:05
+func Every(d time.Duration) Schedule {
+	return intervalSchedule(d)
+}
+
+// Next 返回 t 之后 d 的时间
+//
+//	@receiver s intervalSchedule
+//	@param t time.Time
+//	@return time.Time
+//	@update 2026-10-17 21:12:05
+func (s intervalSchedule) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(s))
+}
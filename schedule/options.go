@@ -0,0 +1,70 @@
+package schedule
+
+import (
+	"log/slog"
+	"time"
+)
+
+// Overlap 上一次执行尚未结束时又到了执行时间的处理策略
+type Overlap int
+
+const (
+	// Skip 跳过本次执行
+	Skip Overlap = iota
+	// Queue 上一次结束后立即补执行一次；期间多次到期只补执行一次
+	Queue
+	// Concurrent 与上一次并发执行
+	Concurrent
+)
+
+type options struct {
+	location *time.Location
+	logger   *slog.Logger
+}
+
+// Option New 的选项
+type Option func(*options)
+
+// WithLocation 计算 cron 执行时间使用的时区，默认 time.Local
+//
+//	@param loc *time.Location
+//	@return Option
+//	@update 2026-10-17 21:12:05
+func WithLocation(loc *time.Location) Option {
+	return func(o *options) { o.location = loc }
+}
+
+// WithLogger 记录任务失败、panic 与跳过的日志，默认 slog.Default()
+//
+//	@param logger *slog.Logger
+//	@return Option
+//	@update 2026-10-17 21:12:05
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) { o.logger = logger }
+}
+
+type jobOptions struct {
+	name    string
+	overlap Overlap
+}
+
+// JobOption Add、Cron、Every 的任务选项
+type JobOption func(*jobOptions)
+
+// Name 任务名，用于日志；默认取任务函数名
+//
+//	@param name string
+//	@return JobOption
+//	@update 2026-10-17 21:12:05
+func Name(name string) JobOption {
+	return func(o *jobOptions) { o.name = name }
+}
+
+// WithOverlap 执行重叠时的策略，默认 Skip
+//
+//	@param p Overlap
+//	@return JobOption
+//	@update 2026-10-17 21:12:05
+func WithOverlap(p Overlap) JobOption {
+	return func(o *jobOptions) { o.overlap = p }
+}
@@ -0,0 +1,283 @@
+// Package schedule 支持 cron 表达式与固定间隔的轻量定时任务调度
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+// ErrRunning Run 已在运行
+var ErrRunning = errors.New("schedule: scheduler already running")
+
+// Scheduler 定时任务调度器；任务可在 Run 之前或运行中添加
+type Scheduler struct {
+	opts options
+
+	mu      sync.Mutex
+	jobs    map[*Job]struct{}
+	ctx     context.Context // Run 期间非 nil
+	stop    bool            // Run 的 ctx 已结束、正在等待退出，此时添加的任务不再启动
+	loops   sync.WaitGroup  // 各任务的调度循环
+	running sync.WaitGroup  // 执行中的任务
+}
+
+// Job 已添加的任务
+type Job struct {
+	s        *Scheduler
+	name     string
+	schedule Schedule
+	fn       func(ctx context.Context) error
+	overlap  Overlap
+	stop     chan struct{}
+	stopOnce sync.Once
+
+	mu      sync.Mutex
+	next    time.Time
+	active  bool // Skip、Queue 策略下是否有执行中的实例
+	pending bool // Queue 策略下是否需要补执行
+}
+
+// New 创建调度器
+//
+//	@param opts ...Option
+//	@return *Scheduler
+//	@update 2026-10-17 21:12:05
+//
+// for example:
+//
+//	s := schedule.New(schedule.WithLocation(time.UTC))
+//	_, _ = s.Cron("0 3 * * *", cleanupExpired)
+//	_, _ = s.Every(30*time.Second, refreshConfig, schedule.WithOverlap(schedule.Queue))
+//	_ = s.Run(ctx) // ctx 结束后等待执行中的任务返回
+func New(opts ...Option) *Scheduler {
+	o := options{location: time.Local, logger: slog.Default()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Scheduler{opts: o, jobs: map[*Job]struct{}{}}
+}
+
+// Add 按 schedule 添加任务 fn；fn 收到的 ctx 在 Run 的 ctx 结束时取消，返回错误或 panic 时记录日志
+//
+//	@receiver s *Scheduler
+//	@param schedule Schedule
+//	@param fn func(ctx context.Context) error
+//	@param opts ...JobOption
+//	@return *Job
+//	@update 2026-10-18 12:22:53
+func (s *Scheduler) Add(schedule Schedule, fn func(ctx context.Context) error, opts ...JobOption) *Job {
+	var o jobOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.name == "" {
+		o.name = reflecting.GetFunctionName(fn)
+	}
+	j := &Job{
+		s:        s,
+		name:     o.name,
+		schedule: schedule,
+		fn:       fn,
+		overlap:  o.overlap,
+		stop:     make(chan struct{}),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[j] = struct{}{}
+	if s.ctx != nil && !s.stop {
+		s.startLocked(j)
+	}
+	return j
+}
+
+// Cron 按 cron 表达式添加任务，表达式语法见 ParseCron
+//
+//	@receiver s *Scheduler
+//	@param expr string
+//	@param fn func(ctx context.Context) error
+//	@param opts ...JobOption
+//	@return *Job
+//	@return error 表达式无效时返回
+//	@update 2026-10-17 21:12:05
+func (s *Scheduler) Cron(expr string, fn func(ctx context.Context) error, opts ...JobOption) (*Job, error) {
+	schedule, err := ParseCron(expr)
+	if err != nil {
+		return nil, err
+	}
+	return s.Add(schedule, fn, opts...), nil
+}
+
+// Every 按固定间隔 d 添加任务，首次执行在开始调度 d 之后
+//
+//	@receiver s *Scheduler
+//	@param d time.Duration
+//	@param fn func(ctx context.Context) error
+//	@param opts ...JobOption
+//	@return *Job
+//	@return error d 不大于 0 时返回
+//	@update 2026-10-17 21:12:05
+func (s *Scheduler) Every(d time.Duration, fn func(ctx context.Context) error, opts ...JobOption) (*Job, error) {
+	if d <= 0 {
+		return nil, fmt.Errorf("schedule: interval must be positive, got %s", d)
+	}
+	return s.Add(Every(d), fn, opts...), nil
+}
+
+// Run 开始调度并阻塞到 ctx 结束，随后等待执行中的任务返回
+//
+//	@receiver s *Scheduler
+//	@param ctx context.Context
+//	@return error 重复调用时返回 ErrRunning
+//	@update 2026-10-18 12:22:53
+func (s *Scheduler) Run(ctx context.Context) error {
+	s.mu.Lock()
+	if s.ctx != nil {
+		s.mu.Unlock()
+		return ErrRunning
+	}
+	s.ctx = ctx
+	for j := range s.jobs {
+		s.startLocked(j)
+	}
+	s.mu.Unlock()
+
+	<-ctx.Done()
+	// 先在锁内标记退出，保证 Wait 期间并发的 Add 不再对 loops 调用 Add
+	s.mu.Lock()
+	s.stop = true
+	s.mu.Unlock()
+	s.loops.Wait()
+	s.running.Wait()
+
+	s.mu.Lock()
+	s.ctx, s.stop = nil, false
+	s.mu.Unlock()
+	return nil
+}
+
+// startLocked 启动任务的调度循环；调用方需持有 s.mu
+func (s *Scheduler) startLocked(j *Job) {
+	ctx := s.ctx
+	s.loops.Add(1)
+	go func() {
+		defer s.loops.Done()
+		j.loop(ctx)
+	}()
+}
+
+// Name 返回任务名
+//
+//	@receiver j *Job
+//	@return string
+//	@update 2026-10-17 21:12:05
+func (j *Job) Name() string {
+	return j.name
+}
+
+// Next 返回下一次执行时间，未在调度中时为零值
+//
+//	@receiver j *Job
+//	@return time.Time
+//	@update 2026-10-17 21:12:05
+func (j *Job) Next() time.Time {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.next
+}
+
+// Remove 移除任务，不影响执行中的实例；可重复调用
+//
+//	@receiver j *Job
+//	@update 2026-10-17 21:12:05
+func (j *Job) Remove() {
+	j.s.mu.Lock()
+	delete(j.s.jobs, j)
+	j.s.mu.Unlock()
+	j.stopOnce.Do(func() { close(j.stop) })
+}
+
+func (j *Job) loop(ctx context.Context) {
+	timer := time.NewTimer(0)
+	<-timer.C
+	defer timer.Stop()
+	now := time.Now()
+	for {
+		next := j.schedule.Next(now.In(j.s.opts.location))
+		j.mu.Lock()
+		j.next = next
+		j.mu.Unlock()
+		if next.IsZero() {
+			return
+		}
+		timer.Reset(time.Until(next))
+		select {
+		case <-ctx.Done():
+			return
+		case <-j.stop:
+			return
+		case now = <-timer.C:
+			j.dispatch(ctx)
+		}
+	}
+}
+
+// dispatch 按重叠策略触发一次执行
+func (j *Job) dispatch(ctx context.Context) {
+	if j.overlap == Concurrent {
+		j.s.running.Add(1)
+		go func() {
+			defer j.s.running.Done()
+			j.exec(ctx)
+		}()
+		return
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.active {
+		if j.overlap == Queue {
+			j.pending = true
+			return
+		}
+		j.s.opts.logger.Warn("schedule: job still running, skipped", "job", j.name)
+		return
+	}
+	j.active = true
+	j.s.running.Add(1)
+	go func() {
+		defer j.s.running.Done()
+		for {
+			j.exec(ctx)
+			j.mu.Lock()
+			if !j.pending || ctx.Err() != nil {
+				j.active, j.pending = false, false
+				j.mu.Unlock()
+				return
+			}
+			j.pending = false
+			j.mu.Unlock()
+		}
+	}()
+}
+
+func (j *Job) exec(ctx context.Context) {
+	start := time.Now()
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = reflecting.NewPanicError(r)
+			}
+		}()
+		return j.fn(ctx)
+	}()
+	if err != nil {
+		j.s.opts.logger.Error("schedule: job failed", "job", j.name, "elapsed", time.Since(start), "err", err)
+	}
+}
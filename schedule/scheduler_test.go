@@ -0,0 +1,53 @@
+package schedule_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/BetaGoRobot/go_utils/schedule"
+	"github.com/BetaGoRobot/go_utils/testx"
+)
+
+// TestAddDuringShutdown Run 等待退出期间并发 Add 不应启动新的调度循环，配合 -race 检查
+func TestAddDuringShutdown(t *testing.T) {
+	noop := func(context.Context) error { return nil }
+	for range 50 {
+		s := schedule.New()
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() { done <- s.Run(ctx) }()
+
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 20 {
+					_, _ = s.Every(time.Hour, noop)
+				}
+			}()
+		}
+		cancel()
+		wg.Wait()
+		testx.ErrorIs(t, <-done, nil)
+	}
+}
+
+// TestRunAgainStartsJobsAddedDuringShutdown 退出期间添加的任务在下次 Run 时调度
+func TestRunAgainStartsJobsAddedDuringShutdown(t *testing.T) {
+	s := schedule.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	testx.ErrorIs(t, s.Run(ctx), nil)
+
+	j, err := s.Every(time.Hour, func(context.Context) error { return nil })
+	testx.ErrorIs(t, err, nil)
+	testx.Equal(t, true, j.Next().IsZero())
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = s.Run(ctx) }()
+	testx.Eventually(t, func() bool { return !j.Next().IsZero() }, time.Second, 5*time.Millisecond)
+}
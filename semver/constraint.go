@@ -0,0 +1,289 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidConstraint 约束表达式格式错误
+var ErrInvalidConstraint = errors.New("semver: invalid constraint")
+
+// comparator 单个比较条件；op 为 out 时表示不在 [v, hi) 区间内
+type comparator struct {
+	op string
+	v  Version
+	hi Version
+}
+
+func (c comparator) check(v Version) bool {
+	n := v.Compare(c.v)
+	switch c.op {
+	case "=":
+		return n == 0
+	case "!=":
+		return n != 0
+	case ">":
+		return n > 0
+	case ">=":
+		return n >= 0
+	case "<":
+		return n < 0
+	case "<=":
+		return n <= 0
+	case "out":
+		return n < 0 || v.Compare(c.hi) >= 0
+	}
+	return false
+}
+
+// Constraint 版本约束，由 || 分隔的若干组条件构成，组内条件需全部满足
+type Constraint struct {
+	raw    string
+	groups [][]comparator
+}
+
+// ParseConstraint 解析版本约束
+//
+//	组内条件以空格或逗号分隔，组之间以 || 分隔，支持：
+//	  =、!=、>、>=、<、<=        省略的部分补 0，如 <2 即 <2.0.0；>1.2 即 >=1.3.0
+//	  1.2、1.2.x、1.*、*         通配，1.2 即 >=1.2.0 <1.3.0
+//	  ~1.2.3                     >=1.2.3 <1.3.0（~1 为 >=1.0.0 <2.0.0）
+//	  ^1.2.3                     >=1.2.3 <2.0.0（^0.2.3 为 <0.3.0，^0.0.3 为 <0.0.4）
+//	  1.2 - 2.3                  >=1.2.0 <2.4.0
+//	与 npm 一致，预发布版本只有在同组内某个条件的版本号带预发布且 MAJOR.MINOR.PATCH 相同时才可能满足
+//
+//	@param s string
+//	@return *Constraint
+//	@return error
+//	@update 2026-10-18 04:31:06
+//
+// for example:
+//
+//	c, err := semver.ParseConstraint(">=1.2.0 <2 || ^3.1")
+//	ok := c.Check(semver.MustParse("1.9.0")) // true
+func ParseConstraint(s string) (*Constraint, error) {
+	c := &Constraint{raw: strings.TrimSpace(s)}
+	for _, part := range strings.Split(s, "||") {
+		group, err := parseGroup(part)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %v", ErrInvalidConstraint, s, err)
+		}
+		c.groups = append(c.groups, group)
+	}
+	return c, nil
+}
+
+// MustParseConstraint 同 ParseConstraint，失败时 panic
+//
+//	@param s string
+//	@return *Constraint
+//	@update 2026-10-18 04:31:06
+func MustParseConstraint(s string) *Constraint {
+	c, err := ParseConstraint(s)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+var operators = []string{">=", "<=", "!=", "==", ">", "<", "=", "~", "^"}
+
+func parseGroup(s string) ([]comparator, error) {
+	tokens := strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == ',' || r == '\t' })
+	// 合并操作符与版本号之间的空格，如 ">= 1.2"
+	var terms []string
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		if isOperator(t) && i+1 < len(tokens) {
+			t += tokens[i+1]
+			i++
+		}
+		terms = append(terms, t)
+	}
+	if len(terms) == 0 {
+		return nil, errors.New("empty constraint")
+	}
+
+	var res []comparator
+	for i := 0; i < len(terms); i++ {
+		// 连字符区间 a - b
+		if i+2 < len(terms) && terms[i+1] == "-" {
+			lo, _, err := parsePartial(terms[i])
+			if err != nil {
+				return nil, err
+			}
+			hi, n, err := parsePartial(terms[i+2])
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, comparator{op: ">=", v: lo})
+			if n == 3 {
+				res = append(res, comparator{op: "<=", v: hi})
+			} else if n > 0 {
+				res = append(res, comparator{op: "<", v: bump(hi, n)})
+			}
+			i += 2
+			continue
+		}
+		cs, err := parseTerm(terms[i])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, cs...)
+	}
+	return res, nil
+}
+
+func isOperator(s string) bool {
+	for _, op := range operators {
+		if s == op {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTerm 将单个条件展开为比较条件
+func parseTerm(t string) ([]comparator, error) {
+	op := ""
+	for _, o := range operators {
+		if strings.HasPrefix(t, o) {
+			op, t = o, t[len(o):]
+			break
+		}
+	}
+	v, n, err := parsePartial(t)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		// 通配符：除 < 与 != 外匹配任意版本
+		switch op {
+		case "<", "!=":
+			return []comparator{{op: "<", v: Version{}}}, nil
+		}
+		return []comparator{{op: ">=", v: Version{}}}, nil
+	}
+	switch op {
+	case "", "=", "==":
+		if n == 3 {
+			return []comparator{{op: "=", v: v}}, nil
+		}
+		return []comparator{{op: ">=", v: v}, {op: "<", v: bump(v, n)}}, nil
+	case "!=":
+		if n == 3 {
+			return []comparator{{op: "!=", v: v}}, nil
+		}
+		return []comparator{{op: "out", v: v, hi: bump(v, n)}}, nil
+	case ">":
+		if n == 3 {
+			return []comparator{{op: ">", v: v}}, nil
+		}
+		return []comparator{{op: ">=", v: bump(v, n)}}, nil
+	case ">=":
+		return []comparator{{op: ">=", v: v}}, nil
+	case "<":
+		return []comparator{{op: "<", v: v}}, nil
+	case "<=":
+		if n == 3 {
+			return []comparator{{op: "<=", v: v}}, nil
+		}
+		return []comparator{{op: "<", v: bump(v, n)}}, nil
+	case "~":
+		return []comparator{{op: ">=", v: v}, {op: "<", v: bump(v, min(n, 2))}}, nil
+	case "^":
+		var level int
+		switch {
+		case v.Major > 0 || n == 1:
+			level = 1
+		case v.Minor > 0 || n == 2:
+			level = 2
+		default:
+			level = 3
+		}
+		return []comparator{{op: ">=", v: v}, {op: "<", v: bump(v, level)}}, nil
+	}
+	return nil, fmt.Errorf("unknown operator %q", op)
+}
+
+// bump 返回第 level 段加一、其后各段清零的正式版本
+func bump(v Version, level int) Version {
+	switch level {
+	case 1:
+		return Version{Major: v.Major + 1}
+	case 2:
+		return Version{Major: v.Major, Minor: v.Minor + 1}
+	}
+	return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+}
+
+// Check 判断 v 是否满足约束
+//
+//	@receiver c *Constraint
+//	@param v Version
+//	@return bool
+//	@update 2026-10-18 04:31:06
+func (c *Constraint) Check(v Version) bool {
+	for _, g := range c.groups {
+		if checkGroup(g, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckString 解析版本号并判断是否满足约束，无法解析时返回 false
+//
+//	@receiver c *Constraint
+//	@param s string
+//	@return bool
+//	@update 2026-10-18 04:31:06
+func (c *Constraint) CheckString(s string) bool {
+	v, err := Parse(s)
+	return err == nil && c.Check(v)
+}
+
+func checkGroup(g []comparator, v Version) bool {
+	for _, cmp := range g {
+		if !cmp.check(v) {
+			return false
+		}
+	}
+	if !v.IsPrerelease() {
+		return true
+	}
+	for _, cmp := range g {
+		if cmp.v.IsPrerelease() && cmp.v.Major == v.Major && cmp.v.Minor == v.Minor && cmp.v.Patch == v.Patch {
+			return true
+		}
+	}
+	return false
+}
+
+// Latest 返回 vs 中满足约束的最高版本
+//
+//	@receiver c *Constraint
+//	@param vs []Version
+//	@return Version
+//	@return bool 没有满足约束的版本时为 false
+//	@update 2026-10-18 04:31:06
+func (c *Constraint) Latest(vs []Version) (Version, bool) {
+	var best Version
+	found := false
+	for _, v := range vs {
+		if c.Check(v) && (!found || best.Less(v)) {
+			best, found = v, true
+		}
+	}
+	return best, found
+}
+
+// String 返回原始的约束表达式
+//
+//	@receiver c *Constraint
+//	@return string
+//	@update 2026-10-18 04:31:06
+func (c *Constraint) String() string {
+	return c.raw
+}
@@ -0,0 +1,293 @@
+// Package semver 语义化版本的解析、比较、排序与约束匹配
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	commonutils "github.com/BetaGoRobot/go_utils/common_utils"
+)
+
+// ErrInvalidVersion 版本号格式错误
+var ErrInvalidVersion = errors.New("semver: invalid version")
+
+// Version 语义化版本 MAJOR.MINOR.PATCH[-PRERELEASE][+BUILD]
+type Version struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease string // 不含前导 '-'
+	Build      string // 不含前导 '+'，不参与比较
+}
+
+// Parse 解析版本号，允许前导 v 以及省略 MINOR / PATCH（视为 0），如 v1.2、1.2.3-rc.1+build.5
+//
+//	@param s string
+//	@return Version
+//	@return error
+//	@update 2026-10-18 04:31:06
+func Parse(s string) (Version, error) {
+	v, _, err := parsePartial(s)
+	if err != nil {
+		return Version{}, err
+	}
+	return v, nil
+}
+
+// MustParse 同 Parse，失败时 panic，适用于常量
+//
+//	@param s string
+//	@return Version
+//	@update 2026-10-18 04:31:06
+func MustParse(s string) Version {
+	v, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// parsePartial 解析版本号并返回给出的数字段数（0 表示通配符 * / x）
+func parsePartial(s string) (Version, int, error) {
+	orig := s
+	fail := func(reason string) (Version, int, error) {
+		return Version{}, 0, fmt.Errorf("%w %q: %s", ErrInvalidVersion, orig, reason)
+	}
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	var v Version
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		v.Build, s = s[i+1:], s[:i]
+		if !validIdents(v.Build, false) {
+			return fail("bad build metadata")
+		}
+	}
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		v.Prerelease, s = s[i+1:], s[:i]
+		if !validIdents(v.Prerelease, true) {
+			return fail("bad pre-release")
+		}
+	}
+	if s == "" {
+		return fail("empty version")
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return fail("too many components")
+	}
+	nums := []*uint64{&v.Major, &v.Minor, &v.Patch}
+	given := 0
+	for i, p := range parts {
+		if p == "*" || p == "x" || p == "X" {
+			if v.Prerelease != "" || v.Build != "" {
+				return fail("wildcard with pre-release or build")
+			}
+			// 通配符之后的部分必须也是通配符
+			for _, rest := range parts[i+1:] {
+				if rest != "*" && rest != "x" && rest != "X" {
+					return fail("component after wildcard")
+				}
+			}
+			break
+		}
+		if p == "" || (len(p) > 1 && p[0] == '0') {
+			return fail("bad numeric component")
+		}
+		n, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return fail("bad numeric component")
+		}
+		*nums[i] = n
+		given++
+	}
+	return v, given, nil
+}
+
+// validIdents 检查点分标识符；numericNoLeadingZero 时纯数字标识符不允许前导零
+func validIdents(s string, numericNoLeadingZero bool) bool {
+	if s == "" {
+		return false
+	}
+	for _, id := range strings.Split(s, ".") {
+		if id == "" {
+			return false
+		}
+		numeric := true
+		for _, c := range []byte(id) {
+			switch {
+			case c >= '0' && c <= '9':
+			case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '-':
+				numeric = false
+			default:
+				return false
+			}
+		}
+		if numeric && numericNoLeadingZero && len(id) > 1 && id[0] == '0' {
+			return false
+		}
+	}
+	return true
+}
+
+// String 返回不带前导 v 的规范形式
+//
+//	@receiver v Version
+//	@return string
+//	@update 2026-10-18 04:31:06
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// Compare 比较两个版本，忽略 Build
+//
+//	预发布版本低于对应的正式版本；预发布标识符逐个比较：纯数字按数值，纯数字低于含字母的，
+//	其余按 commonutils.NaturalCompare 自然排序（与 SemVer 规范的字典序不同，rc9 < rc10）
+//
+//	@receiver v Version
+//	@param o Version
+//	@return int -1、0 或 1
+//	@update 2026-10-18 04:31:06
+func (v Version) Compare(o Version) int {
+	for _, c := range [][2]uint64{{v.Major, o.Major}, {v.Minor, o.Minor}, {v.Patch, o.Patch}} {
+		if c[0] != c[1] {
+			if c[0] < c[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return comparePrerelease(v.Prerelease, o.Prerelease)
+}
+
+// Less 判断 v 是否低于 o
+//
+//	@receiver v Version
+//	@param o Version
+//	@return bool
+//	@update 2026-10-18 04:31:06
+func (v Version) Less(o Version) bool {
+	return v.Compare(o) < 0
+}
+
+// IsPrerelease 判断是否为预发布版本
+//
+//	@receiver v Version
+//	@return bool
+//	@update 2026-10-18 04:31:06
+func (v Version) IsPrerelease() bool {
+	return v.Prerelease != ""
+}
+
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := range min(len(as), len(bs)) {
+		x, y := as[i], bs[i]
+		nx, errx := strconv.ParseUint(x, 10, 64)
+		ny, erry := strconv.ParseUint(y, 10, 64)
+		switch {
+		case errx == nil && erry == nil:
+			if nx != ny {
+				if nx < ny {
+					return -1
+				}
+				return 1
+			}
+		case errx == nil:
+			return -1
+		case erry == nil:
+			return 1
+		default:
+			if c := commonutils.NaturalCompare(x, y); c != 0 {
+				return c
+			}
+		}
+	}
+	switch {
+	case len(as) < len(bs):
+		return -1
+	case len(as) > len(bs):
+		return 1
+	}
+	return 0
+}
+
+// Compare 比较两个版本，同 a.Compare(b)，可直接用于 slices.SortFunc
+//
+//	@param a Version
+//	@param b Version
+//	@return int
+//	@update 2026-10-18 04:31:06
+func Compare(a, b Version) int {
+	return a.Compare(b)
+}
+
+// CompareStrings 解析并比较两个版本号
+//
+//	@param a string
+//	@param b string
+//	@return int
+//	@return error
+//	@update 2026-10-18 04:31:06
+func CompareStrings(a, b string) (int, error) {
+	va, err := Parse(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := Parse(b)
+	if err != nil {
+		return 0, err
+	}
+	return va.Compare(vb), nil
+}
+
+// Sort 将版本从低到高原地排序，相等的版本保持原有顺序
+//
+//	@param vs []Version
+//	@update 2026-10-18 04:31:06
+func Sort(vs []Version) {
+	slices.SortStableFunc(vs, Compare)
+}
+
+// SortStrings 解析并将版本号字符串从低到高原地排序，有无法解析的版本号时返回错误且不修改 vs
+//
+//	@param vs []string
+//	@return error
+//	@update 2026-10-18 04:31:06
+func SortStrings(vs []string) error {
+	parsed := make([]Version, len(vs))
+	for i, s := range vs {
+		v, err := Parse(s)
+		if err != nil {
+			return err
+		}
+		parsed[i] = v
+	}
+	idx := make([]int, len(vs))
+	for i := range idx {
+		idx[i] = i
+	}
+	slices.SortStableFunc(idx, func(a, b int) int { return parsed[a].Compare(parsed[b]) })
+	sorted := make([]string, len(vs))
+	for i, j := range idx {
+		sorted[i] = vs[j]
+	}
+	copy(vs, sorted)
+	return nil
+}
@@ -0,0 +1,157 @@
+// Package single 泛型的 singleflight：相同 key 的并发调用只执行一次，所有调用方共享结果
+package single
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+type call[V any] struct {
+	done chan struct{}
+	val  V
+	err  error
+	dups int
+}
+
+// Group 按 key 合并并发调用，零值可用
+//
+//	结果不会被缓存：调用结束（成功或失败）后 key 即被遗忘，之后的调用会重新执行 fn
+type Group[K comparable, V any] struct {
+	mu sync.Mutex
+	m  map[K]*call[V]
+}
+
+// Do 执行 fn 并返回结果；同一 key 已有调用在执行时等待并共享其结果，shared 表示结果是否被多个调用方共享
+//
+//	fn 的 panic 会转为 *reflecting.PanicError 返回给所有调用方
+//
+//	@receiver g *Group[K, V]
+//	@param key K
+//	@param fn func() (V, error)
+//	@return V
+//	@return error
+//	@return bool
+//	@update 2026-10-17 19:10:08
+func (g *Group[K, V]) Do(key K, fn func() (V, error)) (v V, err error, shared bool) {
+	return g.DoContext(context.Background(), key, func(context.Context) (V, error) { return fn() })
+}
+
+// DoContext 与 Do 相同，调用方的 ctx 结束时不再等待并返回 ctx.Err()，执行中的 fn 不受影响
+//
+//	fn 收到的 ctx 继承首个调用方 ctx 中的值但不会随其取消，避免一个调用方离开导致其他调用方一起失败
+//
+//	@receiver g *Group[K, V]
+//	@param ctx context.Context
+//	@param key K
+//	@param fn func(context.Context) (V, error)
+//	@return V
+//	@return error
+//	@return bool
+//	@update 2026-10-17 19:10:08
+//
+// for example:
+//
+//	var users single.Group[int64, *User]
+//	u, err, _ := users.DoContext(ctx, id, func(ctx context.Context) (*User, error) {
+//		return db.LoadUser(ctx, id)
+//	})
+func (g *Group[K, V]) DoContext(ctx context.Context, key K, fn func(context.Context) (V, error)) (v V, err error, shared bool) {
+	g.mu.Lock()
+	if g.m == nil {
+		g.m = map[K]*call[V]{}
+	}
+	c, ok := g.m[key]
+	if ok {
+		c.dups++
+	} else {
+		c = &call[V]{done: make(chan struct{})}
+		g.m[key] = c
+		go g.run(context.WithoutCancel(ctx), key, c, fn)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		g.mu.Lock()
+		shared = c.dups > 0
+		g.mu.Unlock()
+		return c.val, c.err, shared
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err(), ok
+	}
+}
+
+// Forget 遗忘 key 上正在执行的调用，之后的调用会重新执行 fn 而不等待它
+//
+//	@receiver g *Group[K, V]
+//	@param key K
+//	@update 2026-10-17 19:10:08
+func (g *Group[K, V]) Forget(key K) {
+	g.mu.Lock()
+	delete(g.m, key)
+	g.mu.Unlock()
+}
+
+func (g *Group[K, V]) run(ctx context.Context, key K, c *call[V], fn func(context.Context) (V, error)) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.err = reflecting.NewPanicError(r)
+		}
+		g.mu.Lock()
+		if g.m[key] == c {
+			delete(g.m, key)
+		}
+		g.mu.Unlock()
+		close(c.done)
+	}()
+	c.val, c.err = fn(ctx)
+}
+
+// globalKey 全局 Group 的键，带上结果类型以免不同类型的调用因 key 相同而共享
+type globalKey struct {
+	key any
+	typ reflect.Type
+}
+
+var global Group[globalKey, any]
+
+// Do 在全局 Group 上执行 fn，相同类型的 key 与 V 的并发调用只执行一次，语义同 Group.Do
+//
+//	@param key K
+//	@param fn func() (V, error)
+//	@return V
+//	@return error
+//	@return bool
+//	@update 2026-10-17 19:10:08
+func Do[K comparable, V any](key K, fn func() (V, error)) (V, error, bool) {
+	return DoContext(context.Background(), key, func(context.Context) (V, error) { return fn() })
+}
+
+// DoContext 在全局 Group 上执行 fn，语义同 Group.DoContext
+//
+//	@param ctx context.Context
+//	@param key K
+//	@param fn func(context.Context) (V, error)
+//	@return V
+//	@return error
+//	@return bool
+//	@update 2026-10-17 19:10:08
+func DoContext[K comparable, V any](ctx context.Context, key K, fn func(context.Context) (V, error)) (V, error, bool) {
+	v, err, shared := global.DoContext(ctx, globalKey{key: key, typ: reflect.TypeFor[V]()}, func(ctx context.Context) (any, error) {
+		return fn(ctx)
+	})
+	res, _ := v.(V)
+	return res, err, shared
+}
+
+// Forget 遗忘全局 Group 中 key 上正在执行的、结果类型为 V 的调用
+//
+//	@param key K
+//	@update 2026-10-17 19:10:08
+func Forget[K comparable, V any](key K) {
+	global.Forget(globalKey{key: key, typ: reflect.TypeFor[V]()})
+}
@@ -0,0 +1,359 @@
+// Package sortedmap 基于跳表的有序 map，支持按键区间遍历与按排名查询
+package sortedmap
+
+import (
+	"cmp"
+	"iter"
+	"math/bits"
+	"math/rand/v2"
+)
+
+const maxLevel = 32
+
+// node 跳表节点，span[i] 为第 i 层从本节点到 next[i] 跨过的节点数，用于排名查询
+type node[K cmp.Ordered, V any] struct {
+	key  K
+	val  V
+	next []*node[K, V]
+	span []int
+}
+
+// SortedMap 按键升序排列的 map，基于可索引跳表，查找、插入、删除与排名查询均为期望 O(log n)；零值可用，非并发安全
+//
+//	键的比较使用 cmp.Compare，浮点数 NaN 视为小于所有其他值
+type SortedMap[K cmp.Ordered, V any] struct {
+	head   *node[K, V]
+	level  int
+	length int
+}
+
+// New 创建空的 SortedMap
+//
+//	@return *SortedMap[K, V]
+//	@update 2026-10-18 06:40:52
+//
+// for example:
+//
+//	scores := sortedmap.New[int64, string]()
+//	scores.Set(1700000000, "a")
+//	for ts, v := range scores.Range(from, to) {
+//		...
+//	}
+func New[K cmp.Ordered, V any]() *SortedMap[K, V] {
+	return &SortedMap[K, V]{}
+}
+
+func (m *SortedMap[K, V]) init() {
+	if m.head == nil {
+		m.head = &node[K, V]{next: make([]*node[K, V], maxLevel), span: make([]int, maxLevel)}
+		m.level = 1
+	}
+}
+
+// Len 返回键的数量
+//
+//	@receiver m *SortedMap[K, V]
+//	@return int
+//	@update 2026-10-18 06:40:52
+func (m *SortedMap[K, V]) Len() int {
+	return m.length
+}
+
+// Get 返回键 k 对应的值
+//
+//	@receiver m *SortedMap[K, V]
+//	@param k K
+//	@return V
+//	@return bool
+//	@update 2026-10-18 06:40:52
+func (m *SortedMap[K, V]) Get(k K) (V, bool) {
+	if n := m.ceiling(k); n != nil && cmp.Compare(n.key, k) == 0 {
+		return n.val, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Set 设置键 k 的值，已存在时替换
+//
+//	@receiver m *SortedMap[K, V]
+//	@param k K
+//	@param v V
+//	@update 2026-10-18 06:40:52
+func (m *SortedMap[K, V]) Set(k K, v V) {
+	m.init()
+	var (
+		update [maxLevel]*node[K, V]
+		rank   [maxLevel]int
+	)
+	x := m.head
+	for i := m.level - 1; i >= 0; i-- {
+		if i < m.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for x.next[i] != nil && cmp.Less(x.next[i].key, k) {
+			rank[i] += x.span[i]
+			x = x.next[i]
+		}
+		update[i] = x
+	}
+	if n := x.next[0]; n != nil && cmp.Compare(n.key, k) == 0 {
+		n.val = v
+		return
+	}
+
+	lvl := randomLevel()
+	if lvl > m.level {
+		for i := m.level; i < lvl; i++ {
+			update[i] = m.head
+			rank[i] = 0
+			m.head.span[i] = m.length
+		}
+		m.level = lvl
+	}
+	n := &node[K, V]{key: k, val: v, next: make([]*node[K, V], lvl), span: make([]int, lvl)}
+	for i := range lvl {
+		n.next[i] = update[i].next[i]
+		update[i].next[i] = n
+		n.span[i] = update[i].span[i] - (rank[0] - rank[i])
+		update[i].span[i] = rank[0] - rank[i] + 1
+	}
+	for i := lvl; i < m.level; i++ {
+		update[i].span[i]++
+	}
+	m.length++
+}
+
+// Delete 删除键 k，返回键是否存在
+//
+//	@receiver m *SortedMap[K, V]
+//	@param k K
+//	@return bool
+//	@update 2026-10-18 06:40:52
+func (m *SortedMap[K, V]) Delete(k K) bool {
+	if m.head == nil {
+		return false
+	}
+	var update [maxLevel]*node[K, V]
+	x := m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && cmp.Less(x.next[i].key, k) {
+			x = x.next[i]
+		}
+		update[i] = x
+	}
+	n := x.next[0]
+	if n == nil || cmp.Compare(n.key, k) != 0 {
+		return false
+	}
+	for i := range m.level {
+		if update[i].next[i] == n {
+			update[i].span[i] += n.span[i] - 1
+			update[i].next[i] = n.next[i]
+		} else {
+			update[i].span[i]--
+		}
+	}
+	for m.level > 1 && m.head.next[m.level-1] == nil {
+		m.level--
+	}
+	m.length--
+	return true
+}
+
+// Clear 删除所有键
+//
+//	@receiver m *SortedMap[K, V]
+//	@update 2026-10-18 06:40:52
+func (m *SortedMap[K, V]) Clear() {
+	m.head, m.level, m.length = nil, 0, 0
+}
+
+// Min 返回最小的键及其值，map 为空时 ok 为 false
+//
+//	@receiver m *SortedMap[K, V]
+//	@return K
+//	@return V
+//	@return bool
+//	@update 2026-10-18 06:40:52
+func (m *SortedMap[K, V]) Min() (k K, v V, ok bool) {
+	if m.length == 0 {
+		return k, v, false
+	}
+	n := m.head.next[0]
+	return n.key, n.val, true
+}
+
+// Max 返回最大的键及其值，map 为空时 ok 为 false
+//
+//	@receiver m *SortedMap[K, V]
+//	@return K
+//	@return V
+//	@return bool
+//	@update 2026-10-18 06:40:52
+func (m *SortedMap[K, V]) Max() (k K, v V, ok bool) {
+	if m.length == 0 {
+		return k, v, false
+	}
+	x := m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for x.next[i] != nil {
+			x = x.next[i]
+		}
+	}
+	return x.key, x.val, true
+}
+
+// Floor 返回不大于 k 的最大键及其值
+//
+//	@receiver m *SortedMap[K, V]
+//	@param k K
+//	@return K
+//	@return V
+//	@return bool
+//	@update 2026-10-18 06:40:52
+func (m *SortedMap[K, V]) Floor(k K) (K, V, bool) {
+	var (
+		zk K
+		zv V
+	)
+	if m.head == nil {
+		return zk, zv, false
+	}
+	x := m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && cmp.Compare(x.next[i].key, k) <= 0 {
+			x = x.next[i]
+		}
+	}
+	if x == m.head {
+		return zk, zv, false
+	}
+	return x.key, x.val, true
+}
+
+// Ceiling 返回不小于 k 的最小键及其值
+//
+//	@receiver m *SortedMap[K, V]
+//	@param k K
+//	@return K
+//	@return V
+//	@return bool
+//	@update 2026-10-18 06:40:52
+func (m *SortedMap[K, V]) Ceiling(k K) (K, V, bool) {
+	if n := m.ceiling(k); n != nil {
+		return n.key, n.val, true
+	}
+	var (
+		zk K
+		zv V
+	)
+	return zk, zv, false
+}
+
+// ceiling 返回第一个不小于 k 的节点
+func (m *SortedMap[K, V]) ceiling(k K) *node[K, V] {
+	if m.head == nil {
+		return nil
+	}
+	x := m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && cmp.Less(x.next[i].key, k) {
+			x = x.next[i]
+		}
+	}
+	return x.next[0]
+}
+
+// Rank 返回小于 k 的键的数量，即 k 存在时其从 0 开始的排名
+//
+//	@receiver m *SortedMap[K, V]
+//	@param k K
+//	@return int
+//	@update 2026-10-18 06:40:52
+func (m *SortedMap[K, V]) Rank(k K) int {
+	if m.head == nil {
+		return 0
+	}
+	rank := 0
+	x := m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && cmp.Less(x.next[i].key, k) {
+			rank += x.span[i]
+			x = x.next[i]
+		}
+	}
+	return rank
+}
+
+// At 返回升序排名为 i（从 0 开始）的键及其值，越界时 ok 为 false
+//
+//	@receiver m *SortedMap[K, V]
+//	@param i int
+//	@return K
+//	@return V
+//	@return bool
+//	@update 2026-10-18 06:40:52
+//
+// for example:
+//
+//	// 排行榜第 1 名（键为负分数时即最高分）
+//	score, user, ok := board.At(0)
+func (m *SortedMap[K, V]) At(i int) (k K, v V, ok bool) {
+	if i < 0 || i >= m.length {
+		return k, v, false
+	}
+	target := i + 1
+	traversed := 0
+	x := m.head
+	for l := m.level - 1; l >= 0; l-- {
+		for x.next[l] != nil && traversed+x.span[l] <= target {
+			traversed += x.span[l]
+			x = x.next[l]
+		}
+		if traversed == target {
+			return x.key, x.val, true
+		}
+	}
+	return k, v, false
+}
+
+// All 按键升序遍历所有键值对，遍历期间不得修改 map
+//
+//	@receiver m *SortedMap[K, V]
+//	@return iter.Seq2[K, V]
+//	@update 2026-10-18 06:40:52
+func (m *SortedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if m.head == nil {
+			return
+		}
+		for n := m.head.next[0]; n != nil; n = n.next[0] {
+			if !yield(n.key, n.val) {
+				return
+			}
+		}
+	}
+}
+
+// Range 按键升序遍历 [from, to) 区间内的键值对，遍历期间不得修改 map
+//
+//	@receiver m *SortedMap[K, V]
+//	@param from K
+//	@param to K
+//	@return iter.Seq2[K, V]
+//	@update 2026-10-18 06:40:52
+func (m *SortedMap[K, V]) Range(from, to K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for n := m.ceiling(from); n != nil && cmp.Less(n.key, to); n = n.next[0] {
+			if !yield(n.key, n.val) {
+				return
+			}
+		}
+	}
+}
+
+// randomLevel 以 1/4 的概率逐层晋升
+func randomLevel() int {
+	return min(bits.TrailingZeros64(rand.Uint64()|1<<62)/2+1, maxLevel)
+}
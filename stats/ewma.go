@@ -0,0 +1,85 @@
+package stats
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// EWMA 指数加权的事件速率：每个事件的权重随时间按 exp(-age/tau) 衰减，无需后台定时器
+//
+//	速率从 0 开始，稳定的事件流大约经过 3*tau 后收敛到真实速率的 95%
+type EWMA struct {
+	mu   sync.Mutex
+	tau  float64 // 时间常数，秒
+	rate float64 // last 时刻的速率，每秒
+	last time.Time
+}
+
+// NewEWMA 创建指数加权速率，tau 越大越平滑、对变化的反应越慢
+//
+//	@param tau time.Duration 时间常数，tau 之前的事件权重衰减为 1/e；<= 0 时为 1 分钟
+//	@return *EWMA
+//	@update 2026-10-18 10:29:14
+//
+// for example:
+//
+//	qps := stats.NewEWMA(10 * time.Second)
+//	qps.Add(1) // 每个请求
+//	if qps.Rate() > limit {
+//		// 降级
+//	}
+func NewEWMA(tau time.Duration) *EWMA {
+	if tau <= 0 {
+		tau = time.Minute
+	}
+	return &EWMA{tau: tau.Seconds()}
+}
+
+// Add 记录 n 个事件，n 可为小数（如按字节数加权）
+//
+//	@receiver e *EWMA
+//	@param n float64
+//	@update 2026-10-18 10:29:14
+func (e *EWMA) Add(n float64) {
+	now := time.Now()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.decay(now)
+	e.rate += n / e.tau
+}
+
+// Rate 返回当前的每秒事件数
+//
+//	@receiver e *EWMA
+//	@return float64
+//	@update 2026-10-18 10:29:14
+func (e *EWMA) Rate() float64 {
+	now := time.Now()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.decay(now)
+	return e.rate
+}
+
+// Reset 将速率清零
+//
+//	@receiver e *EWMA
+//	@update 2026-10-18 10:29:14
+func (e *EWMA) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rate, e.last = 0, time.Time{}
+}
+
+// decay 将速率衰减到 now 时刻，调用方须持有锁
+func (e *EWMA) decay(now time.Time) {
+	if !e.last.IsZero() {
+		if dt := now.Sub(e.last).Seconds(); dt > 0 {
+			e.rate *= math.Exp(-dt / e.tau)
+		}
+	}
+	if now.After(e.last) {
+		e.last = now
+	}
+}
@@ -0,0 +1,118 @@
+// Package stats 并发安全的滑动窗口计数与指数加权速率，用于自适应限流等决策
+package stats
+
+import (
+	"sync"
+	"time"
+)
+
+// bucket 一个时间片的计数，epoch 为时间片序号，用于判断是否已过期
+type bucket struct {
+	epoch int64
+	count int64
+}
+
+// SlidingCounter 统计最近 window 内事件数的滑动窗口计数器，以环形时间片实现，精度为 window / buckets
+type SlidingCounter struct {
+	mu      sync.Mutex
+	width   int64 // 时间片宽度，纳秒
+	buckets []bucket
+}
+
+// NewSlidingCounter 创建滑动窗口计数器
+//
+//	@param window time.Duration 窗口长度，<= 0 时为 1s
+//	@param buckets int 时间片数，越多越精确，<= 0 时为 10
+//	@return *SlidingCounter
+//	@update 2026-10-18 10:29:14
+//
+// for example:
+//
+//	errs := stats.NewSlidingCounter(10*time.Second, 10)
+//	errs.Add(1)
+//	if errs.Sum() > 100 {
+//		// 最近 10s 错误超过 100 次
+//	}
+func NewSlidingCounter(window time.Duration, buckets int) *SlidingCounter {
+	if window <= 0 {
+		window = time.Second
+	}
+	if buckets <= 0 {
+		buckets = 10
+	}
+	return &SlidingCounter{
+		width:   max(int64(window)/int64(buckets), 1),
+		buckets: make([]bucket, buckets),
+	}
+}
+
+// Add 记录 n 个事件
+//
+//	@receiver c *SlidingCounter
+//	@param n int64
+//	@update 2026-10-18 10:29:14
+func (c *SlidingCounter) Add(n int64) {
+	epoch := time.Now().UnixNano() / c.width
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b := &c.buckets[epoch%int64(len(c.buckets))]
+	if b.epoch != epoch {
+		b.epoch, b.count = epoch, 0
+	}
+	b.count += n
+}
+
+// Inc 记录 1 个事件
+//
+//	@receiver c *SlidingCounter
+//	@update 2026-10-18 10:29:14
+func (c *SlidingCounter) Inc() {
+	c.Add(1)
+}
+
+// Sum 返回最近 window 内的事件数（含当前未满的时间片）
+//
+//	@receiver c *SlidingCounter
+//	@return int64
+//	@update 2026-10-18 10:29:14
+func (c *SlidingCounter) Sum() int64 {
+	epoch := time.Now().UnixNano() / c.width
+	oldest := epoch - int64(len(c.buckets))
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var sum int64
+	for _, b := range c.buckets {
+		if b.epoch > oldest && b.epoch <= epoch {
+			sum += b.count
+		}
+	}
+	return sum
+}
+
+// Rate 返回最近 window 内的平均每秒事件数
+//
+//	@receiver c *SlidingCounter
+//	@return float64
+//	@update 2026-10-18 10:29:14
+func (c *SlidingCounter) Rate() float64 {
+	return float64(c.Sum()) / c.Window().Seconds()
+}
+
+// Window 返回窗口长度
+//
+//	@receiver c *SlidingCounter
+//	@return time.Duration
+//	@update 2026-10-18 10:29:14
+func (c *SlidingCounter) Window() time.Duration {
+	return time.Duration(c.width * int64(len(c.buckets)))
+}
+
+// Reset 清空计数
+//
+//	@receiver c *SlidingCounter
+//	@update 2026-10-18 10:29:14
+func (c *SlidingCounter) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.buckets)
+}
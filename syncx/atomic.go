@@ -0,0 +1,103 @@
+// Package syncx 泛型的并发安全值包装
+package syncx
+
+import "sync/atomic"
+
+// Atomic 任意类型的原子值，基于 atomic.Pointer 实现，零值可用（Load 返回 T 的零值）
+type Atomic[T any] struct {
+	p atomic.Pointer[T]
+}
+
+// NewAtomic 创建初始值为 v 的原子值
+//
+//	@param v T
+//	@return *Atomic[T]
+//	@update 2026-10-17 23:02:40
+func NewAtomic[T any](v T) *Atomic[T] {
+	a := &Atomic[T]{}
+	a.p.Store(&v)
+	return a
+}
+
+// Load 返回当前值，未存储过时返回零值
+//
+//	@receiver a *Atomic[T]
+//	@return T
+//	@update 2026-10-17 23:02:40
+func (a *Atomic[T]) Load() T {
+	if p := a.p.Load(); p != nil {
+		return *p
+	}
+	var zero T
+	return zero
+}
+
+// Store 存储 v
+//
+//	@receiver a *Atomic[T]
+//	@param v T
+//	@update 2026-10-17 23:02:40
+func (a *Atomic[T]) Store(v T) {
+	a.p.Store(&v)
+}
+
+// Swap 存储 v 并返回旧值
+//
+//	@receiver a *Atomic[T]
+//	@param v T
+//	@return T
+//	@update 2026-10-17 23:02:40
+func (a *Atomic[T]) Swap(v T) T {
+	if p := a.p.Swap(&v); p != nil {
+		return *p
+	}
+	var zero T
+	return zero
+}
+
+// CompareAndSwap 当前值等于 old 时存储 new 并返回 true；按值比较，T 不可比较时 panic（与 atomic.Value 一致）
+//
+//	@receiver a *Atomic[T]
+//	@param old T
+//	@param new T
+//	@return bool
+//	@update 2026-10-17 23:02:40
+func (a *Atomic[T]) CompareAndSwap(old, new T) bool {
+	for {
+		p := a.p.Load()
+		var cur T
+		if p != nil {
+			cur = *p
+		}
+		if any(cur) != any(old) {
+			return false
+		}
+		if a.p.CompareAndSwap(p, &new) {
+			return true
+		}
+	}
+}
+
+// Update 以 fn 原子地更新值并返回新值；存在竞争时 fn 可能被调用多次，不应有副作用
+//
+//	@receiver a *Atomic[T]
+//	@param fn func(old T) T
+//	@return T
+//	@update 2026-10-17 23:02:40
+//
+// for example:
+//
+//	cfg.Update(func(c Config) Config { c.Debug = true; return c })
+func (a *Atomic[T]) Update(fn func(old T) T) T {
+	for {
+		p := a.p.Load()
+		var cur T
+		if p != nil {
+			cur = *p
+		}
+		next := fn(cur)
+		if a.p.CompareAndSwap(p, &next) {
+			return next
+		}
+	}
+}
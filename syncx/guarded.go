@@ -0,0 +1,70 @@
+package syncx
+
+import "sync"
+
+// Guarded 由读写锁保护的值，只能在 Read / Write 的回调中访问，不会忘记解锁；零值可用
+type Guarded[T any] struct {
+	mu sync.RWMutex
+	v  T
+}
+
+// NewGuarded 创建初始值为 v 的 Guarded
+//
+//	@param v T
+//	@return *Guarded[T]
+//	@update 2026-10-17 23:02:40
+func NewGuarded[T any](v T) *Guarded[T] {
+	return &Guarded[T]{v: v}
+}
+
+// Read 持有读锁调用 fn；T 含有 map、切片或指针时，fn 不应修改或在返回后继续持有它们
+//
+//	@receiver g *Guarded[T]
+//	@param fn func(v T)
+//	@update 2026-10-17 23:02:40
+//
+// for example:
+//
+//	sessions.Read(func(m map[string]*Session) { s = m[id] })
+func (g *Guarded[T]) Read(fn func(v T)) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	fn(g.v)
+}
+
+// Write 持有写锁调用 fn，fn 可通过指针修改值
+//
+//	@receiver g *Guarded[T]
+//	@param fn func(v *T)
+//	@update 2026-10-17 23:02:40
+//
+// for example:
+//
+//	sessions.Write(func(m *map[string]*Session) { delete(*m, id) })
+func (g *Guarded[T]) Write(fn func(v *T)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	fn(&g.v)
+}
+
+// Get 返回值的浅拷贝
+//
+//	@receiver g *Guarded[T]
+//	@return T
+//	@update 2026-10-17 23:02:40
+func (g *Guarded[T]) Get() T {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.v
+}
+
+// Set 替换整个值
+//
+//	@receiver g *Guarded[T]
+//	@param v T
+//	@update 2026-10-17 23:02:40
+func (g *Guarded[T]) Set(v T) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.v = v
+}
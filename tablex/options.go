@@ -0,0 +1,82 @@
+package tablex
+
+// Align 列的对齐方式
+type Align uint8
+
+const (
+	// AlignDefault 默认对齐：Render 中为左对齐，RenderStructs 中数字列右对齐、其余左对齐
+	AlignDefault Align = iota
+	// AlignLeft 左对齐
+	AlignLeft
+	// AlignRight 右对齐
+	AlignRight
+	// AlignCenter 居中
+	AlignCenter
+)
+
+// Style 表格的输出样式
+type Style uint8
+
+const (
+	// StyleBox 以 +、-、| 绘制边框（默认）
+	StyleBox Style = iota
+	// StyleMarkdown GitHub 风格的 Markdown 表格，单元格中的 | 会被转义
+	StyleMarkdown
+	// StylePlain 无边框，列之间以两个空格分隔，类似 kubectl get 的输出
+	StylePlain
+)
+
+type options struct {
+	style    Style
+	aligns   []Align
+	maxWidth int
+	tail     string
+	tag      string
+}
+
+// Option Render / RenderStructs 的选项
+type Option func(*options)
+
+// WithStyle 设置输出样式，默认 StyleBox
+//
+//	@param s Style
+//	@return Option
+//	@update 2026-10-18 08:35:27
+func WithStyle(s Style) Option {
+	return func(o *options) { o.style = s }
+}
+
+// WithAligns 按列顺序设置对齐方式，未设置的列使用 AlignDefault
+//
+//	@param aligns ...Align
+//	@return Option
+//	@update 2026-10-18 08:35:27
+func WithAligns(aligns ...Align) Option {
+	return func(o *options) { o.aligns = aligns }
+}
+
+// WithMaxWidth 单元格的最大显示宽度，超出时截断并以 "…" 结尾；<= 0 表示不限制（默认）
+//
+//	@param n int
+//	@return Option
+//	@update 2026-10-18 08:35:27
+func WithMaxWidth(n int) Option {
+	return func(o *options) { o.maxWidth = n }
+}
+
+// WithTag RenderStructs 读取列名的 tag，默认 table；tag 为 "-" 的字段不输出，未设置 tag 的字段使用 Go 字段名
+//
+//	@param tag string
+//	@return Option
+//	@update 2026-10-18 08:35:27
+func WithTag(tag string) Option {
+	return func(o *options) { o.tag = tag }
+}
+
+func newOptions(opts []Option) options {
+	o := options{tail: "…", tag: "table"}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,93 @@
+package tablex
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+// RenderStructs 以结构体切片渲染表格，列取自结构体的导出字段（列名规则见 WithTag），数字列默认右对齐
+//
+//	元素可以是结构体或结构体指针，nil 指针渲染为空行；nil 的指针字段渲染为空，其余字段使用 fmt.Sprint；
+//	T 不是结构体（或结构体指针）时每个元素渲染为单列
+//
+//	@param items []T
+//	@param opts ...Option
+//	@return string
+//	@update 2026-10-18 08:35:27
+//
+// for example:
+//
+//	type row struct {
+//		Name  string  `table:"NAME"`
+//		Ready int     `table:"READY"`
+//		Cost  float64 `table:"-"`
+//	}
+//	fmt.Print(tablex.RenderStructs(rows, tablex.WithStyle(tablex.StylePlain)))
+//	// NAME   READY
+//	// api        3
+func RenderStructs[T any](items []T, opts ...Option) string {
+	o := newOptions(opts)
+	t := reflect.TypeFor[T]()
+	fields := reflecting.FieldsOfType(t, o.tag)
+	if len(fields) == 0 {
+		rows := make([][]string, len(items))
+		for i, item := range items {
+			rows[i] = []string{format(reflect.ValueOf(&item).Elem())}
+		}
+		return render(nil, rows, o, nil)
+	}
+
+	headers := make([]string, len(fields))
+	defaults := make([]Align, len(fields))
+	for i, f := range fields {
+		headers[i] = f.Key()
+		if isNumeric(f.Type) {
+			defaults[i] = AlignRight
+		}
+	}
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		sv := reflect.ValueOf(item)
+		for sv.Kind() == reflect.Pointer && !sv.IsNil() {
+			sv = sv.Elem()
+		}
+		row := make([]string, len(fields))
+		if sv.Kind() == reflect.Struct {
+			for j, f := range fields {
+				row[j] = format(f.Value(sv))
+			}
+		}
+		rows[i] = row
+	}
+	return render(headers, rows, o, defaults)
+}
+
+func isNumeric(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		// time.Duration 等实现了 fmt.Stringer 的数字类型按文本处理
+		return !t.Implements(reflect.TypeFor[fmt.Stringer]())
+	}
+	return false
+}
+
+// format 格式化单元格，nil 指针与无效值为空
+func format(v reflect.Value) string {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() || !v.CanInterface() {
+		return ""
+	}
+	return fmt.Sprint(v.Interface())
+}
@@ -0,0 +1,186 @@
+// Package tablex 将表格渲染为文本，用于 CLI 输出与调试日志：按显示宽度对齐（兼容中文等宽字符）、截断与 Markdown 输出
+package tablex
+
+import (
+	"strings"
+
+	commonutils "github.com/BetaGoRobot/go_utils/common_utils"
+)
+
+// whitespace 单元格中会破坏行结构的空白字符
+var whitespace = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ", "\t", " ")
+
+// Render 渲染表格，headers 为 nil 时不输出表头；行的列数可以不同，缺少的单元格视为空
+//
+//	单元格中的换行与制表符被替换为空格；结果以换行结尾
+//
+//	@param headers []string
+//	@param rows [][]string
+//	@param opts ...Option
+//	@return string
+//	@update 2026-10-18 08:35:27
+//
+// for example:
+//
+//	fmt.Print(tablex.Render([]string{"ID", "Name"}, [][]string{{"1", "张三"}, {"2", "bob"}},
+//		tablex.WithAligns(tablex.AlignRight)))
+//	// +----+------+
+//	// | ID | Name |
+//	// +----+------+
+//	// |  1 | 张三 |
+//	// |  2 | bob  |
+//	// +----+------+
+func Render(headers []string, rows [][]string, opts ...Option) string {
+	return render(headers, rows, newOptions(opts), nil)
+}
+
+// render 渲染表格，defaults 为各列在 AlignDefault 时使用的对齐方式
+func render(headers []string, rows [][]string, o options, defaults []Align) string {
+	cols := len(headers)
+	for _, row := range rows {
+		cols = max(cols, len(row))
+	}
+	if cols == 0 {
+		return ""
+	}
+
+	clean := func(row []string) []string {
+		res := make([]string, cols)
+		for i := range min(len(row), cols) {
+			s := whitespace.Replace(row[i])
+			if o.style == StyleMarkdown {
+				s = strings.ReplaceAll(s, "|", `\|`)
+			}
+			if o.maxWidth > 0 {
+				s = commonutils.TruncateWidth(s, o.maxWidth, o.tail)
+			}
+			res[i] = s
+		}
+		return res
+	}
+	var header []string
+	if headers != nil {
+		header = clean(headers)
+	}
+	body := make([][]string, len(rows))
+	for i, row := range rows {
+		body[i] = clean(row)
+	}
+
+	widths := make([]int, cols)
+	for _, row := range append([][]string{header}, body...) {
+		for i, s := range row {
+			widths[i] = max(widths[i], commonutils.DisplayWidth(s))
+		}
+	}
+	if o.style == StyleMarkdown {
+		// Markdown 分隔行至少需要 3 个 -
+		for i := range widths {
+			widths[i] = max(widths[i], 3)
+		}
+	}
+	aligns := make([]Align, cols)
+	for i := range aligns {
+		switch {
+		case i < len(o.aligns) && o.aligns[i] != AlignDefault:
+			aligns[i] = o.aligns[i]
+		case i < len(defaults) && defaults[i] != AlignDefault:
+			aligns[i] = defaults[i]
+		default:
+			aligns[i] = AlignLeft
+		}
+	}
+
+	t := table{widths: widths, aligns: aligns}
+	switch o.style {
+	case StyleMarkdown:
+		if header == nil {
+			// Markdown 表格必须有表头
+			header = make([]string, cols)
+		}
+		t.row(header, "| ", " | ", " |")
+		t.markdownRule()
+		for _, row := range body {
+			t.row(row, "| ", " | ", " |")
+		}
+	case StylePlain:
+		if header != nil {
+			t.row(header, "", "  ", "")
+		}
+		for _, row := range body {
+			t.row(row, "", "  ", "")
+		}
+	default:
+		t.rule()
+		if header != nil {
+			t.row(header, "| ", " | ", " |")
+			t.rule()
+		}
+		for _, row := range body {
+			t.row(row, "| ", " | ", " |")
+		}
+		if len(body) > 0 {
+			t.rule()
+		}
+	}
+	return t.sb.String()
+}
+
+type table struct {
+	sb     strings.Builder
+	widths []int
+	aligns []Align
+}
+
+func (t *table) row(cells []string, left, sep, right string) {
+	var line strings.Builder
+	line.WriteString(left)
+	for i, s := range cells {
+		if i > 0 {
+			line.WriteString(sep)
+		}
+		line.WriteString(pad(s, t.widths[i], t.aligns[i]))
+	}
+	line.WriteString(right)
+	t.sb.WriteString(strings.TrimRight(line.String(), " "))
+	t.sb.WriteByte('\n')
+}
+
+func (t *table) rule() {
+	t.sb.WriteByte('+')
+	for _, w := range t.widths {
+		t.sb.WriteString(strings.Repeat("-", w+2))
+		t.sb.WriteByte('+')
+	}
+	t.sb.WriteByte('\n')
+}
+
+func (t *table) markdownRule() {
+	t.sb.WriteByte('|')
+	for i, w := range t.widths {
+		dashes := strings.Repeat("-", w)
+		switch t.aligns[i] {
+		case AlignRight:
+			dashes = dashes[:w-1] + ":"
+		case AlignCenter:
+			dashes = ":" + dashes[:w-2] + ":"
+		}
+		t.sb.WriteString(" " + dashes + " |")
+	}
+	t.sb.WriteByte('\n')
+}
+
+func pad(s string, width int, align Align) string {
+	gap := width - commonutils.DisplayWidth(s)
+	if gap <= 0 {
+		return s
+	}
+	switch align {
+	case AlignRight:
+		return strings.Repeat(" ", gap) + s
+	case AlignCenter:
+		return strings.Repeat(" ", gap/2) + s + strings.Repeat(" ", gap-gap/2)
+	default:
+		return s + strings.Repeat(" ", gap)
+	}
+}
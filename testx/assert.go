@@ -0,0 +1,149 @@
+// Package testx 基于泛型的最小断言集合，失败时通过 t.Errorf 报告并返回 false，不中断测试
+package testx
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+// Equal 断言 got == want
+//
+//	@param t testing.TB
+//	@param want T
+//	@param got T
+//	@param msgAndArgs ...any 可选的失败说明，首个元素为格式串
+//	@return bool 断言是否成立
+//	@update 2026-10-18 09:24:50
+//
+// for example:
+//
+//	testx.Equal(t, 3, len(items))
+//	testx.Equal(t, "ok", resp.Status, "request %d", i)
+func Equal[T comparable](t testing.TB, want, got T, msgAndArgs ...any) bool {
+	t.Helper()
+	if want == got {
+		return true
+	}
+	fail(t, fmt.Sprintf("not equal:\n\twant: %#v\n\tgot:  %#v", want, got), msgAndArgs)
+	return false
+}
+
+// DeepEqual 按 reflect.DeepEqual 的语义断言 got 与 want 相等，失败时逐项列出不相等的字段路径
+//
+//	@param t testing.TB
+//	@param want any
+//	@param got any
+//	@param msgAndArgs ...any
+//	@return bool
+//	@update 2026-10-18 09:24:50
+func DeepEqual(t testing.TB, want, got any, msgAndArgs ...any) bool {
+	t.Helper()
+	equal, diffs := reflecting.DeepEqualDiff(want, got)
+	if equal {
+		return true
+	}
+	report := strings.TrimRight(reflecting.FormatDiffs(diffs), "\n")
+	fail(t, "not deep equal:\n\t"+strings.ReplaceAll(report, "\n", "\n\t"), msgAndArgs)
+	return false
+}
+
+// ErrorIs 断言 errors.Is(err, target)；target 为 nil 时即断言 err 为 nil
+//
+//	@param t testing.TB
+//	@param err error
+//	@param target error
+//	@param msgAndArgs ...any
+//	@return bool
+//	@update 2026-10-18 09:24:50
+func ErrorIs(t testing.TB, err, target error, msgAndArgs ...any) bool {
+	t.Helper()
+	if errors.Is(err, target) {
+		return true
+	}
+	if target == nil {
+		fail(t, fmt.Sprintf("unexpected error: %v", err), msgAndArgs)
+		return false
+	}
+	fail(t, fmt.Sprintf("error is not target:\n\terr:    %v\n\ttarget: %v", err, target), msgAndArgs)
+	return false
+}
+
+// Eventually 每隔 tick 检查一次 cond，断言其在 timeout 内返回 true
+//
+//	@param t testing.TB
+//	@param cond func() bool
+//	@param timeout time.Duration
+//	@param tick time.Duration
+//	@param msgAndArgs ...any
+//	@return bool
+//	@update 2026-10-18 09:24:50
+//
+// for example:
+//
+//	testx.Eventually(t, func() bool { return b.Len() == 0 }, time.Second, 10*time.Millisecond)
+func Eventually(t testing.TB, cond func() bool, timeout, tick time.Duration, msgAndArgs ...any) bool {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+	for {
+		if cond() {
+			return true
+		}
+		if !time.Now().Before(deadline) {
+			fail(t, fmt.Sprintf("condition not satisfied within %s", timeout), msgAndArgs)
+			return false
+		}
+		<-ticker.C
+	}
+}
+
+// Panics 断言 fn 发生 panic，返回 recover 得到的值
+//
+//	@param t testing.TB
+//	@param fn func()
+//	@param msgAndArgs ...any
+//	@return any fn 未 panic 时为 nil
+//	@update 2026-10-18 09:24:50
+func Panics(t testing.TB, fn func(), msgAndArgs ...any) any {
+	t.Helper()
+	r, panicked := catch(fn)
+	if !panicked {
+		fail(t, "function did not panic", msgAndArgs)
+	}
+	return r
+}
+
+// catch 执行 fn 并捕获 panic，panic(nil) 也视为发生了 panic
+func catch(fn func()) (r any, panicked bool) {
+	defer func() {
+		if panicked {
+			r = recover()
+		}
+	}()
+	panicked = true
+	fn()
+	panicked = false
+	return nil, false
+}
+
+// fail 报告失败，msgAndArgs 非空时附加在末尾
+func fail(t testing.TB, report string, msgAndArgs []any) {
+	t.Helper()
+	if len(msgAndArgs) > 0 {
+		report += "\n\tmessage: " + message(msgAndArgs)
+	}
+	t.Errorf("%s", report)
+}
+
+func message(msgAndArgs []any) string {
+	if format, ok := msgAndArgs[0].(string); ok {
+		return fmt.Sprintf(format, msgAndArgs[1:]...)
+	}
+	return fmt.Sprint(msgAndArgs...)
+}
@@ -0,0 +1,239 @@
+package testx
+
+import (
+	"math"
+	"math/rand/v2"
+	"unicode/utf8"
+)
+
+// Gen 随机值生成器
+//
+//	Generate 按 size 生成一个值，size 随用例序号从 0 增长到 MaxSize，集合类生成器以其作为长度上限（< 0 时按 0 处理）；
+//	Shrink 返回比 v 更简单的候选值，越靠前越简单，用于在属性失败时缩小反例，可为 nil
+type Gen[T any] struct {
+	Generate func(r *rand.Rand, size int) T
+	Shrink   func(v T) []T
+}
+
+func (g Gen[T]) shrink(v T) []T {
+	if g.Shrink == nil {
+		return nil
+	}
+	return g.Shrink(v)
+}
+
+// Int 生成 [lo, hi] 内的整数，向最接近 0 的值缩小
+//
+//	@param lo int
+//	@param hi int
+//	@return Gen[int]
+//	@update 2026-10-18 09:57:42
+func Int(lo, hi int) Gen[int] {
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	target := min(max(0, lo), hi)
+	span := uint64(hi) - uint64(lo)
+	return Gen[int]{
+		Generate: func(r *rand.Rand, _ int) int {
+			if span == math.MaxUint64 {
+				return int(r.Uint64())
+			}
+			return lo + int(r.Uint64N(span+1))
+		},
+		Shrink: func(v int) []int {
+			if v == target {
+				return nil
+			}
+			// 依次尝试 target、v 与 target 的中点……直到只差 1，以无符号差值计算避免溢出
+			var res []int
+			if v > target {
+				for d := uint64(v) - uint64(target); d > 0; d /= 2 {
+					res = append(res, v-int(d))
+				}
+			} else {
+				for d := uint64(target) - uint64(v); d > 0; d /= 2 {
+					res = append(res, v+int(d))
+				}
+			}
+			return res
+		},
+	}
+}
+
+// Bool 生成 bool，向 false 缩小
+//
+//	@return Gen[bool]
+//	@update 2026-10-18 09:57:42
+func Bool() Gen[bool] {
+	return Gen[bool]{
+		Generate: func(r *rand.Rand, _ int) bool { return r.IntN(2) == 1 },
+		Shrink: func(v bool) []bool {
+			if v {
+				return []bool{false}
+			}
+			return nil
+		},
+	}
+}
+
+// OneOf 从 values 中随机选取，向靠前的值缩小；values 为空时 panic
+//
+//	@param values ...T
+//	@return Gen[T]
+//	@update 2026-10-18 09:57:42
+func OneOf[T comparable](values ...T) Gen[T] {
+	if len(values) == 0 {
+		panic("testx: OneOf requires at least one value")
+	}
+	return Gen[T]{
+		Generate: func(r *rand.Rand, _ int) T { return values[r.IntN(len(values))] },
+		Shrink: func(v T) []T {
+			for i, x := range values {
+				if x == v {
+					return values[:i]
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// runePool 除 ASCII 外额外采样的字符：多字节、全角、组合字符与 emoji
+var runePool = []rune("éß中文字😀́ \t\n")
+
+// Rune 生成字符，多数为可打印 ASCII，其余来自常见的多字节字符，向 'a' 缩小
+//
+//	@return Gen[rune]
+//	@update 2026-10-18 09:57:42
+func Rune() Gen[rune] {
+	return Gen[rune]{
+		Generate: func(r *rand.Rand, _ int) rune {
+			if r.IntN(4) == 0 {
+				return runePool[r.IntN(len(runePool))]
+			}
+			return rune(' ' + r.IntN('~'-' '+1))
+		},
+		Shrink: func(v rune) []rune {
+			if v == 'a' {
+				return nil
+			}
+			return []rune{'a'}
+		},
+	}
+}
+
+// String 生成长度不超过 size 个字符的字符串，字符由 Rune 生成
+//
+//	@return Gen[string]
+//	@update 2026-10-18 09:57:42
+func String() Gen[string] {
+	return StringOf(Rune())
+}
+
+// StringOf 生成由 g 产生的字符组成的字符串，按字符删除与缩小
+//
+//	@param g Gen[rune]
+//	@return Gen[string]
+//	@update 2026-10-18 09:57:42
+func StringOf(g Gen[rune]) Gen[string] {
+	runes := SliceOf(g)
+	return Gen[string]{
+		Generate: func(r *rand.Rand, size int) string { return string(runes.Generate(r, size)) },
+		Shrink: func(v string) []string {
+			if !utf8.ValidString(v) {
+				return nil
+			}
+			cands := runes.shrink([]rune(v))
+			res := make([]string, len(cands))
+			for i, c := range cands {
+				res[i] = string(c)
+			}
+			return res
+		},
+	}
+}
+
+// SliceOf 生成长度不超过 size 的切片，缩小时依次尝试空切片、前后半段、删除单个元素与缩小单个元素
+//
+//	@param elem Gen[T]
+//	@return Gen[[]T]
+//	@update 2026-10-18 09:57:42
+func SliceOf[T any](elem Gen[T]) Gen[[]T] {
+	return Gen[[]T]{
+		Generate: func(r *rand.Rand, size int) []T {
+			res := make([]T, r.IntN(max(size, 0)+1))
+			for i := range res {
+				res[i] = elem.Generate(r, size)
+			}
+			return res
+		},
+		Shrink: func(v []T) [][]T {
+			n := len(v)
+			if n == 0 {
+				return nil
+			}
+			res := [][]T{{}}
+			if n > 1 {
+				res = append(res, v[:n/2], v[n/2:])
+			}
+			for i := range n {
+				res = append(res, append(append(make([]T, 0, n-1), v[:i]...), v[i+1:]...))
+			}
+			for i := range n {
+				for _, c := range elem.shrink(v[i]) {
+					s := append([]T(nil), v...)
+					s[i] = c
+					res = append(res, s)
+				}
+			}
+			return res
+		},
+	}
+}
+
+// MapOf 生成键数不超过 size 的 map，缩小时依次尝试空 map、删除单个键与缩小单个值
+//
+//	@param key Gen[K]
+//	@param value Gen[V]
+//	@return Gen[map[K]V]
+//	@update 2026-10-18 09:57:42
+func MapOf[K comparable, V any](key Gen[K], value Gen[V]) Gen[map[K]V] {
+	return Gen[map[K]V]{
+		Generate: func(r *rand.Rand, size int) map[K]V {
+			n := r.IntN(max(size, 0) + 1)
+			res := make(map[K]V, n)
+			for range n {
+				res[key.Generate(r, size)] = value.Generate(r, size)
+			}
+			return res
+		},
+		Shrink: func(v map[K]V) []map[K]V {
+			if len(v) == 0 {
+				return nil
+			}
+			res := []map[K]V{{}}
+			for k := range v {
+				m := clone(v)
+				delete(m, k)
+				res = append(res, m)
+			}
+			for k, x := range v {
+				for _, c := range value.shrink(x) {
+					m := clone(v)
+					m[k] = c
+					res = append(res, m)
+				}
+			}
+			return res
+		},
+	}
+}
+
+func clone[K comparable, V any](m map[K]V) map[K]V {
+	res := make(map[K]V, len(m))
+	for k, v := range m {
+		res[k] = v
+	}
+	return res
+}
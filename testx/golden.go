@@ -0,0 +1,226 @@
+package testx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+const maxLineDiffs = 10
+
+// updateEnv 设置为非空时 Golden 以更新模式运行，便于一次更新多个包：GOLDEN_UPDATE=1 go test ./...
+const updateEnv = "GOLDEN_UPDATE"
+
+// RegisterUpdateFlag 注册 Golden 使用的 -update 命令行参数，已存在同名参数时不做任何事；
+// 须在测试包初始化阶段（包级变量或 init）调用，之后的注册不会被 go test 解析
+//
+//	testx 不在导入时注册，以免与测试包自己定义的 -update 冲突；未注册时可使用环境变量 GOLDEN_UPDATE
+//
+//	@return bool 总是返回 true，便于以包级变量的形式调用
+//	@update 2026-10-18 11:34:09
+//
+// for example:
+//
+//	var _ = testx.RegisterUpdateFlag()
+//
+//	func TestRender(t *testing.T) {
+//		testx.Golden(t, "render", render())
+//	}
+func RegisterUpdateFlag() bool {
+	if flag.Lookup("update") == nil {
+		flag.Bool("update", false, "testx: rewrite golden files with the actual output")
+	}
+	return true
+}
+
+// updating 是否以 -update（由 RegisterUpdateFlag 或测试包自己注册的 bool 参数）或 GOLDEN_UPDATE 运行
+func updating() bool {
+	if os.Getenv(updateEnv) != "" {
+		return true
+	}
+	f := flag.Lookup("update")
+	if f == nil {
+		return false
+	}
+	getter, ok := f.Value.(flag.Getter)
+	if !ok {
+		return false
+	}
+	v, _ := getter.Get().(bool)
+	return v
+}
+
+type goldenOptions struct {
+	normalizers []func([]byte) []byte
+	json        bool
+}
+
+// GoldenOption Golden 的选项
+type GoldenOption func(*goldenOptions)
+
+// Normalize 比较与写入前对实际输出与 golden 文件内容做的处理，多个按顺序执行
+//
+//	@param fn func([]byte) []byte
+//	@return GoldenOption
+//	@update 2026-10-18 09:41:17
+func Normalize(fn func([]byte) []byte) GoldenOption {
+	return func(o *goldenOptions) { o.normalizers = append(o.normalizers, fn) }
+}
+
+// timestampRe RFC 3339 时间戳，可带小数秒与时区
+var timestampRe = regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?`)
+
+// StripTimestamps 将形如 2006-01-02T15:04:05Z07:00、2006-01-02 15:04:05 的时间戳替换为 <timestamp>
+//
+//	@return GoldenOption
+//	@update 2026-10-18 09:41:17
+func StripTimestamps() GoldenOption {
+	return Normalize(func(b []byte) []byte { return timestampRe.ReplaceAll(b, []byte("<timestamp>")) })
+}
+
+// JSON 按 JSON 值比较，忽略格式与对象键的顺序，失败时列出不相等的路径；写入时格式化为缩进的 JSON。
+// name 以 .json 结尾时默认启用
+//
+//	@return GoldenOption
+//	@update 2026-10-18 09:41:17
+func JSON() GoldenOption {
+	return func(o *goldenOptions) { o.json = true }
+}
+
+// Golden 将 got 与 testdata/<name>.golden 比较，以 -update（见 RegisterUpdateFlag）或 GOLDEN_UPDATE=1 运行时
+// 改为用 got 覆盖该文件
+//
+//	@param t testing.TB
+//	@param name string 可包含子目录，如 "warmup/basic"
+//	@param got []byte
+//	@param opts ...GoldenOption
+//	@return bool 是否一致；-update 时写入成功即为 true
+//	@update 2026-10-18 11:34:09
+//
+// for example:
+//
+//	out := render(input)
+//	testx.Golden(t, "render/basic", out, testx.StripTimestamps())
+//	// 更新：go test -run TestRender -update，或 GOLDEN_UPDATE=1 go test ./...
+func Golden(t testing.TB, name string, got []byte, opts ...GoldenOption) bool {
+	t.Helper()
+	o := goldenOptions{json: strings.HasSuffix(name, ".json")}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	path := filepath.Join("testdata", filepath.FromSlash(name)+".golden")
+	got = o.normalize(got)
+
+	if updating() {
+		if err := writeGolden(path, got, o.json); err != nil {
+			t.Errorf("testx: update golden %s: %v", path, err)
+			return false
+		}
+		return true
+	}
+
+	want, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("testx: golden file %s does not exist, run the test with -update or %s=1 to create it", path, updateEnv)
+		return false
+	}
+	if err != nil {
+		t.Errorf("testx: read golden %s: %v", path, err)
+		return false
+	}
+	want = o.normalize(want)
+
+	var report string
+	if o.json {
+		report, err = jsonDiff(want, got)
+		if err != nil {
+			t.Errorf("testx: golden %s: %v", path, err)
+			return false
+		}
+	} else if !bytes.Equal(want, got) {
+		report = lineDiff(want, got)
+	}
+	if report == "" {
+		return true
+	}
+	t.Errorf("golden %s mismatch (run with -update or %s=1 to accept):\n\t%s", path, updateEnv, strings.ReplaceAll(report, "\n", "\n\t"))
+	return false
+}
+
+func (o goldenOptions) normalize(b []byte) []byte {
+	for _, fn := range o.normalizers {
+		b = fn(b)
+	}
+	return b
+}
+
+func writeGolden(path string, got []byte, isJSON bool) error {
+	if isJSON {
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, got, "", "  "); err != nil {
+			return fmt.Errorf("invalid JSON: %w", err)
+		}
+		buf.WriteByte('\n')
+		got = buf.Bytes()
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, got, 0o644)
+}
+
+// jsonDiff 按 JSON 值比较，一致时返回空串
+func jsonDiff(want, got []byte) (string, error) {
+	var w, g any
+	if err := json.Unmarshal(want, &w); err != nil {
+		return "", fmt.Errorf("golden is not valid JSON: %w", err)
+	}
+	if err := json.Unmarshal(got, &g); err != nil {
+		return "", fmt.Errorf("output is not valid JSON: %w", err)
+	}
+	if equal, diffs := reflecting.DeepEqualDiff(w, g); !equal {
+		return strings.TrimRight(reflecting.FormatDiffs(diffs), "\n"), nil
+	}
+	return "", nil
+}
+
+// lineDiff 逐行列出不一致的行，最多 maxLineDiffs 处
+func lineDiff(want, got []byte) string {
+	wl := strings.Split(string(want), "\n")
+	gl := strings.Split(string(got), "\n")
+	var sb strings.Builder
+	n := 0
+	for i := range max(len(wl), len(gl)) {
+		w, g := "<missing>", "<missing>"
+		if i < len(wl) {
+			w = strconv.Quote(wl[i])
+		}
+		if i < len(gl) {
+			g = strconv.Quote(gl[i])
+		}
+		if w == g {
+			continue
+		}
+		if n == maxLineDiffs {
+			sb.WriteString("...\n")
+			break
+		}
+		n++
+		fmt.Fprintf(&sb, "line %d:\n  want: %s\n  got:  %s\n", i+1, w, g)
+	}
+	if len(wl) != len(gl) {
+		fmt.Fprintf(&sb, "want %d lines, got %d lines\n", len(wl), len(gl))
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
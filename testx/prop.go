@@ -0,0 +1,153 @@
+package testx
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+	"time"
+)
+
+const (
+	defaultCases   = 100
+	defaultMaxSize = 50
+	maxShrinks     = 1000
+)
+
+type propOptions struct {
+	cases   int
+	maxSize int
+	seed    uint64
+}
+
+// PropOption ForAll 的选项
+type PropOption func(*propOptions)
+
+// Cases 生成的用例数，默认 100
+//
+//	@param n int
+//	@return PropOption
+//	@update 2026-10-18 09:57:42
+func Cases(n int) PropOption {
+	return func(o *propOptions) { o.cases = n }
+}
+
+// MaxSize 传给生成器的最大 size，默认 50，< 0 时按 0 处理
+//
+//	@param n int
+//	@return PropOption
+//	@update 2026-10-18 11:50:26
+func MaxSize(n int) PropOption {
+	return func(o *propOptions) { o.maxSize = n }
+}
+
+// Seed 固定随机种子以复现失败，失败报告中会给出所用的种子；默认取当前时间
+//
+//	@param seed uint64
+//	@return PropOption
+//	@update 2026-10-18 09:57:42
+func Seed(seed uint64) PropOption {
+	return func(o *propOptions) { o.seed = seed }
+}
+
+// ForAll 以 gen 生成的值检查属性 prop，prop 返回 false 或 panic 视为失败；
+// 失败时反复用 Shrink 的候选替换反例，报告最简的反例与复现用的种子
+//
+//	@param t testing.TB
+//	@param gen Gen[T]
+//	@param prop func(T) bool
+//	@param opts ...PropOption
+//	@return bool 属性是否对所有用例成立
+//	@update 2026-10-18 09:57:42
+//
+// for example:
+//
+//	testx.ForAll(t, testx.MapOf(testx.String(), testx.Int(0, 9)), func(m map[string]int) bool {
+//		res, _ := commonutils.TransMapWithSkip(m, func(k string, v int) (string, int, bool) { return k, v, v%2 == 0 })
+//		for _, v := range res {
+//			if v%2 == 0 {
+//				return false
+//			}
+//		}
+//		return true
+//	})
+func ForAll[T any](t testing.TB, gen Gen[T], prop func(T) bool, opts ...PropOption) bool {
+	t.Helper()
+	o := propOptions{cases: defaultCases, maxSize: defaultMaxSize, seed: uint64(time.Now().UnixNano())}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	o.maxSize = max(o.maxSize, 0)
+	r := rand.New(rand.NewPCG(o.seed, o.seed))
+	for i := range o.cases {
+		size := o.maxSize
+		if o.cases > 1 {
+			size = i * o.maxSize / (o.cases - 1)
+		}
+		v := gen.Generate(r, size)
+		ok, panicked := check(prop, v)
+		if ok {
+			continue
+		}
+		smallest, smallestPanic, steps := shrink(gen, prop, v, panicked)
+		report := fmt.Sprintf("property failed on case %d (seed %d, rerun with testx.Seed(%d))", i+1, o.seed, o.seed)
+		if steps > 0 {
+			report += fmt.Sprintf("\n\toriginal:       %#v\n\tcounterexample: %#v (shrunk in %d steps)", v, smallest, steps)
+		} else {
+			report += fmt.Sprintf("\n\tcounterexample: %#v", smallest)
+		}
+		if smallestPanic != nil {
+			report += fmt.Sprintf("\n\tpanic:          %v", smallestPanic)
+		}
+		t.Errorf("%s", report)
+		return false
+	}
+	return true
+}
+
+// ForAllSlices 以 elem 组成的随机切片检查属性 prop，同 ForAll(t, SliceOf(elem), prop, opts...)
+//
+//	@param t testing.TB
+//	@param elem Gen[T]
+//	@param prop func([]T) bool
+//	@param opts ...PropOption
+//	@return bool
+//	@update 2026-10-18 09:57:42
+//
+// for example:
+//
+//	testx.ForAllSlices(t, testx.Int(-100, 100), func(s []int) bool {
+//		return len(commonutils.TransSlice(s, strconv.Itoa)) == len(s)
+//	})
+func ForAllSlices[T any](t testing.TB, elem Gen[T], prop func([]T) bool, opts ...PropOption) bool {
+	t.Helper()
+	return ForAll(t, SliceOf(elem), prop, opts...)
+}
+
+// shrink 贪心地用第一个仍然失败的候选替换反例，直到没有候选失败或达到 maxShrinks 次
+func shrink[T any](gen Gen[T], prop func(T) bool, v T, panicked any) (T, any, int) {
+	steps := 0
+	for steps < maxShrinks {
+		progressed := false
+		for _, c := range gen.shrink(v) {
+			if ok, p := check(prop, c); !ok {
+				v, panicked, progressed = c, p, true
+				steps++
+				break
+			}
+		}
+		if !progressed {
+			break
+		}
+	}
+	return v, panicked, steps
+}
+
+// check 执行 prop，panic 视为失败并返回 panic 的值
+func check[T any](prop func(T) bool, v T) (ok bool, panicked any) {
+	defer func() {
+		if r := recover(); r != nil {
+			ok, panicked = false, r
+		}
+	}()
+	return prop(v), nil
+}
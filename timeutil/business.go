@@ -0,0 +1,184 @@
+package timeutil
+
+import "time"
+
+// Calendar 判断某天是否为工作日
+type Calendar interface {
+	IsBusinessDay(day time.Time) bool
+}
+
+// CalendarFunc 函数形式的 Calendar
+type CalendarFunc func(day time.Time) bool
+
+// IsBusinessDay 实现 Calendar
+//
+//	@receiver f CalendarFunc
+//	@param day time.Time
+//	@return bool
+//	@update 2026-10-18 01:34:08
+func (f CalendarFunc) IsBusinessDay(day time.Time) bool { return f(day) }
+
+// Weekdays 周一到周五为工作日的日历，Calendar 参数为 nil 时的默认值
+var Weekdays Calendar = CalendarFunc(func(day time.Time) bool {
+	wd := day.Weekday()
+	return wd != time.Saturday && wd != time.Sunday
+})
+
+// dateKey 按日历日比较的 key，忽略时刻与时区
+type dateKey struct {
+	y int
+	m time.Month
+	d int
+}
+
+func keyOf(t time.Time) dateKey {
+	y, m, d := t.Date()
+	return dateKey{y, m, d}
+}
+
+// Holidays 在 Weekdays 基础上加入节假日与调休补班日的日历
+type Holidays struct {
+	base     Calendar
+	holidays map[dateKey]bool
+	workdays map[dateKey]bool
+}
+
+// NewHolidays 创建节假日日历，days 中的日期（按各自时区的日历日）为休息日
+//
+//	@param days ...time.Time
+//	@return *Holidays
+//	@update 2026-10-18 01:34:08
+//
+// for example:
+//
+//	cal := timeutil.NewHolidays(nationalDay...).WithWorkdays(makeupDay)
+//	due := timeutil.AddBusinessDays(now, 3, cal)
+func NewHolidays(days ...time.Time) *Holidays {
+	h := &Holidays{base: Weekdays, holidays: map[dateKey]bool{}, workdays: map[dateKey]bool{}}
+	return h.Add(days...)
+}
+
+// Add 追加休息日
+//
+//	@receiver h *Holidays
+//	@param days ...time.Time
+//	@return *Holidays
+//	@update 2026-10-18 01:34:08
+func (h *Holidays) Add(days ...time.Time) *Holidays {
+	for _, d := range days {
+		h.holidays[keyOf(d)] = true
+	}
+	return h
+}
+
+// WithWorkdays 追加本应休息但需要上班的日期，如调休的周末，优先于休息日
+//
+//	@receiver h *Holidays
+//	@param days ...time.Time
+//	@return *Holidays
+//	@update 2026-10-18 01:34:08
+func (h *Holidays) WithWorkdays(days ...time.Time) *Holidays {
+	for _, d := range days {
+		h.workdays[keyOf(d)] = true
+	}
+	return h
+}
+
+// WithBase 替换基础日历，默认 Weekdays
+//
+//	@receiver h *Holidays
+//	@param base Calendar
+//	@return *Holidays
+//	@update 2026-10-18 01:34:08
+func (h *Holidays) WithBase(base Calendar) *Holidays {
+	h.base = base
+	return h
+}
+
+// IsBusinessDay 实现 Calendar
+//
+//	@receiver h *Holidays
+//	@param day time.Time
+//	@return bool
+//	@update 2026-10-18 01:34:08
+func (h *Holidays) IsBusinessDay(day time.Time) bool {
+	k := keyOf(day)
+	if h.workdays[k] {
+		return true
+	}
+	if h.holidays[k] {
+		return false
+	}
+	return h.base.IsBusinessDay(day)
+}
+
+// IsBusinessDay 判断 t 所在的日期是否为工作日，cal 为 nil 时使用 Weekdays
+//
+//	@param t time.Time
+//	@param cal Calendar
+//	@return bool
+//	@update 2026-10-18 01:34:08
+func IsBusinessDay(t time.Time, cal Calendar) bool {
+	if cal == nil {
+		cal = Weekdays
+	}
+	return cal.IsBusinessDay(t)
+}
+
+// AddBusinessDays 返回 t 之后（n 为负时之前）第 n 个工作日的同一时刻，n 为 0 时返回 t
+//
+//	@param t time.Time
+//	@param n int
+//	@param cal Calendar 为 nil 时使用 Weekdays
+//	@return time.Time
+//	@update 2026-10-18 01:34:08
+func AddBusinessDays(t time.Time, n int, cal Calendar) time.Time {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for n > 0 {
+		t = t.AddDate(0, 0, step)
+		if IsBusinessDay(t, cal) {
+			n--
+		}
+	}
+	return t
+}
+
+// NextBusinessDay 返回 t 当天（若为工作日）或之后最近的工作日的同一时刻
+//
+//	@param t time.Time
+//	@param cal Calendar 为 nil 时使用 Weekdays
+//	@return time.Time
+//	@update 2026-10-18 01:34:08
+func NextBusinessDay(t time.Time, cal Calendar) time.Time {
+	for !IsBusinessDay(t, cal) {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t
+}
+
+// BusinessDaysBetween 统计 [from, to) 之间按日历日计的工作日数量，from 晚于 to 时返回负数
+//
+//	@param from time.Time
+//	@param to time.Time
+//	@param cal Calendar 为 nil 时使用 Weekdays
+//	@return int
+//	@update 2026-10-18 01:34:08
+func BusinessDaysBetween(from, to time.Time, cal Calendar) int {
+	sign := 1
+	if from.After(to) {
+		from, to, sign = to, from, -1
+	}
+	n := 0
+	for day := range RangeDays(from, to) {
+		if keyOf(day) == keyOf(in(to, from.Location())) {
+			break
+		}
+		if IsBusinessDay(day, cal) {
+			n++
+		}
+	}
+	return sign * n
+}
@@ -0,0 +1,121 @@
+// Package timeutil 时间周期截断、日期区间迭代与工作日计算
+package timeutil
+
+import (
+	"iter"
+	"time"
+)
+
+// in 将 t 转换到 loc，loc 为 nil 时保持 t 原有的时区
+func in(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		return t
+	}
+	return t.In(loc)
+}
+
+// BeginOfDay 返回 t 在 loc 时区当天的 00:00:00，loc 为 nil 时使用 t 的时区
+//
+//	与 t.Truncate(24*time.Hour) 不同，结果按本地日历计算，不受 UTC 偏移影响
+//
+//	@param t time.Time
+//	@param loc *time.Location
+//	@return time.Time
+//	@update 2026-10-18 01:34:08
+func BeginOfDay(t time.Time, loc *time.Location) time.Time {
+	t = in(t, loc)
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// EndOfDay 返回 t 在 loc 时区当天的最后一纳秒
+//
+//	@param t time.Time
+//	@param loc *time.Location
+//	@return time.Time
+//	@update 2026-10-18 01:34:08
+func EndOfDay(t time.Time, loc *time.Location) time.Time {
+	return BeginOfDay(t, loc).AddDate(0, 0, 1).Add(-time.Nanosecond)
+}
+
+// BeginOfWeek 返回 t 在 loc 时区所在周的周一 00:00:00（ISO 8601，周一为一周的第一天）
+//
+//	@param t time.Time
+//	@param loc *time.Location
+//	@return time.Time
+//	@update 2026-10-18 01:34:08
+func BeginOfWeek(t time.Time, loc *time.Location) time.Time {
+	day := BeginOfDay(t, loc)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// EndOfWeek 返回 t 在 loc 时区所在周的周日最后一纳秒
+//
+//	@param t time.Time
+//	@param loc *time.Location
+//	@return time.Time
+//	@update 2026-10-18 01:34:08
+func EndOfWeek(t time.Time, loc *time.Location) time.Time {
+	return BeginOfWeek(t, loc).AddDate(0, 0, 7).Add(-time.Nanosecond)
+}
+
+// BeginOfMonth 返回 t 在 loc 时区所在月的 1 日 00:00:00
+//
+//	@param t time.Time
+//	@param loc *time.Location
+//	@return time.Time
+//	@update 2026-10-18 01:34:08
+func BeginOfMonth(t time.Time, loc *time.Location) time.Time {
+	t = in(t, loc)
+	y, m, _ := t.Date()
+	return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+}
+
+// EndOfMonth 返回 t 在 loc 时区所在月的最后一纳秒
+//
+//	@param t time.Time
+//	@param loc *time.Location
+//	@return time.Time
+//	@update 2026-10-18 01:34:08
+func EndOfMonth(t time.Time, loc *time.Location) time.Time {
+	return BeginOfMonth(t, loc).AddDate(0, 1, 0).Add(-time.Nanosecond)
+}
+
+// RangeDays 依次产出 from 到 to（含）之间每一天的 00:00:00，时区取 from 的时区
+//
+//	按日历日递增，夏令时切换的日子也只产出一次；from 晚于 to 时不产出任何值
+//
+//	@param from time.Time
+//	@param to time.Time
+//	@return iter.Seq[time.Time]
+//	@update 2026-10-18 01:34:08
+//
+// for example:
+//
+//	for day := range timeutil.RangeDays(start, end) {
+//		fmt.Println(day.Format(time.DateOnly))
+//	}
+func RangeDays(from, to time.Time) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		loc := from.Location()
+		last := BeginOfDay(to, loc)
+		for day := BeginOfDay(from, loc); !day.After(last); day = day.AddDate(0, 0, 1) {
+			if !yield(day) {
+				return
+			}
+		}
+	}
+}
+
+// Overlaps 判断半开区间 [a1, a2) 与 [b1, b2) 是否有交集，首尾相接不算重叠
+//
+//	@param a1 time.Time
+//	@param a2 time.Time
+//	@param b1 time.Time
+//	@param b2 time.Time
+//	@return bool
+//	@update 2026-10-18 01:34:08
+func Overlaps(a1, a2, b1, b2 time.Time) bool {
+	return a1.Before(b2) && b1.Before(a2)
+}
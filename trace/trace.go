@@ -0,0 +1,148 @@
+// Package trace 以当前函数名命名的轻量 span 工具，配置 Tracer 后桥接到 OpenTelemetry 等实现，未配置时退化为仅计时
+package trace
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+// Tracer 创建 span 的后端，通常是对 OpenTelemetry trace.Tracer 的几行适配
+//
+//	type otelTracer struct{ t oteltrace.Tracer }
+//
+//	func (o otelTracer) Start(ctx context.Context, name string) (context.Context, trace.Handle) {
+//		ctx, span := o.t.Start(ctx, name)
+//		return ctx, otelSpan{span}
+//	}
+//
+//	type otelSpan struct{ oteltrace.Span }
+//
+//	func (s otelSpan) End(err error) {
+//		if err != nil {
+//			s.RecordError(err)
+//			s.SetStatus(codes.Error, err.Error())
+//		}
+//		s.Span.End()
+//	}
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Handle)
+}
+
+// Handle 后端的 span，End 在 span 结束时调用一次，err 为 SpanErr 记录的错误
+type Handle interface {
+	End(err error)
+}
+
+// Record 一个结束的 span 的信息，交给 SetObserver 设置的回调
+type Record struct {
+	Name     string
+	Start    time.Time
+	Duration time.Duration
+	Err      error
+}
+
+var (
+	tracer   atomic.Pointer[Tracer]
+	observer atomic.Pointer[func(Record)]
+)
+
+// SetTracer 设置全局 Tracer，传入 nil 时 span 不再上报给后端
+//
+//	@param t Tracer
+//	@update 2026-10-18 02:48:13
+func SetTracer(t Tracer) {
+	if t == nil {
+		tracer.Store(nil)
+		return
+	}
+	tracer.Store(&t)
+}
+
+// SetObserver 设置每个 span 结束时的回调，可用于记录耗时指标；传入 nil 时取消
+//
+//	@param fn func(Record)
+//	@update 2026-10-18 02:48:13
+func SetObserver(fn func(Record)) {
+	if fn == nil {
+		observer.Store(nil)
+		return
+	}
+	observer.Store(&fn)
+}
+
+// Span 开始一个 span，返回携带 span 的 ctx 与结束 span 的函数；name 省略时使用调用方的函数名（同 reflecting.GetCurrentFunc）
+//
+//	未配置 Tracer 与 Observer 时只有一次缓存的函数名查询与一次取时间的开销
+//
+//	@param ctx context.Context
+//	@param name ...string
+//	@return context.Context
+//	@return func()
+//	@update 2026-10-18 02:48:13
+//
+// for example:
+//
+//	func (s *Service) Handle(ctx context.Context) {
+//		ctx, end := trace.Span(ctx) // span 名为 xxx.Service.Handle
+//		defer end()
+//		...
+//	}
+func Span(ctx context.Context, name ...string) (context.Context, func()) {
+	ctx, end := start(ctx, spanName(name))
+	return ctx, func() { end(nil) }
+}
+
+// SpanErr 同 Span，结束时将 *errp 记录为 span 的错误，适用于具名返回值
+//
+//	@param ctx context.Context
+//	@param errp *error 可为 nil
+//	@param name ...string
+//	@return context.Context
+//	@return func()
+//	@update 2026-10-18 02:48:13
+//
+// for example:
+//
+//	func load(ctx context.Context) (err error) {
+//		ctx, end := trace.SpanErr(ctx, &err)
+//		defer end()
+//		...
+//	}
+func SpanErr(ctx context.Context, errp *error, name ...string) (context.Context, func()) {
+	ctx, end := start(ctx, spanName(name))
+	return ctx, func() {
+		var err error
+		if errp != nil {
+			err = *errp
+		}
+		end(err)
+	}
+}
+
+// spanName 取显式名称或 Span / SpanErr 的调用方函数名
+func spanName(name []string) string {
+	if len(name) > 0 && name[0] != "" {
+		return name[0]
+	}
+	// 0: GetCurrentFuncDepth 1: spanName 2: Span / SpanErr 3: 调用方
+	return reflecting.GetCurrentFuncDepth(3)
+}
+
+func start(ctx context.Context, name string) (context.Context, func(error)) {
+	begin := time.Now()
+	var h Handle
+	if t := tracer.Load(); t != nil {
+		ctx, h = (*t).Start(ctx, name)
+	}
+	return ctx, func(err error) {
+		if h != nil {
+			h.End(err)
+		}
+		if fn := observer.Load(); fn != nil {
+			(*fn)(Record{Name: name, Start: begin, Duration: time.Since(begin), Err: err})
+		}
+	}
+}
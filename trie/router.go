@@ -0,0 +1,208 @@
+package trie
+
+import (
+	"errors"
+	"iter"
+	"strings"
+)
+
+const (
+	// Wildcard 匹配恰好一个分段
+	Wildcard = "*"
+	// MultiWildcard 匹配零个或多个分段，可出现在任意位置
+	MultiWildcard = "#"
+)
+
+// ErrInvalidPattern 模式为空或包含空分段
+var ErrInvalidPattern = errors.New("trie: invalid pattern")
+
+type rnode[V any] struct {
+	children map[string]*rnode[V]
+	pattern  string
+	val      V
+	ok       bool
+}
+
+// Router 按分隔符分段的路由树，模式中的 * 匹配一个分段、# 匹配零个或多个分段（同 AMQP topic 规则）；
+// 零值可用，分隔符为 "."，非并发安全
+type Router[V any] struct {
+	sep  string
+	root rnode[V]
+	size int
+}
+
+// NewRouter 创建以 sep 分段的路由树，sep 为空时使用 "."
+//
+//	@param sep string
+//	@return *Router[V]
+//	@update 2026-10-18 07:13:45
+//
+// for example:
+//
+//	r := trie.NewRouter[Handler](".")
+//	_ = r.Insert("orders.*.created", onCreated)
+//	_ = r.Insert("orders.#", audit)
+//	for pattern, h := range r.Match("orders.eu.created") {
+//		// orders.*.created, orders.#
+//	}
+func NewRouter[V any](sep string) *Router[V] {
+	return &Router[V]{sep: sep}
+}
+
+func (r *Router[V]) split(s string) []string {
+	sep := r.sep
+	if sep == "" {
+		sep = "."
+	}
+	return strings.Split(s, sep)
+}
+
+// Len 返回模式的数量
+//
+//	@receiver r *Router[V]
+//	@return int
+//	@update 2026-10-18 07:13:45
+func (r *Router[V]) Len() int {
+	return r.size
+}
+
+// Insert 插入模式，模式已存在时替换值
+//
+//	@receiver r *Router[V]
+//	@param pattern string
+//	@param v V
+//	@return error 模式为空或包含空分段时返回 ErrInvalidPattern
+//	@update 2026-10-18 07:13:45
+func (r *Router[V]) Insert(pattern string, v V) error {
+	segs := r.split(pattern)
+	n := &r.root
+	for _, seg := range segs {
+		if seg == "" {
+			return ErrInvalidPattern
+		}
+	}
+	for _, seg := range segs {
+		c, ok := n.children[seg]
+		if !ok {
+			if n.children == nil {
+				n.children = map[string]*rnode[V]{}
+			}
+			c = &rnode[V]{}
+			n.children[seg] = c
+		}
+		n = c
+	}
+	if !n.ok {
+		r.size++
+	}
+	n.pattern, n.val, n.ok = pattern, v, true
+	return nil
+}
+
+// Delete 删除模式，返回模式是否存在
+//
+//	@receiver r *Router[V]
+//	@param pattern string
+//	@return bool
+//	@update 2026-10-18 07:13:45
+func (r *Router[V]) Delete(pattern string) bool {
+	segs := r.split(pattern)
+	path := make([]*rnode[V], 0, len(segs)+1)
+	n := &r.root
+	path = append(path, n)
+	for _, seg := range segs {
+		c, ok := n.children[seg]
+		if !ok {
+			return false
+		}
+		n = c
+		path = append(path, n)
+	}
+	if !n.ok {
+		return false
+	}
+	var zero V
+	n.pattern, n.val, n.ok = "", zero, false
+	r.size--
+	// 自底向上删除不再有用的节点
+	for i := len(segs); i > 0; i-- {
+		c := path[i]
+		if c.ok || len(c.children) > 0 {
+			break
+		}
+		delete(path[i-1].children, segs[i-1])
+	}
+	return true
+}
+
+// Match 遍历与 topic 匹配的模式及其值，字面分段优先于 *，* 优先于 #，因此第一个结果为最具体的匹配
+//
+//	@receiver r *Router[V]
+//	@param topic string
+//	@return iter.Seq2[string, V]
+//	@update 2026-10-18 07:13:45
+func (r *Router[V]) Match(topic string) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		m := matcher[V]{yield: yield}
+		m.match(&r.root, r.split(topic))
+	}
+}
+
+// Lookup 返回与 topic 匹配的最具体的模式的值，规则同 Match
+//
+//	@receiver r *Router[V]
+//	@param topic string
+//	@return V
+//	@return bool
+//	@update 2026-10-18 07:13:45
+func (r *Router[V]) Lookup(topic string) (V, bool) {
+	for _, v := range r.Match(topic) {
+		return v, true
+	}
+	var zero V
+	return zero, false
+}
+
+// matcher 一次匹配的状态，seen 避免多个 # 使同一模式经不同路径重复匹配
+type matcher[V any] struct {
+	yield   func(string, V) bool
+	seen    map[*rnode[V]]bool
+	stopped bool
+}
+
+func (m *matcher[V]) match(n *rnode[V], segs []string) {
+	if m.stopped {
+		return
+	}
+	if len(segs) == 0 {
+		m.emit(n)
+	} else {
+		if c, ok := n.children[segs[0]]; ok && segs[0] != Wildcard && segs[0] != MultiWildcard {
+			m.match(c, segs[1:])
+		}
+		if c, ok := n.children[Wildcard]; ok {
+			m.match(c, segs[1:])
+		}
+	}
+	if c, ok := n.children[MultiWildcard]; ok {
+		for i := 0; i <= len(segs) && !m.stopped; i++ {
+			m.match(c, segs[i:])
+		}
+	}
+}
+
+func (m *matcher[V]) emit(n *rnode[V]) {
+	if !n.ok || m.stopped {
+		return
+	}
+	if m.seen == nil {
+		m.seen = map[*rnode[V]]bool{}
+	}
+	if m.seen[n] {
+		return
+	}
+	m.seen[n] = true
+	if !m.yield(n.pattern, n.val) {
+		m.stopped = true
+	}
+}
@@ -0,0 +1,247 @@
+// Package trie 字符串前缀树：压缩的基数树（最长前缀匹配、前缀遍历）与按分隔符分段、支持通配符的路由树
+package trie
+
+import (
+	"iter"
+	"slices"
+	"strings"
+)
+
+// node 基数树节点，label 为从父节点到本节点的边上的字符串，children 按 label 首字节升序
+type node[V any] struct {
+	label    string
+	children []*node[V]
+	val      V
+	ok       bool
+}
+
+// Trie 基数树（压缩前缀树），以字节为单位比较键；零值可用，非并发安全
+type Trie[V any] struct {
+	root node[V]
+	size int
+}
+
+// child 返回首字节为 b 的子节点及其下标，不存在时返回插入位置与 nil
+func (n *node[V]) child(b byte) (int, *node[V]) {
+	i, found := slices.BinarySearchFunc(n.children, b, func(c *node[V], b byte) int {
+		return int(c.label[0]) - int(b)
+	})
+	if found {
+		return i, n.children[i]
+	}
+	return i, nil
+}
+
+func commonPrefix(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+// Len 返回键的数量
+//
+//	@receiver t *Trie[V]
+//	@return int
+//	@update 2026-10-18 07:13:45
+func (t *Trie[V]) Len() int {
+	return t.size
+}
+
+// Insert 插入键值，键已存在时替换值并返回 false
+//
+//	@receiver t *Trie[V]
+//	@param key string
+//	@param v V
+//	@return bool 是否为新键
+//	@update 2026-10-18 07:13:45
+//
+// for example:
+//
+//	var t trie.Trie[Config]
+//	t.Insert("/api/", apiCfg)
+//	t.Insert("/api/admin/", adminCfg)
+//	prefix, cfg, ok := t.LongestPrefixMatch("/api/admin/users") // "/api/admin/", adminCfg, true
+func (t *Trie[V]) Insert(key string, v V) bool {
+	n := &t.root
+	for {
+		if key == "" {
+			isNew := !n.ok
+			n.val, n.ok = v, true
+			if isNew {
+				t.size++
+			}
+			return isNew
+		}
+		i, c := n.child(key[0])
+		if c == nil {
+			n.children = slices.Insert(n.children, i, &node[V]{label: key, val: v, ok: true})
+			t.size++
+			return true
+		}
+		l := commonPrefix(key, c.label)
+		if l < len(c.label) {
+			// 拆分边：c.label[:l] 成为新的中间节点
+			mid := &node[V]{label: c.label[:l], children: []*node[V]{c}}
+			c.label = c.label[l:]
+			n.children[i] = mid
+			c = mid
+		}
+		key = key[l:]
+		n = c
+	}
+}
+
+// Get 返回键 key 对应的值
+//
+//	@receiver t *Trie[V]
+//	@param key string
+//	@return V
+//	@return bool
+//	@update 2026-10-18 07:13:45
+func (t *Trie[V]) Get(key string) (V, bool) {
+	n := &t.root
+	for key != "" {
+		_, c := n.child(key[0])
+		if c == nil || !strings.HasPrefix(key, c.label) {
+			var zero V
+			return zero, false
+		}
+		key = key[len(c.label):]
+		n = c
+	}
+	return n.val, n.ok
+}
+
+// Delete 删除键 key，返回键是否存在；删除后合并只剩一个子节点的中间节点
+//
+//	@receiver t *Trie[V]
+//	@param key string
+//	@return bool
+//	@update 2026-10-18 07:13:45
+func (t *Trie[V]) Delete(key string) bool {
+	var (
+		parent *node[V]
+		idx    int
+	)
+	n := &t.root
+	for key != "" {
+		i, c := n.child(key[0])
+		if c == nil || !strings.HasPrefix(key, c.label) {
+			return false
+		}
+		parent, idx = n, i
+		key = key[len(c.label):]
+		n = c
+	}
+	if !n.ok {
+		return false
+	}
+	var zero V
+	n.val, n.ok = zero, false
+	t.size--
+
+	if parent == nil {
+		return true
+	}
+	switch len(n.children) {
+	case 0:
+		parent.children = slices.Delete(parent.children, idx, idx+1)
+		// 父节点可能因此只剩一个子节点
+		if parent != &t.root && !parent.ok && len(parent.children) == 1 {
+			parent.mergeChild()
+		}
+	case 1:
+		n.mergeChild()
+	}
+	return true
+}
+
+// mergeChild 将唯一的子节点合并到 n
+func (n *node[V]) mergeChild() {
+	c := n.children[0]
+	n.label += c.label
+	n.children, n.val, n.ok = c.children, c.val, c.ok
+}
+
+// LongestPrefixMatch 返回 s 的最长的、作为键存在的前缀及其值
+//
+//	@receiver t *Trie[V]
+//	@param s string
+//	@return string
+//	@return V
+//	@return bool
+//	@update 2026-10-18 07:13:45
+func (t *Trie[V]) LongestPrefixMatch(s string) (prefix string, v V, ok bool) {
+	n := &t.root
+	if n.ok {
+		v, ok = n.val, true
+	}
+	rest := s
+	for rest != "" {
+		_, c := n.child(rest[0])
+		if c == nil || !strings.HasPrefix(rest, c.label) {
+			break
+		}
+		rest = rest[len(c.label):]
+		n = c
+		if n.ok {
+			prefix, v, ok = s[:len(s)-len(rest)], n.val, true
+		}
+	}
+	return prefix, v, ok
+}
+
+// WalkPrefix 按字典序遍历所有以 prefix 开头的键值对，遍历期间不得修改 Trie
+//
+//	@receiver t *Trie[V]
+//	@param prefix string
+//	@return iter.Seq2[string, V]
+//	@update 2026-10-18 07:13:45
+func (t *Trie[V]) WalkPrefix(prefix string) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		n := &t.root
+		path := ""
+		rest := prefix
+		for rest != "" {
+			_, c := n.child(rest[0])
+			if c == nil {
+				return
+			}
+			switch {
+			case strings.HasPrefix(rest, c.label):
+				rest = rest[len(c.label):]
+			case strings.HasPrefix(c.label, rest):
+				// prefix 止于边的中间
+				rest = ""
+			default:
+				return
+			}
+			path += c.label
+			n = c
+		}
+		walk(n, path, yield)
+	}
+}
+
+// All 按字典序遍历所有键值对
+//
+//	@receiver t *Trie[V]
+//	@return iter.Seq2[string, V]
+//	@update 2026-10-18 07:13:45
+func (t *Trie[V]) All() iter.Seq2[string, V] {
+	return t.WalkPrefix("")
+}
+
+func walk[V any](n *node[V], path string, yield func(string, V) bool) bool {
+	if n.ok && !yield(path, n.val) {
+		return false
+	}
+	for _, c := range n.children {
+		if !walk(c, path+c.label, yield) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,298 @@
+package urlx
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+// ErrUnsupportedType 无法编码或解码的查询参数类型
+var ErrUnsupportedType = errors.New("urlx: unsupported type")
+
+var (
+	timeType            = reflect.TypeFor[time.Time]()
+	durationType        = reflect.TypeFor[time.Duration]()
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// EncodeQuery 将 v 编码为查询参数
+//
+//	v 为结构体（或其指针）时按 `url:"name,omitempty"` tag 编码，未设置 tag 的字段使用字段名，tag 为 "-" 的字段被忽略；
+//	切片编码为重复的参数，time.Time 编码为 RFC 3339，实现了 encoding.TextMarshaler 的类型使用 MarshalText；
+//	v 为 map 时值按同样的规则编码，nil 返回空的 url.Values
+//
+//	@param v any
+//	@return url.Values
+//	@return error
+//	@update 2026-10-18 05:03:44
+func EncodeQuery(v any) (url.Values, error) {
+	res := url.Values{}
+	switch x := v.(type) {
+	case nil:
+		return res, nil
+	case url.Values:
+		for k, vs := range x {
+			res[k] = append([]string(nil), vs...)
+		}
+		return res, nil
+	case map[string]string:
+		for k, s := range x {
+			res.Set(k, s)
+		}
+		return res, nil
+	case map[string][]string:
+		for k, vs := range x {
+			res[k] = append([]string(nil), vs...)
+		}
+		return res, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return res, nil
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, rv.Type())
+		}
+		iter := rv.MapRange()
+		for iter.Next() {
+			vs, err := formatValues(iter.Value())
+			if err != nil {
+				return nil, fmt.Errorf("urlx: query %q: %w", iter.Key().String(), err)
+			}
+			res[iter.Key().String()] = vs
+		}
+	case reflect.Struct:
+		for _, f := range reflecting.FieldsOfType(rv.Type(), "url") {
+			fv := f.Value(rv)
+			if !fv.IsValid() || (f.HasOption("omitempty") && fv.IsZero()) {
+				continue
+			}
+			vs, err := formatValues(fv)
+			if err != nil {
+				return nil, fmt.Errorf("urlx: field %s: %w", f.Name, err)
+			}
+			if len(vs) > 0 {
+				res[f.Key()] = vs
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, rv.Type())
+	}
+	return res, nil
+}
+
+// formatValues 将单个值或切片格式化为参数值列表，nil 指针与 nil 接口返回空
+func formatValues(v reflect.Value) ([]string, error) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	if (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Type().Elem().Kind() != reflect.Uint8 {
+		res := make([]string, 0, v.Len())
+		for i := range v.Len() {
+			vs, err := formatValues(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, vs...)
+		}
+		return res, nil
+	}
+	s, err := formatScalar(v)
+	if err != nil {
+		return nil, err
+	}
+	return []string{s}, nil
+}
+
+func formatScalar(v reflect.Value) (string, error) {
+	switch v.Type() {
+	case timeType:
+		return v.Interface().(time.Time).Format(time.RFC3339Nano), nil
+	case durationType:
+		return time.Duration(v.Int()).String(), nil
+	}
+	if v.Type().Implements(textMarshalerType) {
+		b, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(b), err
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), nil
+	case reflect.Slice:
+		// []byte
+		return string(v.Bytes()), nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnsupportedType, v.Type())
+}
+
+// ParseQueryInto 解析原始查询串（可带前导 ?）并写入 dst 指向的结构体，规则见 DecodeQuery
+//
+//	@param rawQuery string
+//	@param dst any
+//	@return error
+//	@update 2026-10-18 05:03:44
+//
+// for example:
+//
+//	var q struct {
+//		Page int      `url:"page"`
+//		Tags []string `url:"tag"`
+//		From *time.Time `url:"from"`
+//	}
+//	err := urlx.ParseQueryInto(r.URL.RawQuery, &q)
+func ParseQueryInto(rawQuery string, dst any) error {
+	values, err := url.ParseQuery(strings.TrimPrefix(rawQuery, "?"))
+	if err != nil {
+		return fmt.Errorf("urlx: %w", err)
+	}
+	return DecodeQuery(values, dst)
+}
+
+// DecodeQuery 将 values 写入 dst 指向的结构体，字段按 url tag（或字段名，忽略大小写）匹配；
+// 未出现的参数保持字段原值，切片字段接收全部同名参数，其余字段取第一个值；
+// 数字按十进制解析，time.Time 按 RFC 3339 解析，实现了 encoding.TextUnmarshaler 的类型使用 UnmarshalText
+//
+//	@param values url.Values
+//	@param dst any
+//	@return error
+//	@update 2026-10-18 05:03:44
+func DecodeQuery(values url.Values, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("urlx: expect non-nil pointer to struct, got %T", dst)
+	}
+	sv := rv.Elem()
+	for _, f := range reflecting.FieldsOfType(sv.Type(), "url") {
+		vs, ok := lookup(values, f.Key())
+		if !ok {
+			continue
+		}
+		if err := parseInto(f.ValueAlloc(sv), vs); err != nil {
+			return fmt.Errorf("urlx: query %q: %w", f.Key(), err)
+		}
+	}
+	return nil
+}
+
+func lookup(values url.Values, key string) ([]string, bool) {
+	if vs, ok := values[key]; ok {
+		return vs, true
+	}
+	for k, vs := range values {
+		if strings.EqualFold(k, key) {
+			return vs, true
+		}
+	}
+	return nil, false
+}
+
+// parseInto 将参数值写入字段，切片接收全部值
+func parseInto(v reflect.Value, vs []string) error {
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 && !reflect.PointerTo(v.Type()).Implements(textUnmarshalerType) {
+		res := reflect.MakeSlice(v.Type(), len(vs), len(vs))
+		for i, s := range vs {
+			if err := parseScalar(res.Index(i), s); err != nil {
+				return err
+			}
+		}
+		v.Set(res)
+		return nil
+	}
+	if len(vs) == 0 {
+		return nil
+	}
+	return parseScalar(v, vs[0])
+}
+
+func parseScalar(v reflect.Value, s string) error {
+	if v.Kind() == reflect.Pointer {
+		elem := reflect.New(v.Type().Elem())
+		if err := parseScalar(elem.Elem(), s); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	}
+	switch v.Type() {
+	case timeType:
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	case durationType:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		// 仅出现参数名（如 ?debug）视为 true
+		if s == "" {
+			v.SetBool(true)
+			return nil
+		}
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	case reflect.Slice:
+		v.SetBytes([]byte(s))
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedType, v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+// Package validate 基于 struct tag 的字段校验，如 `validate:"required,min=1,max=10,oneof=a b"`
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/BetaGoRobot/go_utils/reflecting"
+)
+
+// ErrUnknownRule tag 中使用了未注册的规则
+var ErrUnknownRule = errors.New("validate: unknown rule")
+
+// FieldError 单个字段未通过某条规则
+type FieldError struct {
+	Field string // 字段路径，按 json tag 命名，如 items[0].name
+	Rule  string
+	Param string
+	Value any
+	msg   string
+}
+
+// Error 返回 "字段路径 描述" 形式的说明，如 "name must be at least 1 characters"
+//
+//	@receiver e *FieldError
+//	@return string
+//	@update 2026-10-18 00:38:02
+func (e *FieldError) Error() string {
+	return e.Field + " " + e.msg
+}
+
+// Errors 一次校验中的全部字段错误
+type Errors []*FieldError
+
+// Error 以 "; " 连接全部字段错误
+//
+//	@receiver es Errors
+//	@return string
+//	@update 2026-10-18 00:38:02
+func (es Errors) Error() string {
+	msgs := make([]string, len(es))
+	for i, e := range es {
+		msgs[i] = e.Error()
+	}
+	return "validate: " + strings.Join(msgs, "; ")
+}
+
+// Struct 按 validate tag 校验结构体（或结构体指针）v，嵌套的结构体、结构体指针以及它们的切片与 map 会被递归校验
+//
+//	内置规则：
+//	- required：非零值；切片、map 须非空
+//	- omitempty：零值时跳过其余规则
+//	- min=n、max=n、len=n：数字比较数值，字符串比较字符数，切片、map、数组比较长度
+//	- oneof=a b c：值的字符串形式须为其中之一
+//	- email、url：格式校验
+//	tag 为 "-" 的字段不校验，也不会递归
+//
+//	@param v any
+//	@return error 未通过时为 Errors；tag 中有未注册的规则时 errors.Is(err, ErrUnknownRule)
+//	@update 2026-10-18 00:38:02
+//
+// for example:
+//
+//	type CreateUser struct {
+//		Name  string   `json:"name" validate:"required,max=32"`
+//		Role  string   `json:"role" validate:"oneof=admin member"`
+//		Email string   `json:"email" validate:"omitempty,email"`
+//		Tags  []string `json:"tags" validate:"max=10"`
+//	}
+//	if err := validate.Struct(req); err != nil {
+//		var fes validate.Errors
+//		errors.As(err, &fes)
+//	}
+func Struct(v any) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("validate: expect struct, got %s", rv.Type())
+	}
+	var c checker
+	if err := c.structValue(rv, ""); err != nil {
+		return err
+	}
+	if len(c.errs) == 0 {
+		return nil
+	}
+	return c.errs
+}
+
+type checker struct {
+	errs Errors
+}
+
+func (c *checker) structValue(sv reflect.Value, prefix string) error {
+	for _, f := range fieldsOf(sv.Type()) {
+		fv := f.Value(sv)
+		if !fv.IsValid() {
+			continue
+		}
+		path := joinField(prefix, fieldName(f))
+		if err := c.field(fv, path, f.StructTag.Get("validate")); err != nil {
+			return err
+		}
+		if err := c.nested(fv, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fieldsOf 返回需要校验的字段；不按 validate tag 取字段名（规则文本会被当作名字去重），validate:"-" 的字段被排除
+func fieldsOf(t reflect.Type) []reflecting.FieldInfo {
+	var res []reflecting.FieldInfo
+	for _, f := range reflecting.FieldsOfType(t, "") {
+		if f.StructTag.Get("validate") != "-" {
+			res = append(res, f)
+		}
+	}
+	return res
+}
+
+// fieldName 字段在错误中的名字：优先使用 json tag 的名称
+func fieldName(f reflecting.FieldInfo) string {
+	if name, _ := reflecting.ParseTag(f.StructTag.Get("json")); name != "" && name != "-" {
+		return name
+	}
+	return f.Name
+}
+
+func joinField(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// field 依次执行 tag 中的规则
+func (c *checker) field(v reflect.Value, path, tag string) error {
+	if tag == "" {
+		return nil
+	}
+	for rule := range strings.SplitSeq(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		switch name {
+		case "":
+			continue
+		case "omitempty":
+			if isEmpty(v) {
+				return nil
+			}
+			continue
+		}
+		fn, ok := lookup(name)
+		if !ok {
+			return fmt.Errorf("%w %q on field %s", ErrUnknownRule, name, path)
+		}
+		if msg, ok := fn(v, param); !ok {
+			c.errs = append(c.errs, &FieldError{Field: path, Rule: name, Param: param, Value: valueOf(v), msg: msg})
+			if name == "required" {
+				// 缺失的字段不再报告其余规则
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// nested 递归校验结构体、结构体指针及其容器中的元素
+func (c *checker) nested(v reflect.Value, path string) error {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		if !hasRules(v.Type()) {
+			return nil
+		}
+		return c.structValue(v, path)
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			if err := c.nested(v.Index(i), path+"["+strconv.Itoa(i)+"]"); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := c.nested(iter.Value(), path+"["+fmt.Sprint(iter.Key().Interface())+"]"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hasRules 判断结构体类型（含嵌套）中是否有需要校验的字段，避免遍历 time.Time 等无关类型
+func hasRules(t reflect.Type) bool {
+	return hasRulesVisit(t, map[reflect.Type]bool{})
+}
+
+func hasRulesVisit(t reflect.Type, visiting map[reflect.Type]bool) bool {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || visiting[t] {
+		return false
+	}
+	visiting[t] = true
+	for _, f := range fieldsOf(t) {
+		if f.StructTag.Get("validate") != "" || hasRulesVisit(f.Type, visiting) {
+			return true
+		}
+	}
+	return false
+}
+
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+// valueOf 返回字段值，指针取其指向的值
+func valueOf(v reflect.Value) any {
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.CanInterface() {
+		return v.Interface()
+	}
+	return nil
+}